@@ -0,0 +1,108 @@
+package golan
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Assertion builtins fail with an AssertionError located at the call.
+
+func assertionError(args []Value, n int, format string, a ...any) error {
+	msg := fmt.Sprintf(format, a...)
+	if len(args) > n {
+		msg = fmt.Sprintf("%v: %s", args[n], msg)
+	}
+	return &Error{Kind: "AssertionError", Message: msg}
+}
+
+// inspect formats v for assertion messages, quoting strings.
+func inspect(v Value) string {
+	return inspectIn(v, map[Value]bool{})
+}
+
+// inspectIn is inspect within the formatting of the values in seen, which
+// are abbreviated when they come up again so that cycles end.
+func inspectIn(v Value, seen map[Value]bool) string {
+	switch x := v.(type) {
+	case String:
+		return strconv.Quote(string(x))
+	case *List:
+		return x.format(seen)
+	case *Map:
+		return x.format(seen)
+	case *StructInstance:
+		return x.format(seen)
+	case *ClassInstance:
+		return x.format(seen)
+	}
+	return fmt.Sprint(v)
+}
+
+// valuesEqual compares x and y by compareWith on e, which may be nil.
+func valuesEqual(e *Engine, x Value, y Value) bool {
+	r, err := compareWith(e, x, y)
+	return err == nil && r == CMP_EQ
+}
+
+func builtinAssert(e *Engine, args []Value) (Value, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1..2)", len(args))
+	}
+	if !ValueTest(args[0]) {
+		return nil, assertionError(args, 1, "assertion failed")
+	}
+	return Undefined{}, nil
+}
+
+func builtinAssertEq(e *Engine, args []Value) (Value, error) {
+	if len(args) < 2 || len(args) > 3 {
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected 2..3)", len(args))
+	}
+	if !valuesEqual(e, args[0], args[1]) {
+		return nil, assertionError(args, 2, "expected %s, got %s", inspect(args[1]), inspect(args[0]))
+	}
+	return Undefined{}, nil
+}
+
+func builtinAssertNe(e *Engine, args []Value) (Value, error) {
+	if len(args) < 2 || len(args) > 3 {
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected 2..3)", len(args))
+	}
+	if valuesEqual(e, args[0], args[1]) {
+		return nil, assertionError(args, 2, "expected a value other than %s", inspect(args[1]))
+	}
+	return Undefined{}, nil
+}
+
+// builtinAssertRaises calls a function without arguments and returns the
+// error it raises; with a kind, the error must be of that kind.
+func builtinAssertRaises(e *Engine, args []Value) (Value, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1..2)", len(args))
+	}
+	switch args[0].(type) {
+	case NativeFunction, *Closure, CallableValue:
+	default:
+		return nil, fmt.Errorf("not a function - %v(%T)", args[0], args[0])
+	}
+	var kind String
+	if len(args) == 2 {
+		k, ok := args[1].(String)
+		if !ok {
+			return nil, fmt.Errorf("not a String - %v(%T)", args[1], args[1])
+		}
+		kind = k
+	}
+	_, err := e.Call(args[0], nil)
+	if err == nil {
+		if kind != "" {
+			return nil, &Error{Kind: "AssertionError", Message: fmt.Sprintf("expected %s to be raised", kind)}
+		}
+		return nil, &Error{Kind: "AssertionError", Message: "expected an error to be raised"}
+	}
+	x := errorAt(err, "RuntimeError", nil)
+	if kind != "" && x.Kind != string(kind) {
+		return nil, &Error{Kind: "AssertionError", Message: fmt.Sprintf("expected %s to be raised, got %s", kind, x)}
+	}
+	return x, nil
+}
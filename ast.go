@@ -3,21 +3,27 @@ package golan
 import (
 	"fmt"
 	"io"
+	"strings"
 )
 
+// Position locates a node in its source. Line numbers and columns are
+// 1-origin.
 type Position struct {
-	FirstLineno int
-	FirstColumn int
-	LastLineno  int
-	LastColumn  int
+	Source      string `json:"source"`
+	FirstLineno int    `json:"first_lineno"`
+	FirstColumn int    `json:"first_column"`
+	LastLineno  int    `json:"last_lineno"`
+	LastColumn  int    `json:"last_column"`
 }
 
 func (p *Position) String() string {
-	return fmt.Sprintf(
-		"(%d:%d,%d:%d)",
-		p.FirstLineno, p.FirstColumn,
-		p.LastLineno, p.LastColumn,
-	)
+	return fmt.Sprintf("%s:%d:%d", p.Source, p.FirstLineno, p.FirstColumn)
+}
+
+// Range formats the span of p as (first line:column,last line:column),
+// the form DumpTree prints.
+func (p *Position) Range() string {
+	return fmt.Sprintf("(%d:%d,%d:%d)", p.FirstLineno, p.FirstColumn, p.LastLineno, p.LastColumn)
 }
 
 func DumpTree(tree Node, output io.Writer) {
@@ -38,9 +44,62 @@ type Node interface {
 	dump(output io.Writer, indentLevel int)
 }
 
+type Comment struct {
+	position *Position
+	Text     string
+}
+
+func (c *Comment) Position() *Position { return c.position }
+
+func (c *Comment) dump(w io.Writer, n int) {
+	indent(w, n)
+	fmt.Fprintf(w, "%T:%v: %#v\n", c, c.position.Range(), c.Text)
+}
+
 type Block struct {
 	position   *Position
 	statements []Node
+	comments   []*Comment
+	leading    map[Node][]*Comment
+	trailing   map[Node]*Comment
+	dangling   []*Comment
+}
+
+// Comments returns all comments of the source in order. Only the root
+// block of a parsed tree has comments.
+func (b *Block) Comments() []*Comment { return b.comments }
+
+// LeadingComments returns the comments placed before statement, which
+// must be one of the statements of b.
+func (b *Block) LeadingComments(statement Node) []*Comment { return b.leading[statement] }
+
+// TrailingComment returns the comment following statement on its last
+// line, or nil.
+func (b *Block) TrailingComment(statement Node) *Comment { return b.trailing[statement] }
+
+// DanglingComments returns the comments after the last statement of b.
+func (b *Block) DanglingComments() []*Comment { return b.dangling }
+
+func (b *Block) attach(c *Comment) {
+	for _, s := range b.statements {
+		p := s.Position()
+		if p.LastLineno == c.position.FirstLineno && p.LastColumn < c.position.FirstColumn && b.trailing[s] == nil {
+			if b.trailing == nil {
+				b.trailing = map[Node]*Comment{}
+			}
+			b.trailing[s] = c
+			return
+		}
+		if c.position.FirstLineno < p.LastLineno ||
+			(c.position.FirstLineno == p.LastLineno && c.position.FirstColumn < p.LastColumn) {
+			if b.leading == nil {
+				b.leading = map[Node][]*Comment{}
+			}
+			b.leading[s] = append(b.leading[s], c)
+			return
+		}
+	}
+	b.dangling = append(b.dangling, c)
 }
 
 func (b *Block) Position() *Position {
@@ -50,6 +109,7 @@ func (b *Block) Position() *Position {
 
 	var p Position
 	if len(b.statements) > 0 {
+		p.Source = b.statements[0].Position().Source
 		p.FirstLineno = b.statements[0].Position().FirstLineno
 		p.FirstColumn = b.statements[0].Position().FirstColumn
 		p.LastLineno = b.statements[len(b.statements)-1].Position().LastLineno
@@ -60,7 +120,7 @@ func (b *Block) Position() *Position {
 
 func (b *Block) dump(w io.Writer, n int) {
 	indent(w, n)
-	fmt.Fprintf(w, "%T:%v\n", b, b.Position())
+	fmt.Fprintf(w, "%T:%v\n", b, b.Position().Range())
 	for _, s := range b.statements {
 		s.dump(w, n+1)
 	}
@@ -80,7 +140,7 @@ func (w *While) Position() *Position { return w.position }
 
 func (w *While) dump(o io.Writer, n int) {
 	indent(o, n)
-	fmt.Fprintf(o, "%T:%v\n", w, w.position)
+	fmt.Fprintf(o, "%T:%v\n", w, w.position.Range())
 	indent(o, n+1)
 	fmt.Fprintln(o, "[condition]")
 	w.Condition.dump(o, n+1)
@@ -89,6 +149,87 @@ func (w *While) dump(o io.Writer, n int) {
 	w.Body.dump(o, n+1)
 }
 
+type For struct {
+	position *Position
+	Variable Node
+	Iterable Node
+	Body     Node
+}
+
+func (f *For) Position() *Position { return f.position }
+
+func (f *For) dump(o io.Writer, n int) {
+	indent(o, n)
+	fmt.Fprintf(o, "%T:%v\n", f, f.position.Range())
+	indent(o, n+1)
+	fmt.Fprintln(o, "[variable]")
+	f.Variable.dump(o, n+1)
+	indent(o, n+1)
+	fmt.Fprintln(o, "[iterable]")
+	f.Iterable.dump(o, n+1)
+	indent(o, n+1)
+	fmt.Fprintln(o, "[body]")
+	f.Body.dump(o, n+1)
+}
+
+// Select waits until one of its cases can send or receive and runs the
+// body of that case, or runs Default at once if none can. Default is nil
+// when omitted.
+type Select struct {
+	position *Position
+	Cases    []*SelectCase
+	Default  Node
+}
+
+func (s *Select) Position() *Position { return s.position }
+
+func (s *Select) dump(o io.Writer, n int) {
+	indent(o, n)
+	fmt.Fprintf(o, "%T:%v\n", s, s.position.Range())
+	for _, c := range s.Cases {
+		c.dump(o, n+1)
+	}
+	if s.Default != nil {
+		indent(o, n+1)
+		fmt.Fprintln(o, "[default]")
+		s.Default.dump(o, n+1)
+	}
+}
+
+// SelectCase is a case of a Select. It sends Value on Channel, or
+// receives from Channel when Value is nil, binding the value received to
+// Variable unless it is nil.
+type SelectCase struct {
+	position *Position
+	Variable Node
+	Channel  Node
+	Value    Node
+	Body     Node
+}
+
+func (c *SelectCase) Position() *Position { return c.position }
+
+func (c *SelectCase) dump(o io.Writer, n int) {
+	indent(o, n)
+	fmt.Fprintf(o, "%T:%v\n", c, c.position.Range())
+	if c.Variable != nil {
+		indent(o, n+1)
+		fmt.Fprintln(o, "[variable]")
+		c.Variable.dump(o, n+1)
+	}
+	indent(o, n+1)
+	fmt.Fprintln(o, "[channel]")
+	c.Channel.dump(o, n+1)
+	if c.Value != nil {
+		indent(o, n+1)
+		fmt.Fprintln(o, "[value]")
+		c.Value.dump(o, n+1)
+	}
+	indent(o, n+1)
+	fmt.Fprintln(o, "[body]")
+	c.Body.dump(o, n+1)
+}
+
 type If struct {
 	position *Position
 	Test     Node
@@ -100,7 +241,7 @@ func (i *If) Position() *Position { return i.position }
 
 func (i *If) dump(o io.Writer, n int) {
 	indent(o, n)
-	fmt.Fprintf(o, "%T:%v\n", i, i.position)
+	fmt.Fprintf(o, "%T:%v\n", i, i.position.Range())
 	indent(o, n+1)
 	fmt.Fprintln(o, "[test]")
 	i.Test.dump(o, n+1)
@@ -114,17 +255,204 @@ func (i *If) dump(o io.Writer, n int) {
 	}
 }
 
+type Begin struct {
+	position *Position
+	Body     Node
+	Variable Node
+	Rescue   Node
+	Ensure   Node
+}
+
+func (b *Begin) Position() *Position { return b.position }
+
+func (b *Begin) dump(o io.Writer, n int) {
+	indent(o, n)
+	fmt.Fprintf(o, "%T:%v\n", b, b.position.Range())
+	indent(o, n+1)
+	fmt.Fprintln(o, "[body]")
+	b.Body.dump(o, n+1)
+	if b.Variable != nil {
+		indent(o, n+1)
+		fmt.Fprintln(o, "[variable]")
+		b.Variable.dump(o, n+1)
+	}
+	if b.Rescue != nil {
+		indent(o, n+1)
+		fmt.Fprintln(o, "[rescue]")
+		b.Rescue.dump(o, n+1)
+	}
+	if b.Ensure != nil {
+		indent(o, n+1)
+		fmt.Fprintln(o, "[ensure]")
+		b.Ensure.dump(o, n+1)
+	}
+}
+
+type Raise struct {
+	position   *Position
+	Expression Node
+}
+
+func (r *Raise) Position() *Position { return r.position }
+
+func (r *Raise) dump(w io.Writer, n int) {
+	indent(w, n)
+	fmt.Fprintf(w, "%T:%v:\n", r, r.position.Range())
+	r.Expression.dump(w, n+1)
+}
+
+type Import struct {
+	position *Position
+	Path     string
+	Name     string
+}
+
+func (i *Import) Position() *Position { return i.position }
+
+func (i *Import) dump(w io.Writer, n int) {
+	indent(w, n)
+	fmt.Fprintf(w, "%T:%v: %#v as %v\n", i, i.position.Range(), i.Path, i.Name)
+}
+
+// Struct declares a record type with the named fields, binding Name to
+// its constructor.
+type Struct struct {
+	position *Position
+	Name     string
+	Fields   []string
+}
+
+func (s *Struct) Position() *Position { return s.position }
+
+func (s *Struct) dump(w io.Writer, n int) {
+	indent(w, n)
+	fmt.Fprintf(w, "%T:%v: %v %v\n", s, s.position.Range(), s.Name, strings.Join(s.Fields, ", "))
+}
+
+// Class declares a class, binding Name to its constructor. Body is a
+// block of the named functions that are its methods, each taking the
+// instance as its first parameter; init, if defined, initializes new
+// instances.
+type Class struct {
+	position *Position
+	Name     string
+	Body     Node
+}
+
+func (c *Class) Position() *Position { return c.position }
+
+func (c *Class) dump(w io.Writer, n int) {
+	indent(w, n)
+	fmt.Fprintf(w, "%T:%v: %v\n", c, c.position.Range(), c.Name)
+	c.Body.dump(w, n+1)
+}
+
+// Methods returns the method definitions of the class in source order.
+func (c *Class) Methods() []*Function {
+	r := []*Function{}
+	if b, ok := c.Body.(*Block); ok {
+		for _, s := range b.statements {
+			if f, ok := s.(*Function); ok {
+				r = append(r, f)
+			}
+		}
+	}
+	return r
+}
+
+// Function is a function definition. Name is empty for a function
+// literal. Type annotations of parameters and the result are empty when
+// omitted.
+type Function struct {
+	position   *Position
+	Name       string
+	Parameters []*Parameter
+	ReturnType string
+	Body       Node
+}
+
+func (f *Function) Position() *Position { return f.position }
+
+func (f *Function) dump(w io.Writer, n int) {
+	indent(w, n)
+	fmt.Fprintf(w, "%T:%v: %v", f, f.position.Range(), f.Name)
+	if f.ReturnType != "" {
+		fmt.Fprintf(w, " -> %v", f.ReturnType)
+	}
+	fmt.Fprintln(w)
+	for _, x := range f.Parameters {
+		x.dump(w, n+1)
+	}
+	f.Body.dump(w, n+1)
+}
+
+type Parameter struct {
+	position *Position
+	Name     string
+	Type     string
+}
+
+func (p *Parameter) Position() *Position { return p.position }
+
+func (p *Parameter) dump(w io.Writer, n int) {
+	indent(w, n)
+	fmt.Fprintf(w, "%T:%v: %v", p, p.position.Range(), p.Name)
+	if p.Type != "" {
+		fmt.Fprintf(w, ": %v", p.Type)
+	}
+	fmt.Fprintln(w)
+}
+
+// Return leaves the innermost function. Expression is nil for a bare
+// return.
+type Return struct {
+	position   *Position
+	Expression Node
+}
+
+func (r *Return) Position() *Position { return r.position }
+
+func (r *Return) dump(w io.Writer, n int) {
+	indent(w, n)
+	fmt.Fprintf(w, "%T:%v:\n", r, r.position.Range())
+	if r.Expression != nil {
+		r.Expression.dump(w, n+1)
+	}
+}
+
+// Yield suspends the generator running the innermost function and hands
+// the value of Expression to its consumer.
+type Yield struct {
+	position   *Position
+	Expression Node
+}
+
+func (y *Yield) Position() *Position { return y.position }
+
+func (y *Yield) dump(w io.Writer, n int) {
+	indent(w, n)
+	fmt.Fprintf(w, "%T:%v:\n", y, y.position.Range())
+	y.Expression.dump(w, n+1)
+}
+
+// Assign binds the value of Expression to Destination. Type is the
+// annotation of `x: type = ...`, empty when omitted.
 type Assign struct {
 	position    *Position
 	Destination Node
 	Expression  Node
+	Type        string
 }
 
 func (a *Assign) Position() *Position { return a.position }
 
 func (a *Assign) dump(w io.Writer, n int) {
 	indent(w, n)
-	fmt.Fprintf(w, "%T:%v\n", a, a.position)
+	if a.Type != "" {
+		fmt.Fprintf(w, "%T:%v: %v\n", a, a.position.Range(), a.Type)
+	} else {
+		fmt.Fprintf(w, "%T:%v\n", a, a.position.Range())
+	}
 	indent(w, n+1)
 	fmt.Fprintln(w, "[destination]")
 	a.Destination.dump(w, n+1)
@@ -135,7 +463,7 @@ func (a *Assign) dump(w io.Writer, n int) {
 
 func dumpBinary(w io.Writer, n int, node Node, l Node, r Node) {
 	indent(w, n)
-	fmt.Fprintf(w, "%T:%v\n", node, node.Position())
+	fmt.Fprintf(w, "%T:%v\n", node, node.Position().Range())
 	indent(w, n+1)
 	fmt.Fprintln(w, "[left]")
 	l.dump(w, n+1)
@@ -216,6 +544,39 @@ func (L *LessThan) dump(w io.Writer, n int) {
 	dumpBinary(w, n, L, L.Left, L.Right)
 }
 
+type In struct {
+	position *Position
+	Left     Node
+	Right    Node
+}
+
+func (i *In) Position() *Position { return i.position }
+
+func (i *In) dump(w io.Writer, n int) {
+	dumpBinary(w, n, i, i.Left, i.Right)
+}
+
+// RangeLiteral is start..stop, or start...stop when Exclusive.
+type RangeLiteral struct {
+	position  *Position
+	Start     Node
+	Stop      Node
+	Exclusive bool
+}
+
+func (r *RangeLiteral) Position() *Position { return r.position }
+
+func (r *RangeLiteral) dump(w io.Writer, n int) {
+	indent(w, n)
+	fmt.Fprintf(w, "%T:%v: exclusive=%v\n", r, r.position.Range(), r.Exclusive)
+	indent(w, n+1)
+	fmt.Fprintln(w, "[start]")
+	r.Start.dump(w, n+1)
+	indent(w, n+1)
+	fmt.Fprintln(w, "[stop]")
+	r.Stop.dump(w, n+1)
+}
+
 type Addition struct {
 	position *Position
 	Left     Node
@@ -295,7 +656,7 @@ func (p *Plus) Position() *Position { return p.position }
 
 func (p *Plus) dump(w io.Writer, n int) {
 	indent(w, n)
-	fmt.Fprintf(w, "%T:%v:\n", p, p.position)
+	fmt.Fprintf(w, "%T:%v:\n", p, p.position.Range())
 	p.Expression.dump(w, n+1)
 }
 
@@ -313,10 +674,25 @@ func (m *Minus) Position() *Position { return m.position }
 
 func (m *Minus) dump(w io.Writer, n int) {
 	indent(w, n)
-	fmt.Fprintf(w, "%T:%v:\n", m, m.position)
+	fmt.Fprintf(w, "%T:%v:\n", m, m.position.Range())
 	m.Expression.dump(w, n+1)
 }
 
+// Spawn starts Call, an Apply, as a task running concurrently with the
+// rest of the script.
+type Spawn struct {
+	position *Position
+	Call     Node
+}
+
+func (s *Spawn) Position() *Position { return s.position }
+
+func (s *Spawn) dump(w io.Writer, n int) {
+	indent(w, n)
+	fmt.Fprintf(w, "%T:%v:\n", s, s.position.Range())
+	s.Call.dump(w, n+1)
+}
+
 type Not struct {
 	position   *Position
 	Expression Node
@@ -331,7 +707,7 @@ func (n *Not) Position() *Position { return n.position }
 
 func (n *Not) dump(w io.Writer, lv int) {
 	indent(w, lv)
-	fmt.Fprintf(w, "%T:%v:\n", n, n.position)
+	fmt.Fprintf(w, "%T:%v:\n", n, n.position.Range())
 	n.Expression.dump(w, lv+1)
 }
 
@@ -344,7 +720,7 @@ func (b *BooleanLiteral) Position() *Position { return b.position }
 
 func (b *BooleanLiteral) dump(w io.Writer, n int) {
 	indent(w, n)
-	fmt.Fprintf(w, "%T:%v: %v\n", b, b.position, b.Value)
+	fmt.Fprintf(w, "%T:%v: %v\n", b, b.position.Range(), b.Value)
 }
 
 type IntLiteral struct {
@@ -356,7 +732,7 @@ func (i *IntLiteral) Position() *Position { return i.position }
 
 func (i *IntLiteral) dump(w io.Writer, n int) {
 	indent(w, n)
-	fmt.Fprintf(w, "%T:%v: %v\n", i, i.position, i.Value)
+	fmt.Fprintf(w, "%T:%v: %v\n", i, i.position.Range(), i.Value)
 }
 
 type FloatLiteral struct {
@@ -368,7 +744,7 @@ func (f *FloatLiteral) Position() *Position { return f.position }
 
 func (f *FloatLiteral) dump(w io.Writer, n int) {
 	indent(w, n)
-	fmt.Fprintf(w, "%T:%v: %v\n", f, f.position, f.Value)
+	fmt.Fprintf(w, "%T:%v: %v\n", f, f.position.Range(), f.Value)
 }
 
 type StringLiteral struct {
@@ -380,7 +756,22 @@ func (s *StringLiteral) Position() *Position { return s.position }
 
 func (s *StringLiteral) dump(w io.Writer, n int) {
 	indent(w, n)
-	fmt.Fprintf(w, "%T:%v: %#v\n", s, s.position, s.Value)
+	fmt.Fprintf(w, "%T:%v: %#v\n", s, s.position.Range(), s.Value)
+}
+
+type ListLiteral struct {
+	position *Position
+	Elements []Node
+}
+
+func (l *ListLiteral) Position() *Position { return l.position }
+
+func (l *ListLiteral) dump(w io.Writer, n int) {
+	indent(w, n)
+	fmt.Fprintf(w, "%T:%v:\n", l, l.position.Range())
+	for _, x := range l.Elements {
+		x.dump(w, n+1)
+	}
 }
 
 type Identifier struct {
@@ -392,7 +783,7 @@ func (i *Identifier) Position() *Position { return i.position }
 
 func (i *Identifier) dump(w io.Writer, n int) {
 	indent(w, n)
-	fmt.Fprintf(w, "%T:%v: %v\n", i, i.position, i.Name)
+	fmt.Fprintf(w, "%T:%v: %v\n", i, i.position.Range(), i.Name)
 }
 
 type Apply struct {
@@ -405,9 +796,38 @@ func (a *Apply) Position() *Position { return a.position }
 
 func (a *Apply) dump(w io.Writer, n int) {
 	indent(w, n)
-	fmt.Fprintf(w, "%T:%v:\n", a, a.position)
+	fmt.Fprintf(w, "%T:%v:\n", a, a.position.Range())
 	a.function.dump(w, n+1)
 	for _, x := range a.arguments {
 		x.dump(w, n+1)
 	}
 }
+
+type Attribute struct {
+	position *Position
+	Receiver Node
+	Name     string
+}
+
+func (a *Attribute) Position() *Position { return a.position }
+
+func (a *Attribute) dump(w io.Writer, n int) {
+	indent(w, n)
+	fmt.Fprintf(w, "%T:%v: %v\n", a, a.position.Range(), a.Name)
+	a.Receiver.dump(w, n+1)
+}
+
+type Index struct {
+	position *Position
+	Receiver Node
+	Key      Node
+}
+
+func (i *Index) Position() *Position { return i.position }
+
+func (i *Index) dump(w io.Writer, n int) {
+	indent(w, n)
+	fmt.Fprintf(w, "%T:%v:\n", i, i.position.Range())
+	i.Receiver.dump(w, n+1)
+	i.Key.dump(w, n+1)
+}
@@ -0,0 +1,69 @@
+package golan_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+func TestPosition(t *testing.T) {
+	tests := []struct {
+		p    golan.Position
+		str  string
+		span string
+	}{
+		{golan.Position{Source: "a.gl", FirstLineno: 1, FirstColumn: 1, LastLineno: 1, LastColumn: 5}, "a.gl:1:1", "(1:1,1:5)"},
+		{golan.Position{Source: "dir/b.gl", FirstLineno: 3, FirstColumn: 7, LastLineno: 9, LastColumn: 2}, "dir/b.gl:3:7", "(3:7,9:2)"},
+		{golan.Position{Source: "<string>", FirstLineno: 2, FirstColumn: 4, LastLineno: 2, LastColumn: 4}, "<string>:2:4", "(2:4,2:4)"},
+	}
+	for _, tt := range tests {
+		if s := tt.p.String(); s != tt.str {
+			t.Errorf("String() = %q, want %q", s, tt.str)
+		}
+		if s := tt.p.Range(); s != tt.span {
+			t.Errorf("Range() = %q, want %q", s, tt.span)
+		}
+	}
+}
+
+func TestParseSourceNames(t *testing.T) {
+	tree, err := golan.ParseSource("lib/m.gl", "x = 1\ny = x +\n")
+	if err == nil {
+		t.Fatalf("parsed %v", tree)
+	}
+	if !strings.HasPrefix(err.Error(), "lib/m.gl:") {
+		t.Errorf("error = %q, want it to name lib/m.gl", err)
+	}
+	tree, err = golan.ParseSource("lib/m.gl", "x = 1\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := tree.(*golan.Block).Statements()[0].Position(); p.String() != "lib/m.gl:1:1" {
+		t.Errorf("position = %v, want lib/m.gl:1:1", p)
+	}
+}
+
+func TestDumpTreeRanges(t *testing.T) {
+	tree, err := golan.ParseSource("a.gl", "x = 1 + 2\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	golan.DumpTree(tree, &out)
+	want := `*golan.Block:(1:1,1:9)
+  *golan.Assign:(1:1,1:9)
+    [destination]
+    *golan.Identifier:(1:1,1:1): x
+    [expression]
+    *golan.Addition:(1:5,1:9)
+      [left]
+      *golan.IntLiteral:(1:5,1:5): 1
+      [right]
+      *golan.IntLiteral:(1:9,1:9): 2
+`
+	if out.String() != want {
+		t.Errorf("dump =\n%s\nwant\n%s", out.String(), want)
+	}
+}
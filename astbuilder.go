@@ -3,17 +3,21 @@ package golan
 import (
 	"errors"
 	"io"
+	"path"
 	"strconv"
 	"strings"
 )
 
 type ASTBuilder struct {
-	buffer  string
-	stack   []Node
-	lastErr error
+	source   string
+	buffer   string
+	stack    []Node
+	comments []*Comment
+	lastErr  error
 }
 
-func (b *ASTBuilder) ASTBuilderInit(buffer string) {
+func (b *ASTBuilder) ASTBuilderInit(source string, buffer string) {
+	b.source = source
 	b.buffer = buffer
 	b.push(&Block{statements: []Node{}})
 }
@@ -40,7 +44,48 @@ func (b *ASTBuilder) Finish() Node {
 	if len(b.stack) == 0 {
 		return nil
 	}
-	return b.pop()
+	tree := b.pop()
+	if root, ok := tree.(*Block); ok {
+		attachComments(root, b.comments)
+	}
+	return tree
+}
+
+// attachComments gives each comment to the innermost block enclosing it,
+// where it becomes a leading comment of the statement it precedes or
+// overlaps, a trailing comment of the statement ending on its line, or a
+// dangling comment of the block.
+func attachComments(root *Block, comments []*Comment) {
+	root.comments = comments
+	blocks := []*Block{}
+	Inspect(root, func(n Node) bool {
+		if x, ok := n.(*Block); ok && x != root {
+			blocks = append(blocks, x)
+		}
+		return true
+	})
+	for _, c := range comments {
+		owner := root
+		for _, x := range blocks {
+			if encloses(x.Position(), c.position) {
+				owner = x
+			}
+		}
+		owner.attach(c)
+	}
+}
+
+func encloses(outer *Position, inner *Position) bool {
+	if inner.FirstLineno < outer.FirstLineno ||
+		(inner.FirstLineno == outer.FirstLineno && inner.FirstColumn <= outer.FirstColumn) {
+		return false
+	}
+	return inner.LastLineno < outer.LastLineno ||
+		(inner.LastLineno == outer.LastLineno && inner.LastColumn < outer.LastColumn)
+}
+
+func (b *ASTBuilder) position(fl, fc, ll, lc int) *Position {
+	return &Position{b.source, fl, fc, ll, lc}
 }
 
 func (b *ASTBuilder) push(n Node) {
@@ -59,7 +104,7 @@ func (b *ASTBuilder) pop() Node {
 func (b *ASTBuilder) PushBlock(beg int) {
 	fl, fc := calcPosition(b.buffer, beg)
 	b.push(&Block{
-		position:   &Position{fl, fc, 0, 0},
+		position:   b.position(fl, fc, 0, 0),
 		statements: []Node{},
 	})
 }
@@ -79,7 +124,7 @@ func (b *ASTBuilder) PopBlock() {
 
 func (b *ASTBuilder) PushWhile(beg int) {
 	fl, fc := calcPosition(b.buffer, beg)
-	b.push(&While{position: &Position{fl, fc, 0, 0}})
+	b.push(&While{position: b.position(fl, fc, 0, 0)})
 }
 
 func (b *ASTBuilder) CompleteWhile() {
@@ -95,6 +140,103 @@ func (b *ASTBuilder) CompleteWhile() {
 	b.push(current)
 }
 
+func (b *ASTBuilder) PushFor(beg int) {
+	fl, fc := calcPosition(b.buffer, beg)
+	b.push(&For{position: b.position(fl, fc, 0, 0)})
+}
+
+func (b *ASTBuilder) CompleteFor() {
+	body := b.pop()
+	iterable := b.pop()
+	variable := b.pop()
+	f := b.pop().(*For)
+	current := b.pop().(*Block)
+	f.position.LastLineno = body.Position().LastLineno
+	f.position.LastColumn = body.Position().LastColumn
+	f.Variable = variable
+	f.Iterable = iterable
+	f.Body = body
+	current.Add(f)
+	b.push(current)
+}
+
+func (b *ASTBuilder) PushSelect(beg int) {
+	fl, fc := calcPosition(b.buffer, beg)
+	b.push(&Select{position: b.position(fl, fc, 0, 0), Cases: []*SelectCase{}})
+}
+
+func (b *ASTBuilder) PushSelectCase(beg int) {
+	fl, fc := calcPosition(b.buffer, beg)
+	b.push(&SelectCase{position: b.position(fl, fc, 0, 0)})
+}
+
+func (b *ASTBuilder) CompleteSelectSend() {
+	v := b.pop()
+	ch := b.pop()
+	c := b.pop().(*SelectCase)
+	c.Channel = ch
+	c.Value = v
+	b.push(c)
+}
+
+func (b *ASTBuilder) CompleteSelectRecv() {
+	ch := b.pop()
+	x := b.pop()
+	c, ok := x.(*SelectCase)
+	if !ok {
+		c = b.pop().(*SelectCase)
+		c.Variable = x
+	}
+	c.Channel = ch
+	b.push(c)
+}
+
+func (b *ASTBuilder) CompleteSelectCase() {
+	body := b.pop()
+	c := b.pop().(*SelectCase)
+	c.position.LastLineno = body.Position().LastLineno
+	c.position.LastColumn = body.Position().LastColumn
+	c.Body = body
+	b.push(c)
+}
+
+// selectDefault is the default clause of a select being built.
+type selectDefault struct {
+	body Node
+}
+
+func (d *selectDefault) Position() *Position { return d.body.Position() }
+
+func (*selectDefault) dump(o io.Writer, n int) { panic("selectDefault is temprary node object") }
+
+func (b *ASTBuilder) PushSelectDefault() {
+	b.push(&selectDefault{b.pop()})
+}
+
+func (b *ASTBuilder) CompleteSelect(end int) {
+	var def Node
+	cases := []*SelectCase{}
+	var s *Select
+	for s == nil {
+		switch x := b.pop().(type) {
+		case *selectDefault:
+			def = x.body
+		case *SelectCase:
+			cases = append([]*SelectCase{x}, cases...)
+		case *Select:
+			s = x
+		}
+	}
+	ll, lc := calcPosition(b.buffer, end-1)
+	s.position.LastLineno = ll
+	s.position.LastColumn = lc
+	s.Cases = cases
+	s.Default = def
+	current := b.pop().(*Block)
+	current.Add(s)
+	b.push(current)
+}
+
 type ifPart struct {
 	position *Position
 	test     Node
@@ -107,7 +249,7 @@ func (*ifPart) dump(o io.Writer, n int) { panic("ifPart is temprary node object"
 
 func (b *ASTBuilder) PushIfPart(beg int) {
 	fl, fc := calcPosition(b.buffer, beg)
-	b.push(&ifPart{position: &Position{fl, fc, 0, 0}})
+	b.push(&ifPart{position: b.position(fl, fc, 0, 0)})
 }
 
 func (b *ASTBuilder) CompleteIfPart() {
@@ -133,7 +275,7 @@ func (*elsifPart) dump(o io.Writer, n int) { panic("elsifPart is temprary node o
 
 func (b *ASTBuilder) PushElsifPart(beg int) {
 	fl, fc := calcPosition(b.buffer, beg)
-	b.push(&elsifPart{position: &Position{fl, fc, 0, 0}})
+	b.push(&elsifPart{position: b.position(fl, fc, 0, 0)})
 }
 
 func (b *ASTBuilder) CompleteElsifPart() {
@@ -158,7 +300,7 @@ func (*elsePart) dump(o io.Writer, n int) { panic("elsePart is temprary node obj
 
 func (b *ASTBuilder) PushElsePart(beg int) {
 	fl, fc := calcPosition(b.buffer, beg)
-	b.push(&elsePart{position: &Position{fl, fc, 0, 0}})
+	b.push(&elsePart{position: b.position(fl, fc, 0, 0)})
 }
 
 func (b *ASTBuilder) CompleteElsePart() {
@@ -216,6 +358,215 @@ func (b *ASTBuilder) CompleteIf() {
 	b.push(current)
 }
 
+func (b *ASTBuilder) PushBegin(beg int) {
+	fl, fc := calcPosition(b.buffer, beg)
+	b.push(&Begin{position: b.position(fl, fc, 0, 0)})
+}
+
+func (b *ASTBuilder) CompleteBeginBody() {
+	body := b.pop()
+	bg := b.pop().(*Begin)
+	bg.position.LastLineno = body.Position().LastLineno
+	bg.position.LastColumn = body.Position().LastColumn
+	bg.Body = body
+	b.push(bg)
+}
+
+type rescuePart struct {
+	position *Position
+	variable Node
+	then     Node
+}
+
+func (p *rescuePart) Position() *Position { return p.position }
+
+func (*rescuePart) dump(o io.Writer, n int) { panic("rescuePart is temprary node object") }
+
+func (b *ASTBuilder) PushRescuePart(beg int) {
+	fl, fc := calcPosition(b.buffer, beg)
+	b.push(&rescuePart{position: b.position(fl, fc, 0, 0)})
+}
+
+func (b *ASTBuilder) CompleteRescuePart() {
+	then := b.pop()
+	last := b.pop()
+	var variable Node
+	if id, ok := last.(*Identifier); ok {
+		variable = id
+		last = b.pop()
+	}
+	p := last.(*rescuePart)
+	p.position.LastLineno = then.Position().LastLineno
+	p.position.LastColumn = then.Position().LastColumn
+	p.variable = variable
+	p.then = then
+	b.push(p)
+}
+
+type ensurePart struct {
+	position *Position
+	then     Node
+}
+
+func (p *ensurePart) Position() *Position { return p.position }
+
+func (*ensurePart) dump(o io.Writer, n int) { panic("ensurePart is temprary node object") }
+
+func (b *ASTBuilder) PushEnsurePart(beg int) {
+	fl, fc := calcPosition(b.buffer, beg)
+	b.push(&ensurePart{position: b.position(fl, fc, 0, 0)})
+}
+
+func (b *ASTBuilder) CompleteEnsurePart() {
+	then := b.pop()
+	p := b.pop().(*ensurePart)
+	p.position.LastLineno = then.Position().LastLineno
+	p.position.LastColumn = then.Position().LastColumn
+	p.then = then
+	b.push(p)
+}
+
+func (b *ASTBuilder) CompleteBegin() {
+	var last Node
+
+	last = b.pop()
+	var ensure *ensurePart
+	if p, ok := last.(*ensurePart); ok {
+		ensure = p
+		last = b.pop()
+	}
+	var rescue *rescuePart
+	if p, ok := last.(*rescuePart); ok {
+		rescue = p
+		last = b.pop()
+	}
+
+	bg := last.(*Begin)
+	if rescue != nil {
+		bg.Variable = rescue.variable
+		bg.Rescue = rescue.then
+		bg.position.LastLineno = rescue.position.LastLineno
+		bg.position.LastColumn = rescue.position.LastColumn
+	}
+	if ensure != nil {
+		bg.Ensure = ensure.then
+		bg.position.LastLineno = ensure.position.LastLineno
+		bg.position.LastColumn = ensure.position.LastColumn
+	}
+	current := b.pop().(*Block)
+	current.Add(bg)
+	b.push(current)
+}
+
+func (b *ASTBuilder) PushRaise(beg int) {
+	fl, fc := calcPosition(b.buffer, beg)
+	b.push(&Raise{position: b.position(fl, fc, 0, 0)})
+}
+
+func (b *ASTBuilder) CompleteRaise() {
+	x := b.pop()
+	r := b.pop().(*Raise)
+	r.position.LastLineno = x.Position().LastLineno
+	r.position.LastColumn = x.Position().LastColumn
+	r.Expression = x
+	current := b.pop().(*Block)
+	current.Add(r)
+	b.push(current)
+}
+
+func (b *ASTBuilder) PushYield(beg int) {
+	fl, fc := calcPosition(b.buffer, beg)
+	b.push(&Yield{position: b.position(fl, fc, 0, 0)})
+}
+
+func (b *ASTBuilder) CompleteYield() {
+	x := b.pop()
+	y := b.pop().(*Yield)
+	y.position.LastLineno = x.Position().LastLineno
+	y.position.LastColumn = x.Position().LastColumn
+	y.Expression = x
+	current := b.pop().(*Block)
+	current.Add(y)
+	b.push(current)
+}
+
+func (b *ASTBuilder) PushImport(beg int) {
+	fl, fc := calcPosition(b.buffer, beg)
+	b.push(&Import{position: b.position(fl, fc, 0, 0)})
+}
+
+func (b *ASTBuilder) CompleteImport() {
+	x := b.pop().(*StringLiteral)
+	i := b.pop().(*Import)
+	i.position.LastLineno = x.Position().LastLineno
+	i.position.LastColumn = x.Position().LastColumn
+	i.Path = x.Value
+	i.Name = strings.TrimSuffix(path.Base(x.Value), path.Ext(x.Value))
+	current := b.pop().(*Block)
+	current.Add(i)
+	b.push(current)
+}
+
+func (b *ASTBuilder) PushStruct(beg int) {
+	fl, fc := calcPosition(b.buffer, beg)
+	b.push(&Struct{position: b.position(fl, fc, 0, 0), Fields: []string{}})
+}
+
+func (b *ASTBuilder) CompleteStruct(end int) {
+	fields := []*Identifier{}
+	var s *Struct
+	for s == nil {
+		switch x := b.pop().(type) {
+		case *Identifier:
+			fields = append([]*Identifier{x}, fields...)
+		case *Struct:
+			s = x
+		}
+	}
+	ll, lc := calcPosition(b.buffer, end-1)
+	s.position.LastLineno = ll
+	s.position.LastColumn = lc
+	s.Name = fields[0].Name
+	for _, f := range fields[1:] {
+		for _, name := range s.Fields {
+			if f.Name == name {
+				b.Raise(&SyntaxError{Position: f.position, Near: f.Name})
+			}
+		}
+		s.Fields = append(s.Fields, f.Name)
+	}
+	current := b.pop().(*Block)
+	current.Add(s)
+	b.push(current)
+}
+
+func (b *ASTBuilder) PushClass(beg int) {
+	fl, fc := calcPosition(b.buffer, beg)
+	b.push(&Class{position: b.position(fl, fc, 0, 0)})
+}
+
+func (b *ASTBuilder) CompleteClass() {
+	body := b.pop().(*Block)
+	id := b.pop().(*Identifier)
+	c := b.pop().(*Class)
+	for _, s := range body.statements {
+		f, ok := s.(*Function)
+		if !ok || f.Name == "" {
+			b.Raise(&SyntaxError{Position: s.Position(), Near: "class", Message: "class " + id.Name + " may only define methods"})
+		}
+		if len(f.Parameters) == 0 {
+			b.Raise(&SyntaxError{Position: f.position, Near: f.Name, Message: "method " + f.Name + " must take self as its first parameter"})
+		}
+	}
+	c.position.LastLineno = body.position.LastLineno
+	c.position.LastColumn = body.position.LastColumn
+	c.Name = id.Name
+	c.Body = body
+	current := b.pop().(*Block)
+	current.Add(c)
+	b.push(current)
+}
+
 func (b *ASTBuilder) PushExpressionStatement() {
 	x := b.pop()
 	block := b.pop().(*Block)
@@ -226,10 +577,15 @@ func (b *ASTBuilder) PushExpressionStatement() {
 func (b *ASTBuilder) PushAssign(op string) {
 	r := b.pop()
 	l := b.pop()
-	p := &Position{
+	switch l.(type) {
+	case *Identifier, *Attribute:
+	default:
+		b.Raise(&SyntaxError{Position: l.Position(), Near: op + "="})
+	}
+	p := b.position(
 		l.Position().FirstLineno, l.Position().FirstColumn,
 		r.Position().LastLineno, r.Position().LastColumn,
-	}
+	)
 	switch op {
 	case "":
 		// do nothing
@@ -244,16 +600,116 @@ func (b *ASTBuilder) PushAssign(op string) {
 	case "%":
 		r = &Modulo{p, l, r}
 	}
-	b.push(&Assign{p, l, r})
+	b.push(&Assign{p, l, r, ""})
+}
+
+// typeName is a type annotation waiting to be attached.
+type typeName struct {
+	position *Position
+	name     string
+}
+
+func (t *typeName) Position() *Position { return t.position }
+
+func (*typeName) dump(o io.Writer, n int) { panic("typeName is temprary node object") }
+
+func (b *ASTBuilder) PushTypeName(beg int, end int, text string) {
+	fl, fc := calcPosition(b.buffer, beg)
+	ll, lc := calcPosition(b.buffer, end-1)
+	b.push(&typeName{b.position(fl, fc, ll, lc), text})
+}
+
+func (b *ASTBuilder) PushTypedAssign() {
+	r := b.pop()
+	t := b.pop().(*typeName)
+	l := b.pop()
+	p := b.position(
+		l.Position().FirstLineno, l.Position().FirstColumn,
+		r.Position().LastLineno, r.Position().LastColumn,
+	)
+	b.push(&Assign{p, l, r, t.name})
+}
+
+func (b *ASTBuilder) PushFunction(beg int) {
+	fl, fc := calcPosition(b.buffer, beg)
+	b.push(&Function{position: b.position(fl, fc, 0, 0), Parameters: []*Parameter{}})
+}
+
+func (b *ASTBuilder) PushParameter() {
+	x := b.pop()
+	tn, typed := x.(*typeName)
+	if typed {
+		x = b.pop()
+	}
+	id := x.(*Identifier)
+	p := *id.position
+	var t string
+	if typed {
+		t = tn.name
+		p.LastLineno, p.LastColumn = tn.position.LastLineno, tn.position.LastColumn
+	}
+	b.push(&Parameter{&p, id.Name, t})
+}
+
+func (b *ASTBuilder) CompleteFunction() {
+	body := b.pop()
+	last := b.pop()
+	var result string
+	if t, ok := last.(*typeName); ok {
+		result = t.name
+		last = b.pop()
+	}
+	params := []*Parameter{}
+	for {
+		p, ok := last.(*Parameter)
+		if !ok {
+			break
+		}
+		params = append([]*Parameter{p}, params...)
+		last = b.pop()
+	}
+	var name string
+	if id, ok := last.(*Identifier); ok {
+		name = id.Name
+		last = b.pop()
+	}
+	f := last.(*Function)
+	f.position.LastLineno = body.Position().LastLineno
+	f.position.LastColumn = body.Position().LastColumn
+	f.Name = name
+	f.Parameters = params
+	f.ReturnType = result
+	f.Body = body
+	b.push(f)
+}
+
+func (b *ASTBuilder) PushReturn(beg int, end int) {
+	fl, fc := calcPosition(b.buffer, beg)
+	ll, lc := calcPosition(b.buffer, end-1)
+	b.push(&Return{position: b.position(fl, fc, ll, lc)})
+}
+
+func (b *ASTBuilder) CompleteReturn() {
+	x := b.pop()
+	r, ok := x.(*Return)
+	if !ok {
+		r = b.pop().(*Return)
+		r.position.LastLineno = x.Position().LastLineno
+		r.position.LastColumn = x.Position().LastColumn
+		r.Expression = x
+	}
+	current := b.pop().(*Block)
+	current.Add(r)
+	b.push(current)
 }
 
 func (b *ASTBuilder) PushBinOp(op string) {
 	y := b.pop()
 	x := b.pop()
-	p := &Position{
+	p := b.position(
 		x.Position().FirstLineno, x.Position().FirstColumn,
 		y.Position().LastLineno, y.Position().LastColumn,
-	}
+	)
 	switch op {
 	case "==":
 		b.push(&Equal{p, x, y})
@@ -267,6 +723,12 @@ func (b *ASTBuilder) PushBinOp(op string) {
 		b.push(&GreaterThan{p, x, y})
 	case "<":
 		b.push(&LessThan{p, x, y})
+	case "in":
+		b.push(&In{p, x, y})
+	case "..":
+		b.push(&RangeLiteral{p, x, y, false})
+	case "...":
+		b.push(&RangeLiteral{p, x, y, true})
 	case "+":
 		b.push(&Addition{p, x, y})
 	case "-":
@@ -286,16 +748,33 @@ func (b *ASTBuilder) PushUnaryOp(beg int, _ int, op string) {
 	fl, fc := calcPosition(b.buffer, beg)
 	switch op {
 	case "+":
-		b.push(&Plus{&Position{fl, fc, 0, 0}, nil})
+		b.push(&Plus{b.position(fl, fc, 0, 0), nil})
 	case "-":
-		b.push(&Minus{&Position{fl, fc, 0, 0}, nil})
+		b.push(&Minus{b.position(fl, fc, 0, 0), nil})
 	case "!":
-		b.push(&Not{&Position{fl, fc, 0, 0}, nil})
+		b.push(&Not{b.position(fl, fc, 0, 0), nil})
 	default:
 		panic("must not happen")
 	}
 }
 
+func (b *ASTBuilder) PushSpawn(beg int) {
+	fl, fc := calcPosition(b.buffer, beg)
+	b.push(&Spawn{position: b.position(fl, fc, 0, 0)})
+}
+
+func (b *ASTBuilder) CompleteSpawn() {
+	x := b.pop()
+	s := b.pop().(*Spawn)
+	if _, ok := x.(*Apply); !ok {
+		b.Raise(&SyntaxError{Position: x.Position(), Near: "spawn"})
+	}
+	s.position.LastLineno = x.Position().LastLineno
+	s.position.LastColumn = x.Position().LastColumn
+	s.Call = x
+	b.push(s)
+}
+
 func (b *ASTBuilder) CompleteUnary() {
 	x := b.pop()
 	u := b.pop()
@@ -309,33 +788,33 @@ func (b *ASTBuilder) CompleteUnary() {
 func (b *ASTBuilder) PushBooleanLiteral(beg int, end int, val bool) {
 	fl, fc := calcPosition(b.buffer, beg)
 	ll, lc := calcPosition(b.buffer, end-1)
-	b.push(&BooleanLiteral{&Position{fl, fc, ll, lc}, val})
+	b.push(&BooleanLiteral{b.position(fl, fc, ll, lc), val})
 }
 
 func (b *ASTBuilder) PushFloatLiteral(beg int, end int, src string) {
 	fl, fc := calcPosition(b.buffer, beg)
 	ll, lc := calcPosition(b.buffer, end-1)
 	f64, _ := strconv.ParseFloat(src, 64)
-	b.push(&FloatLiteral{&Position{fl, fc, ll, lc}, f64})
+	b.push(&FloatLiteral{b.position(fl, fc, ll, lc), f64})
 }
 
 func (b *ASTBuilder) PushIntLiteral(beg int, end int, src string) {
 	fl, fc := calcPosition(b.buffer, beg)
 	ll, lc := calcPosition(b.buffer, end-1)
 	i64, _ := strconv.ParseInt(src, 10, 64)
-	b.push(&IntLiteral{&Position{fl, fc, ll, lc}, i64})
+	b.push(&IntLiteral{b.position(fl, fc, ll, lc), i64})
 }
 
 func (b *ASTBuilder) PushStringLiteral(beg int, end int, s string) {
 	fl, fc := calcPosition(b.buffer, beg)
 	ll, lc := calcPosition(b.buffer, end-1)
-	b.push(&StringLiteral{&Position{fl, fc, ll, lc}, s})
+	b.push(&StringLiteral{b.position(fl, fc, ll, lc), s})
 }
 
 func (b *ASTBuilder) PushIdentifier(beg int, end int, src string) {
 	fl, fc := calcPosition(b.buffer, beg)
 	ll, lc := calcPosition(b.buffer, end-1)
-	b.push(&Identifier{&Position{fl, fc, ll, lc}, src})
+	b.push(&Identifier{b.position(fl, fc, ll, lc), src})
 }
 
 type incompleteApply struct {
@@ -351,7 +830,7 @@ func (*incompleteApply) dump(o io.Writer, n int) { panic("incompleteApply is tem
 func (b *ASTBuilder) PushApply() {
 	f := b.pop()
 	b.push(&incompleteApply{
-		position:  &Position{f.Position().FirstLineno, f.Position().FirstColumn, 0, 0},
+		position:  b.position(f.Position().FirstLineno, f.Position().FirstColumn, 0, 0),
 		function:  f,
 		arguments: []Node{},
 	})
@@ -381,9 +860,73 @@ func (b *ASTBuilder) CompleteApply(end int) {
 	})
 }
 
+func (b *ASTBuilder) PushAttribute() {
+	id := b.pop().(*Identifier)
+	r := b.pop()
+	b.push(&Attribute{
+		position: b.position(
+			r.Position().FirstLineno, r.Position().FirstColumn,
+			id.Position().LastLineno, id.Position().LastColumn,
+		),
+		Receiver: r,
+		Name:     id.Name,
+	})
+}
+
+type incompleteList struct {
+	position *Position
+}
+
+func (l *incompleteList) Position() *Position { return l.position }
+
+func (*incompleteList) dump(o io.Writer, n int) { panic("incompleteList is temprary node object") }
+
+func (b *ASTBuilder) PushList(beg int) {
+	fl, fc := calcPosition(b.buffer, beg)
+	b.push(&incompleteList{b.position(fl, fc, 0, 0)})
+}
+
+func (b *ASTBuilder) CompleteList(end int) {
+	buf := []Node{}
+	var l *incompleteList
+	for {
+		x := b.pop()
+		if v, ok := x.(*incompleteList); ok {
+			l = v
+			break
+		}
+		buf = append(buf, x)
+	}
+	ll, lc := calcPosition(b.buffer, end-1)
+	l.position.LastLineno = ll
+	l.position.LastColumn = lc
+	elements := []Node{}
+	for i := len(buf) - 1; i >= 0; i-- {
+		elements = append(elements, buf[i])
+	}
+	b.push(&ListLiteral{l.position, elements})
+}
+
+func (b *ASTBuilder) PushIndex(end int) {
+	k := b.pop()
+	r := b.pop()
+	ll, lc := calcPosition(b.buffer, end-1)
+	b.push(&Index{
+		position: b.position(r.Position().FirstLineno, r.Position().FirstColumn, ll, lc),
+		Receiver: r,
+		Key:      k,
+	})
+}
+
+func (b *ASTBuilder) PushComment(beg int, end int, text string) {
+	fl, fc := calcPosition(b.buffer, beg)
+	ll, lc := calcPosition(b.buffer, end-1)
+	b.comments = append(b.comments, &Comment{b.position(fl, fc, ll, lc), strings.TrimRight(text, " \t")})
+}
+
 func calcPosition(src string, pos int) (int, int) {
 	a := strings.Split(src[:pos], "\n")
-	lineno := len(a) - 1
-	column := len(a[lineno])
+	lineno := len(a)
+	column := len(a[lineno-1]) + 1
 	return lineno, column
 }
@@ -0,0 +1,195 @@
+package golan
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Channel passes values between tasks. Once it is closed, receiving
+// returns the values still buffered and then Undefined.
+type Channel struct {
+	ch chan Value
+}
+
+// NewChannel returns a channel buffering up to capacity values; sends on
+// an unbuffered channel wait for a receiver.
+func NewChannel(capacity int) *Channel {
+	return &Channel{ch: make(chan Value, capacity)}
+}
+
+func (c *Channel) String() string {
+	return fmt.Sprintf("#<channel %d/%d>", len(c.ch), cap(c.ch))
+}
+
+// OpLen returns the number of values buffered.
+func (c *Channel) OpLen() int { return len(c.ch) }
+
+// OpIter receives values until the channel is closed and drained.
+func (c *Channel) OpIter() (Iterator, error) {
+	return IteratorFunc(func() (Value, bool, error) {
+		v, ok := <-c.ch
+		return v, ok, nil
+	}), nil
+}
+
+func channelError(message string) *Error {
+	return &Error{Kind: "RuntimeError", Message: message}
+}
+
+// Send sends v, waiting for room in the buffer or for a receiver. Sending
+// on a closed channel is an error.
+func (c *Channel) Send(v Value) (err error) {
+	defer func() {
+		if recover() != nil {
+			err = channelError("send on closed channel")
+		}
+	}()
+	c.ch <- v
+	return nil
+}
+
+// Recv receives a value, waiting for one to be sent. It returns Undefined
+// and false when the channel is closed and drained.
+func (c *Channel) Recv() (Value, bool) {
+	v, ok := <-c.ch
+	if !ok {
+		return Undefined{}, false
+	}
+	return v, true
+}
+
+// Close closes the channel. Closing it twice is an error.
+func (c *Channel) Close() (err error) {
+	defer func() {
+		if recover() != nil {
+			err = channelError("close of closed channel")
+		}
+	}()
+	close(c.ch)
+	return nil
+}
+
+func channelArgument(v Value) (*Channel, error) {
+	c, ok := v.(*Channel)
+	if !ok {
+		return nil, fmt.Errorf("not a Channel - %v(%T)", v, v)
+	}
+	return c, nil
+}
+
+func builtinChannel(e *Engine, args []Value) (Value, error) {
+	switch len(args) {
+	case 0:
+		return NewChannel(0), nil
+	case 1:
+		n, ok := args[0].(Integer)
+		if !ok {
+			return nil, fmt.Errorf("not an Integer - %v(%T)", args[0], args[0])
+		}
+		if n < 0 {
+			return nil, &Error{Kind: "ValueError", Message: fmt.Sprintf("negative channel capacity - %d", n)}
+		}
+		return NewChannel(int(n)), nil
+	}
+	return nil, fmt.Errorf("wrong number of arguments (given %d, expected 0..1)", len(args))
+}
+
+func builtinSend(e *Engine, args []Value) (Value, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected 2)", len(args))
+	}
+	c, err := channelArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Send(args[1]); err != nil {
+		return nil, err
+	}
+	return Undefined{}, nil
+}
+
+func builtinRecv(e *Engine, args []Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1)", len(args))
+	}
+	c, err := channelArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+	v, _ := c.Recv()
+	return v, nil
+}
+
+func builtinClose(e *Engine, args []Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1)", len(args))
+	}
+	c, err := channelArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Close(); err != nil {
+		return nil, err
+	}
+	return Undefined{}, nil
+}
+
+func (e *Engine) execSelect(s *Select) (Value, error) {
+	cases := []reflect.SelectCase{}
+	for _, c := range s.Cases {
+		v, err := e.execNode(c.Channel)
+		if err != nil {
+			return nil, err
+		}
+		ch, err := channelArgument(v)
+		if err != nil {
+			return nil, errorAt(err, "TypeError", c.Channel.Position())
+		}
+		if c.Value == nil {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch.ch)})
+			continue
+		}
+		x, err := e.execNode(c.Value)
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(ch.ch), Send: reflect.ValueOf(x)})
+	}
+	if s.Default != nil {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectDefault})
+	}
+	i, v, err := selectCases(cases)
+	if err != nil {
+		return nil, errorAt(err, "RuntimeError", s.position)
+	}
+	if i == len(s.Cases) {
+		if e.coverage != nil {
+			e.coverage.branch(s.position, BranchDefault)
+		}
+		return e.execNode(s.Default)
+	}
+	c := s.Cases[i]
+	if e.coverage != nil {
+		e.coverage.branch(s.position, selectBranch(i))
+	}
+	if c.Variable != nil {
+		e.assign(c.Variable.(*Identifier).Name, v)
+	}
+	return e.execNode(c.Body)
+}
+
+// selectCases runs a select statement over cases. It returns the index of
+// the case chosen and the value received, Undefined if the channel was
+// closed. Sending on a closed channel is an error.
+func selectCases(cases []reflect.SelectCase) (i int, v Value, err error) {
+	defer func() {
+		if recover() != nil {
+			i, v, err = -1, nil, channelError("send on closed channel")
+		}
+	}()
+	i, x, ok := reflect.Select(cases)
+	if cases[i].Dir != reflect.SelectRecv || !ok {
+		return i, Undefined{}, nil
+	}
+	return i, x.Interface().(Value), nil
+}
@@ -0,0 +1,237 @@
+package golan
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ClassType is the value of a class declaration. Calling it constructs an
+// instance and passes it to the init method, if any, with the arguments.
+type ClassType struct {
+	Name    string
+	methods map[string]*Closure
+	// engine runs the operator methods of instances applied by Go code
+	// with no engine at hand, such as comparisons of list elements, on a
+	// task of its own. Operators of scripts run on the engine evaluating
+	// them.
+	engine *Engine
+}
+
+func (t *ClassType) String() string { return fmt.Sprintf("#<class %s>", t.Name) }
+
+func (t *ClassType) OpCall(e *Engine, args []Value) (Value, error) {
+	i := &ClassInstance{Class: t, attrs: map[string]Value{}}
+	init, ok := t.methods["init"]
+	if !ok {
+		if len(args) != 0 {
+			return nil, &Error{
+				Kind:    "TypeError",
+				Message: fmt.Sprintf("wrong number of arguments (given %d, expected 0)", len(args)),
+			}
+		}
+		return i, nil
+	}
+	if _, err := callMethod(e, init, i, args); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// callMethod calls the method m of self; args do not include self.
+func callMethod(e *Engine, m *Closure, self Value, args []Value) (Value, error) {
+	if n := len(m.Definition.Parameters) - 1; len(args) != n {
+		return nil, &Error{
+			Kind:    "TypeError",
+			Message: fmt.Sprintf("wrong number of arguments (given %d, expected %d)", len(args), n),
+		}
+	}
+	return e.callClosure(m, append([]Value{self}, args...))
+}
+
+// ClassInstance is an instance of a class. Its attributes are set by
+// assignment, usually in init; methods named after operators, such as
+// op_add for + and op_cmp for comparisons, make instances operands.
+type ClassInstance struct {
+	Class *ClassType
+	mu    sync.RWMutex
+	attrs map[string]Value
+	// names are the attributes in the order they were first set.
+	names []string
+}
+
+func (i *ClassInstance) String() string { return i.format(map[Value]bool{}) }
+
+// format prints i with its attributes, or as #<Name ...> when i is in
+// seen, being printed already.
+func (i *ClassInstance) format(seen map[Value]bool) string {
+	if seen[i] {
+		return fmt.Sprintf("#<%s ...>", i.Class.Name)
+	}
+	seen[i] = true
+	defer delete(seen, i)
+	i.mu.RLock()
+	names := append([]string{}, i.names...)
+	values := []Value{}
+	for _, name := range names {
+		values = append(values, i.attrs[name])
+	}
+	i.mu.RUnlock()
+	if len(names) == 0 {
+		return fmt.Sprintf("#<%s>", i.Class.Name)
+	}
+	attrs := []string{}
+	for k, name := range names {
+		attrs = append(attrs, name+": "+inspectIn(values[k], seen))
+	}
+	return fmt.Sprintf("#<%s %s>", i.Class.Name, strings.Join(attrs, ", "))
+}
+
+// OpGetAttr returns an attribute of the instance, or else one of its
+// methods bound to it.
+func (i *ClassInstance) OpGetAttr(name string) (Value, error) {
+	i.mu.RLock()
+	v, ok := i.attrs[name]
+	i.mu.RUnlock()
+	if ok {
+		return v, nil
+	}
+	if m, ok := i.Class.methods[name]; ok {
+		return &BoundMethod{Receiver: i, Method: m}, nil
+	}
+	return nil, fmt.Errorf("undefined attribute - %s", name)
+}
+
+func (i *ClassInstance) OpSetAttr(name string, v Value) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if _, ok := i.attrs[name]; !ok {
+		i.names = append(i.names, name)
+	}
+	i.attrs[name] = v
+	return nil
+}
+
+// operator calls the method name of i with args on e, or on a task of
+// the engine of the class when e is nil. It reports false when the class
+// does not define the method.
+func (i *ClassInstance) operator(e *Engine, name string, args ...Value) (Value, bool, error) {
+	m, ok := i.Class.methods[name]
+	if !ok {
+		return nil, false, nil
+	}
+	if e == nil {
+		e = i.Class.engine.task(i.Class.Name+"."+name, m.Definition.Position())
+	}
+	r, err := callMethod(e, m, i, args)
+	return r, true, err
+}
+
+func (i *ClassInstance) binary(name string, what string, y Value) (Value, error) {
+	r, ok, err := i.operator(nil, name, y)
+	if !ok {
+		return nil, fmt.Errorf("not %s value - %v(%T)", what, i, i)
+	}
+	return r, err
+}
+
+func (i *ClassInstance) OpAdd(y Value) (Value, error) {
+	return i.binary("op_add", "an addable", y)
+}
+
+func (i *ClassInstance) OpSub(y Value) (Value, error) {
+	return i.binary("op_sub", "a subtractable", y)
+}
+
+func (i *ClassInstance) OpMul(y Value) (Value, error) {
+	return i.binary("op_mul", "a multipliable", y)
+}
+
+func (i *ClassInstance) OpDiv(y Value) (Value, error) {
+	return i.binary("op_div", "a dividable", y)
+}
+
+func (i *ClassInstance) OpMod(y Value) (Value, error) {
+	return i.binary("op_mod", "a modulo-operatable", y)
+}
+
+func (i *ClassInstance) unary(e *Engine, name string) (Value, error) {
+	r, ok, err := i.operator(e, name)
+	if !ok {
+		return nil, fmt.Errorf("not a signable value - %v(%T)", i, i)
+	}
+	return r, err
+}
+
+func (i *ClassInstance) OpPlus() (Value, error) {
+	return i.unary(nil, "op_plus")
+}
+
+func (i *ClassInstance) OpMinus() (Value, error) {
+	return i.unary(nil, "op_minus")
+}
+
+// OpCmpErr compares by op_cmp, which returns an Integer less than, equal
+// to or greater than 0. Without op_cmp an instance only equals itself.
+func (i *ClassInstance) OpCmpErr(y Value) (CompareResult, error) {
+	return i.compare(nil, y)
+}
+
+func (i *ClassInstance) compare(e *Engine, y Value) (CompareResult, error) {
+	r, ok, err := i.operator(e, "op_cmp", y)
+	if !ok {
+		if i == y {
+			return CMP_EQ, nil
+		}
+		return CMP_NE, nil
+	}
+	if err != nil {
+		return CMP_INVALID, err
+	}
+	n, ok := r.(Integer)
+	if !ok {
+		return CMP_INVALID, fmt.Errorf("op_cmp returned a non-Integer - %v(%T)", r, r)
+	}
+	switch {
+	case n < 0:
+		return CMP_LESS, nil
+	case n > 0:
+		return CMP_GREATER, nil
+	}
+	return CMP_EQ, nil
+}
+
+// operate applies op to x and y. When x is an instance of a class with
+// the operator method named method, the method runs on e instead, in the
+// call stack of the script applying the operator.
+func (e *Engine) operate(method string, op func(Value, Value) (Value, error), x Value, y Value) (Value, error) {
+	if i, ok := x.(*ClassInstance); ok {
+		if r, ok, err := i.operator(e, method, y); ok {
+			return r, err
+		}
+	}
+	return op(x, y)
+}
+
+// compareWith is CompareValues running op_cmp of class instances on e,
+// or on a task of the engine of the class when e is nil.
+func compareWith(e *Engine, x Value, y Value) (CompareResult, error) {
+	if i, ok := x.(*ClassInstance); ok {
+		return i.compare(e, y)
+	}
+	return CompareValues(x, y)
+}
+
+// BoundMethod is a method of an instance, taken as an attribute of it.
+type BoundMethod struct {
+	Receiver *ClassInstance
+	Method   *Closure
+}
+
+func (m *BoundMethod) String() string {
+	return fmt.Sprintf("#<method %s.%s>", m.Receiver.Class.Name, m.Method.Definition.Name)
+}
+
+func (m *BoundMethod) OpCall(e *Engine, args []Value) (Value, error) {
+	return callMethod(e, m.Method, m.Receiver, args)
+}
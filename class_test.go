@@ -0,0 +1,71 @@
+package golan_test
+
+import (
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+// stackHook records the call stack at each statement on a line.
+type stackHook struct {
+	line   int
+	stacks [][]string
+}
+
+func (h *stackHook) BeforeStatement(e *golan.Engine, s golan.Node) error {
+	if s.Position().FirstLineno != h.line {
+		return nil
+	}
+	names := []string{}
+	for _, f := range e.CallStack() {
+		names = append(names, f.Function)
+	}
+	h.stacks = append(h.stacks, names)
+	return nil
+}
+
+func TestOperatorMethodStack(t *testing.T) {
+	e := golan.NewEngine()
+	h := &stackHook{line: 7}
+	e.SetDebugHook(h)
+	run(t, e, `
+class V {
+  func init(self, x) {
+    self.x = x
+  }
+  func op_add(self, other) {
+    return V(self.x + other.x)
+  }
+  func op_cmp(self, other) {
+    return self.x - other.x
+  }
+}
+func sum(a, b) {
+  return a + b
+}
+s = sum(V(1), V(2))
+assert(V(1) < s)
+`)
+	if s := e.Globals()["s"].(*golan.ClassInstance); s.String() != "#<V x: 3>" {
+		t.Errorf("s = %v", s)
+	}
+	if len(h.stacks) != 1 {
+		t.Fatalf("stopped %d times in op_add, want 1", len(h.stacks))
+	}
+	want := []string{"op_add", "sum", "main"}
+	if got := h.stacks[0]; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("call stack in op_add = %v, want %v", got, want)
+	}
+}
+
+func TestClassInstanceCycle(t *testing.T) {
+	expectOutput(t, `
+class Node {
+  func init(self, name) {
+    self.name = name
+    self.me = self
+  }
+}
+print(Node("a"))
+`, "#<Node name: \"a\", me: #<Node ...>>\n")
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/arikui1911/golan"
+)
+
+func astCommand(args []string) {
+	fs := flag.NewFlagSet("ast", flag.ExitOnError)
+	format := fs.String("format", "json", "output format (json or sexp)")
+	files := parseFlags(fs, args)
+
+	tree, err := golan.ParseFiles(files...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	switch *format {
+	case "json":
+		b, err := json.MarshalIndent(tree, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		os.Stdout.Write(append(b, '\n'))
+	case "sexp":
+		if err := golan.FprintSexp(os.Stdout, tree); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		log.Fatalf("unknown format - %s", *format)
+	}
+}
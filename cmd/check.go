@@ -0,0 +1,29 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/arikui1911/golan"
+	"github.com/arikui1911/golan/typecheck"
+)
+
+func checkCommand(args []string) {
+	files := parseFlags(flag.NewFlagSet("check", flag.ExitOnError), args)
+	if len(files) == 0 {
+		log.Fatal("no script given")
+	}
+	tree, err := golan.ParseFiles(files...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	errs := typecheck.Check(tree)
+	for _, e := range errs {
+		fmt.Println(e)
+	}
+	if len(errs) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/arikui1911/golan/dap"
+)
+
+func dapCommand(args []string) {
+	parseFlags(flag.NewFlagSet("dap", flag.ExitOnError), args)
+	if err := dap.NewServer(os.Stdin, os.Stdout).Serve(); err != nil {
+		log.Fatal(err)
+	}
+}
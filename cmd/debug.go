@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/arikui1911/golan"
+	"github.com/arikui1911/golan/debugger"
+)
+
+const debugHelp = `commands:
+	break [file:]line   set a breakpoint (b)
+	clear [file:]line   delete a breakpoint
+	breakpoints         list breakpoints
+	step                stop at the next statement (s)
+	next                step over function calls (n)
+	finish              run until the current function returns
+	continue            run until a breakpoint (c)
+	print expr          evaluate expr in the current scope (p)
+	locals              show local variables
+	backtrace           show the call stack (bt)
+	list                show source around the current line (l)
+	quit                abort the script (q)
+An empty line repeats the previous command.
+
+Commands are read from stdin unless -commands names another file, such
+as /dev/tty. The script then reads its stdin from the same input: each
+line goes to whichever of them reads first. If the commands end before
+the script does, it is aborted with exit status 1.
+`
+
+var (
+	errQuit = errors.New("debugger: quit")
+	// errInputClosed aborts the script when commands run out, since it
+	// cannot be told whether the session ended as intended.
+	errInputClosed = errors.New("debugger: input closed")
+)
+
+type debugSession struct {
+	script  string
+	in      *bufio.Reader
+	out     io.Writer
+	last    string
+	sources map[string][]string
+	dbg     *debugger.Debugger
+}
+
+func debugCommand(args []string) {
+	fs := flag.NewFlagSet("debug", flag.ExitOnError)
+	commands := fs.String("commands", "", "read debugger commands from `file` instead of stdin")
+	files := parseFlags(fs, args)
+	if len(files) != 1 {
+		log.Fatal("debug takes exactly one script")
+	}
+	tree, err := golan.ParseFile(files[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	s := &debugSession{
+		script:  files[0],
+		in:      bufio.NewReader(os.Stdin),
+		out:     os.Stdout,
+		sources: map[string][]string{},
+	}
+	// Sharing the reader with stdin keeps either from buffering away
+	// lines meant for the other.
+	input := golan.WithInput(s.in)
+	if *commands != "" {
+		f, err := os.Open(*commands)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		s.in = bufio.NewReader(f)
+	}
+	s.dbg = debugger.New(s.stopped, true)
+	engine := golan.NewEngine(input)
+	engine.SetDebugHook(s.dbg)
+	if _, err := engine.Execute(tree); err != nil {
+		if errors.Is(err, errQuit) {
+			return
+		}
+		if errors.Is(err, errInputClosed) {
+			fmt.Fprintln(os.Stderr, errInputClosed)
+			os.Exit(1)
+		}
+		log.Fatal(err)
+	}
+	fmt.Fprintln(s.out, "script finished")
+}
+
+func (s *debugSession) lines(source string) []string {
+	if l, ok := s.sources[source]; ok {
+		return l
+	}
+	b, err := os.ReadFile(source)
+	if err != nil {
+		return nil
+	}
+	l := strings.Split(string(b), "\n")
+	s.sources[source] = l
+	return l
+}
+
+func (s *debugSession) show(p *golan.Position, from int, to int) {
+	lines := s.lines(p.Source)
+	for n := from; n <= to; n++ {
+		if n < 1 || n > len(lines) {
+			continue
+		}
+		mark := "  "
+		if n == p.FirstLineno {
+			mark = "=>"
+		}
+		fmt.Fprintf(s.out, "%s %4d  %s\n", mark, n, strings.TrimRight(lines[n-1], "\r"))
+	}
+}
+
+// location parses [file:]line.
+func (s *debugSession) location(arg string) (string, int, error) {
+	source := s.script
+	if i := strings.LastIndex(arg, ":"); i >= 0 {
+		source, arg = arg[:i], arg[i+1:]
+	}
+	line, err := strconv.Atoi(arg)
+	if err != nil || line < 1 {
+		return "", 0, fmt.Errorf("invalid line - %q", arg)
+	}
+	return source, line, nil
+}
+
+func (s *debugSession) stopped(e *golan.Engine, statement golan.Node, reason string) (debugger.Mode, error) {
+	p := statement.Position()
+	fmt.Fprintf(s.out, "stopped at %s (%s)\n", p, reason)
+	s.show(p, p.FirstLineno, p.FirstLineno)
+	for {
+		fmt.Fprint(s.out, "(golan) ")
+		line, err := s.in.ReadString('\n')
+		if err != nil && line == "" {
+			fmt.Fprintln(s.out)
+			return debugger.Continue, errInputClosed
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			line = s.last
+		}
+		s.last = line
+		cmd, arg, _ := strings.Cut(line, " ")
+		arg = strings.TrimSpace(arg)
+
+		switch cmd {
+		case "":
+		case "step", "s":
+			return debugger.StepIn, nil
+		case "next", "n":
+			return debugger.StepOver, nil
+		case "finish":
+			return debugger.StepOut, nil
+		case "continue", "c":
+			return debugger.Continue, nil
+		case "quit", "q":
+			return debugger.Continue, errQuit
+		case "break", "b":
+			source, line, err := s.location(arg)
+			if err != nil {
+				fmt.Fprintln(s.out, err)
+				continue
+			}
+			s.dbg.SetBreakpoint(source, line)
+			fmt.Fprintf(s.out, "breakpoint at %s:%d\n", source, line)
+		case "clear":
+			source, line, err := s.location(arg)
+			if err != nil {
+				fmt.Fprintln(s.out, err)
+				continue
+			}
+			if !s.dbg.ClearBreakpoint(source, line) {
+				fmt.Fprintf(s.out, "no breakpoint at %s:%d\n", source, line)
+			}
+		case "breakpoints":
+			for _, b := range s.dbg.Breakpoints() {
+				fmt.Fprintf(s.out, "%s:%d\n", b.Source, b.Line)
+			}
+		case "print", "p":
+			v, err := e.Eval(arg)
+			if err != nil {
+				fmt.Fprintln(s.out, err)
+				continue
+			}
+			fmt.Fprintln(s.out, v)
+		case "locals":
+			vars := e.Locals()
+			names := []string{}
+			for k := range vars {
+				names = append(names, k)
+			}
+			sort.Strings(names)
+			for _, k := range names {
+				fmt.Fprintf(s.out, "%s = %v\n", k, vars[k])
+			}
+		case "backtrace", "bt":
+			for i, f := range e.CallStack() {
+				fmt.Fprintf(s.out, "#%d %s at %s\n", i, f.Function, f.Position)
+			}
+		case "list", "l":
+			s.show(p, p.FirstLineno-5, p.FirstLineno+5)
+		case "help", "h":
+			fmt.Fprint(s.out, debugHelp)
+		default:
+			fmt.Fprintf(s.out, "unknown command - %s (try help)\n", cmd)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/arikui1911/golan"
+)
+
+func fmtCommand(args []string) {
+	fs := flag.NewFlagSet("fmt", flag.ExitOnError)
+	write := fs.Bool("w", false, "write result to the source file instead of stdout")
+	list := fs.Bool("l", false, "list files whose formatting differs")
+	files := parseFlags(fs, args)
+
+	if len(files) == 0 {
+		src, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			log.Fatal(err)
+		}
+		out, err := golan.Format(src)
+		if err != nil {
+			log.Fatal(err)
+		}
+		os.Stdout.Write(out)
+		return
+	}
+
+	failed := false
+	for _, path := range files {
+		if err := formatFile(path, *write, *list); err != nil {
+			log.Print(err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func formatFile(path string, write bool, list bool) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	tree, err := golan.ParseSource(path, string(src))
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := golan.Fprint(&buf, tree); err != nil {
+		return err
+	}
+	out := buf.Bytes()
+	changed := !bytes.Equal(src, out)
+	if list && changed {
+		fmt.Println(path)
+	}
+	if write {
+		if changed {
+			return os.WriteFile(path, out, 0644)
+		}
+		return nil
+	}
+	if !list {
+		os.Stdout.Write(out)
+	}
+	return nil
+}
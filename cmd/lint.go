@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/arikui1911/golan"
+	"github.com/arikui1911/golan/lint"
+)
+
+func lintCommand(args []string) {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	rules := fs.Bool("rules", false, "list the rules and exit")
+	files := parseFlags(fs, args)
+
+	if *rules {
+		names := []string{}
+		for name := range lint.Rules {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("%-20s %s\n", name, lint.Rules[name])
+		}
+		return
+	}
+	if len(files) == 0 {
+		log.Fatal("no script given")
+	}
+
+	found := false
+	for _, path := range files {
+		tree, err := golan.ParseFile(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, w := range lint.Check(tree) {
+			fmt.Println(w)
+			found = true
+		}
+	}
+	if found {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/arikui1911/golan/lsp"
+)
+
+func lspCommand(args []string) {
+	parseFlags(flag.NewFlagSet("lsp", flag.ExitOnError), args)
+	if err := lsp.NewServer(os.Stdin, os.Stdout).Serve(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -1,89 +1,97 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"io"
 	"log"
 	"os"
 
 	"github.com/arikui1911/golan"
 )
 
-func main() {
-	src := `
-# comment
-hoge = piyo = 111 == -123 + +456 * !789
-
-foo = 100
-
-while (foo == 100) {
-    bar = 200
-}
-
-if foo >= 10 {
-    bar = 20
-}
-
-if (
-    foo <= 11
-)
-{
-    bar = 22
-}
-else
-{
-    bar = 33
-}
-
-if hoge > 100 {
-	piyo = 200
-} elsif huga > 300 {
-	piyo = 400
-} elsif hoge > 500 {
-	piyo = 600
-} else {
-	piyo = 700
-}
-
-{
-    print()
-    print(666)
-    print(100 + 200, 300)
-}
-
-print(
-    1,
-    2,
-)
-
-print(1)(2)()
-
+const usage = `usage: golan <command> [arguments]
+
+commands:
+	run   execute scripts
+	dump  print the syntax tree of scripts
+	ast   export the syntax tree as JSON or S-expression
+	fmt   reformat scripts
+	lsp   run the language server on stdin/stdout
+	lint  report suspicious constructs in scripts
+	check report type errors in scripts
+	debug run a script under the debugger
+	dap   run the debug adapter on stdin/stdout
+	test  run the tests of *_test.gl scripts
 `
 
-	src = `
-print(666 == 111 + 555, !0, 0)
-print(1 < 2, 1.001E-3, true, false)
-print(1.23 + 4.56, "Hello, " + "world!")
-x = hoge(piyo())
-	`
-
-	src = `
-n_lines = 0
-n_chars = 0
-while line = file_readline(stdin) {
-	n_lines += 1
-	n_chars += len(line)
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("golan: ")
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	args := os.Args[2:]
+	switch os.Args[1] {
+	case "run":
+		runCommand(args)
+	case "dump":
+		dumpCommand(args)
+	case "ast":
+		astCommand(args)
+	case "fmt":
+		fmtCommand(args)
+	case "lsp":
+		lspCommand(args)
+	case "lint":
+		lintCommand(args)
+	case "check":
+		checkCommand(args)
+	case "debug":
+		debugCommand(args)
+	case "dap":
+		dapCommand(args)
+	case "test":
+		testCommand(args)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
 }
-print(format("%d lines, %d characters.", n_lines, n_chars))
-`
 
-	src = `print()`
+func parseFlags(fs *flag.FlagSet, args []string) []string {
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: golan %s [flags] files...\n", fs.Name())
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	return fs.Args()
+}
 
-	tree, err := golan.Parse(src)
+func runCommand(args []string) {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	profile := fs.String("profile", "", "write a pprof profile of the run to `file`")
+	files := parseFlags(fs, args)
+	if len(files) == 0 {
+		log.Fatal("no script given")
+	}
+	tree, err := golan.ParseFiles(files...)
 	if err != nil {
 		log.Fatal(err)
 	}
-	golan.DumpTree(tree, os.Stdout)
 	engine := golan.NewEngine()
+	var profiler *golan.Profiler
+	if *profile != "" {
+		profiler = golan.NewProfiler()
+		engine.SetProfiler(profiler)
+	}
 	val, err := engine.Execute(tree)
+	if profiler != nil {
+		if perr := writeFile(*profile, profiler.WritePprof); perr != nil {
+			log.Print(perr)
+		}
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -91,3 +99,25 @@ print(format("%d lines, %d characters.", n_lines, n_chars))
 		log.Println(val)
 	}
 }
+
+// writeFile creates path and fills it by write.
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func dumpCommand(args []string) {
+	files := parseFlags(flag.NewFlagSet("dump", flag.ExitOnError), args)
+	tree, err := golan.ParseFiles(files...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	golan.DumpTree(tree, os.Stdout)
+}
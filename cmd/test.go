@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+
+	"github.com/arikui1911/golan"
+	"github.com/arikui1911/golan/tester"
+)
+
+func testCommand(args []string) {
+	flags := flag.NewFlagSet("test", flag.ExitOnError)
+	verbose := flags.Bool("v", false, "list passing tests too")
+	run := flags.String("run", "", "run only the test functions matching `regexp`")
+	format := flags.String("format", "text", "report format: text, tap or junit")
+	output := flags.String("o", "", "write the report to `file` instead of stdout")
+	cover := flags.Bool("cover", false, "report statement and branch coverage of the tests and imported modules")
+	coverProfile := flags.String("coverprofile", "", "write a coverage profile to `file` (implies -cover)")
+	coverHTML := flags.String("coverhtml", "", "write an HTML coverage report to `file` (implies -cover)")
+	paths := parseFlags(flags, args)
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	var write func(io.Writer, []*tester.Result) error
+	switch *format {
+	case "text":
+		write = func(w io.Writer, results []*tester.Result) error {
+			tester.WriteText(w, results, *verbose)
+			return nil
+		}
+	case "tap":
+		write = func(w io.Writer, results []*tester.Result) error {
+			tester.WriteTAP(w, results)
+			return nil
+		}
+	case "junit":
+		write = tester.WriteJUnit
+	default:
+		log.Fatalf("unknown format - %s", *format)
+	}
+
+	files, err := tester.Discover(paths...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(files) == 0 {
+		log.Fatal("no test files found")
+	}
+	opts := tester.Options{}
+	if *run != "" {
+		if opts.Run, err = regexp.Compile(*run); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *cover || *coverProfile != "" || *coverHTML != "" {
+		opts.Coverage = golan.NewCoverage()
+	}
+
+	results := tester.Run(files, opts)
+	if *output != "" {
+		err = writeFile(*output, func(w io.Writer) error { return write(w, results) })
+	} else {
+		err = write(os.Stdout, results)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if c := opts.Coverage; c != nil {
+		statements, branches := c.Percent()
+		// Keep machine-readable reports on stdout parseable.
+		w := os.Stdout
+		if *format != "text" && *output == "" {
+			w = os.Stderr
+		}
+		fmt.Fprintf(w, "coverage: %.1f%% of statements, %.1f%% of branches\n", statements, branches)
+		if *coverProfile != "" {
+			if err := writeFile(*coverProfile, c.WriteProfile); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if *coverHTML != "" {
+			if err := writeFile(*coverHTML, c.WriteHTML); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+	if tester.Summarize(results).Failed > 0 {
+		os.Exit(1)
+	}
+}
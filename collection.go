@@ -0,0 +1,167 @@
+package golan
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// List is an ordered sequence of values, written [a, b, c].
+type List struct {
+	Elements []Value
+}
+
+func NewList(elements ...Value) *List {
+	return &List{Elements: append([]Value{}, elements...)}
+}
+
+func (l *List) String() string { return l.format(map[Value]bool{}) }
+
+func (l *List) format(seen map[Value]bool) string {
+	if seen[l] {
+		return "[...]"
+	}
+	seen[l] = true
+	defer delete(seen, l)
+	s := []string{}
+	for _, v := range l.Elements {
+		s = append(s, inspectIn(v, seen))
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+func (l *List) OpLen() int { return len(l.Elements) }
+
+func (l *List) OpIter() (Iterator, error) {
+	i := 0
+	return IteratorFunc(func() (Value, bool, error) {
+		if i >= len(l.Elements) {
+			return nil, false, nil
+		}
+		i++
+		return l.Elements[i-1], true, nil
+	}), nil
+}
+
+// OpContains reports whether an element equals x.
+func (l *List) OpContains(x Value) (bool, error) {
+	for _, v := range l.Elements {
+		if valuesEqual(nil, v, x) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (l *List) OpIndex(key Value) (Value, error) {
+	i, err := sequenceIndex(key, len(l.Elements))
+	if err != nil {
+		return nil, err
+	}
+	return l.Elements[i], nil
+}
+
+// sequenceIndex converts key to an index of a sequence of n elements;
+// negative keys count from the end.
+func sequenceIndex(key Value, n int) (int, error) {
+	k, ok := key.(Integer)
+	if !ok {
+		return 0, fmt.Errorf("not an Integer - %v(%T)", key, key)
+	}
+	i := int(k)
+	if i < 0 {
+		i += n
+	}
+	if i < 0 || i >= n {
+		return 0, &Error{Kind: "IndexError", Message: fmt.Sprintf("index out of range - %d (length %d)", k, n)}
+	}
+	return i, nil
+}
+
+// Map associates keys with values and remembers the order in which keys
+// were first set. Keys are compared as Go values, so Integer 1 and Float
+// 1.0 are different keys.
+type Map struct {
+	keys   []Value
+	values map[Value]Value
+}
+
+func NewMap() *Map {
+	return &Map{values: map[Value]Value{}}
+}
+
+func (m *Map) String() string { return m.format(map[Value]bool{}) }
+
+func (m *Map) format(seen map[Value]bool) string {
+	if seen[m] {
+		return "{...}"
+	}
+	seen[m] = true
+	defer delete(seen, m)
+	s := []string{}
+	for _, k := range m.keys {
+		s = append(s, inspectIn(k, seen)+": "+inspectIn(m.values[k], seen))
+	}
+	return "{" + strings.Join(s, ", ") + "}"
+}
+
+// Set associates key with v.
+func (m *Map) Set(key Value, v Value) error {
+	if key == nil || !reflect.TypeOf(key).Comparable() {
+		return fmt.Errorf("unhashable map key - %v(%T)", key, key)
+	}
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = v
+	return nil
+}
+
+// Get returns the value associated with key.
+func (m *Map) Get(key Value) (Value, bool) {
+	if key == nil || !reflect.TypeOf(key).Comparable() {
+		return nil, false
+	}
+	v, ok := m.values[key]
+	return v, ok
+}
+
+// Keys returns the keys in insertion order.
+func (m *Map) Keys() []Value {
+	return append([]Value{}, m.keys...)
+}
+
+func (m *Map) OpLen() int { return len(m.keys) }
+
+// OpIter iterates over the keys in insertion order.
+func (m *Map) OpIter() (Iterator, error) {
+	return NewList(m.keys...).OpIter()
+}
+
+// OpContains reports whether key is set.
+func (m *Map) OpContains(key Value) (bool, error) {
+	_, ok := m.Get(key)
+	return ok, nil
+}
+
+func (m *Map) OpIndex(key Value) (Value, error) {
+	v, ok := m.Get(key)
+	if !ok {
+		return nil, &Error{Kind: "KeyError", Message: fmt.Sprintf("key not found - %s", inspect(key))}
+	}
+	return v, nil
+}
+
+// builtinMap returns a Map of its arguments taken as key, value pairs.
+func builtinMap(e *Engine, args []Value) (Value, error) {
+	if len(args)%2 != 0 {
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected an even number)", len(args))
+	}
+	m := NewMap()
+	for i := 0; i < len(args); i += 2 {
+		if err := m.Set(args[i], args[i+1]); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
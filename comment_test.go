@@ -0,0 +1,108 @@
+package golan_test
+
+import (
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+const commented = `# header
+x = 1 # one
+func f() {
+  # inside
+  return x # result
+  # end of f
+}
+print(f()) # call
+# footer
+`
+
+func texts(comments []*golan.Comment) []string {
+	r := []string{}
+	for _, c := range comments {
+		r = append(r, c.Text)
+	}
+	return r
+}
+
+// compact returns c as a list of none or one comment.
+func compact(c *golan.Comment) []*golan.Comment {
+	if c == nil {
+		return nil
+	}
+	return []*golan.Comment{c}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestComments(t *testing.T) {
+	tree, err := golan.Parse(commented)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := tree.(*golan.Block)
+	all := root.Comments()
+	wantAll := []struct {
+		text     string
+		position string
+	}{
+		{"# header", "(1:1,1:8)"},
+		{"# one", "(2:7,2:11)"},
+		{"# inside", "(4:3,4:10)"},
+		{"# result", "(5:12,5:19)"},
+		{"# end of f", "(6:3,6:12)"},
+		{"# call", "(8:12,8:17)"},
+		{"# footer", "(9:1,9:8)"},
+	}
+	if len(all) != len(wantAll) {
+		t.Fatalf("comments = %q, want %d", texts(all), len(wantAll))
+	}
+	for i, w := range wantAll {
+		if all[i].Text != w.text || all[i].Position().Range() != w.position {
+			t.Errorf("comments[%d] = %q %s, want %q %s", i, all[i].Text, all[i].Position().Range(), w.text, w.position)
+		}
+	}
+
+	body := root.Statements()[1].(*golan.Function).Body.(*golan.Block)
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"leading x", texts(root.LeadingComments(root.Statements()[0])), []string{"# header"}},
+		{"trailing x", texts(compact(root.TrailingComment(root.Statements()[0]))), []string{"# one"}},
+		{"leading f", texts(root.LeadingComments(root.Statements()[1])), []string{}},
+		{"trailing print", texts(compact(root.TrailingComment(root.Statements()[2]))), []string{"# call"}},
+		{"dangling root", texts(root.DanglingComments()), []string{"# footer"}},
+		{"leading return", texts(body.LeadingComments(body.Statements()[0])), []string{"# inside"}},
+		{"trailing return", texts(compact(body.TrailingComment(body.Statements()[0]))), []string{"# result"}},
+		{"dangling f", texts(body.DanglingComments()), []string{"# end of f"}},
+		{"nested blocks", texts(body.Comments()), []string{}},
+	}
+	for _, tt := range tests {
+		if !equalStrings(tt.got, tt.want) {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestCommentsSurviveFormat(t *testing.T) {
+	out, err := golan.Format([]byte(commented))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# header\nx = 1 # one\nfunc f() {\n\t# inside\n\treturn x # result\n\t# end of f\n}\nprint(f()) # call\n# footer\n"
+	if string(out) != want {
+		t.Errorf("formatted = %q, want %q", out, want)
+	}
+}
@@ -0,0 +1,259 @@
+package golan_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/arikui1911/golan"
+	"github.com/arikui1911/golan/debugger"
+)
+
+// run executes src on e and returns what it printed.
+func run(t *testing.T, e *golan.Engine, src string) string {
+	t.Helper()
+	var out bytes.Buffer
+	e.SetOutput(&out)
+	tree, err := golan.Parse(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.Execute(tree); err != nil {
+		t.Fatalf("%v\noutput:\n%s", err, out.String())
+	}
+	return out.String()
+}
+
+func expectOutput(t *testing.T, src string, want string) {
+	t.Helper()
+	if got := run(t, golan.NewEngine(), src); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestSpawnWaitJoin(t *testing.T) {
+	expectOutput(t, `
+func double(x) {
+  return x * 2
+}
+t = spawn double(21)
+print(wait(t))
+print(join(spawn double(1), [spawn double(2), spawn double(3)]))
+`, "42\n[2, 4, 6]\n")
+}
+
+func TestSendRecv(t *testing.T) {
+	expectOutput(t, `
+func produce(c, n) {
+  i = 0
+  while i < n {
+    send(c, i)
+    i = i + 1
+  }
+  close(c)
+}
+c = channel()
+spawn produce(c, 5)
+sum = 0
+for v in c {
+  sum = sum + v
+}
+print(sum)
+print(recv(c))
+`, "10\n#<undefined>\n")
+}
+
+func TestSelect(t *testing.T) {
+	expectOutput(t, `
+a = channel(1)
+b = channel(1)
+send(b, "from b")
+select {
+  case v = recv(a) {
+    print(v)
+  }
+  case v = recv(b) {
+    print(v)
+  }
+}
+select {
+  case send(a, 1) {
+    print("sent")
+  }
+  default {
+    print("full")
+  }
+}
+select {
+  case send(a, 2) {
+    print("sent")
+  }
+  default {
+    print("full")
+  }
+}
+func later(c) {
+  send(c, "done")
+}
+done = channel()
+spawn later(done)
+select {
+  case v = recv(done) {
+    print(v)
+  }
+}
+`, "from b\nsent\nfull\ndone\n")
+}
+
+func TestGeneratorInTask(t *testing.T) {
+	expectOutput(t, `
+func count(n) {
+  i = 0
+  while i < n {
+    yield i
+    i = i + 1
+  }
+}
+func consume(gen) {
+  s = 0
+  for x in gen {
+    s = s + x
+  }
+  return s
+}
+print(wait(spawn consume(count(10))))
+func forward(gen, c) {
+  for x in gen {
+    send(c, x)
+  }
+  close(c)
+}
+out = channel()
+spawn forward(count(3), out)
+for v in out {
+  print(v)
+}
+`, "45\n0\n1\n2\n")
+}
+
+const workers = `
+n = 0
+last = 0
+func work(id, out) {
+  i = 0
+  while i < 50 {
+    n = n + 1
+    puts("task", id, i)
+    i = i + 1
+  }
+  last = id
+  send(out, id)
+}
+out = channel()
+a = spawn work(1, out)
+b = spawn work(2, out)
+c = spawn work(3, out)
+got = recv(out) + recv(out) + recv(out)
+join(a, b, c)
+`
+
+func checkWorkers(t *testing.T, e *golan.Engine, out string) {
+	t.Helper()
+	if n := strings.Count(out, "\n"); n != 150 {
+		t.Errorf("printed %d lines, want 150", n)
+	}
+	g := e.Globals()
+	// n = n + 1 is not atomic, so increments may be lost, but every
+	// write must be seen by the globals.
+	if n, ok := g["n"].(golan.Integer); !ok || n < 50 || n > 150 {
+		t.Errorf("n = %v, want 50..150", g["n"])
+	}
+	if last := g["last"]; last != golan.Integer(1) && last != golan.Integer(2) && last != golan.Integer(3) {
+		t.Errorf("last = %v, want the id of a task", last)
+	}
+	if got := g["got"]; got != golan.Integer(6) {
+		t.Errorf("got = %v, want 6", got)
+	}
+}
+
+func TestConcurrentGlobalWrites(t *testing.T) {
+	e := golan.NewEngine()
+	out := run(t, e, workers)
+	checkWorkers(t, e, out)
+}
+
+// TestConcurrentTooling runs tasks under the debugger, the profiler and
+// coverage, which all of them report to.
+func TestConcurrentTooling(t *testing.T) {
+	e := golan.NewEngine()
+	p := golan.NewProfiler()
+	e.SetProfiler(p)
+	c := golan.NewCoverage()
+	e.SetCoverage(c)
+	stops := make(chan string, 1000)
+	d := debugger.New(func(e *golan.Engine, s golan.Node, reason string) (debugger.Mode, error) {
+		stops <- e.CallStack()[0].Function
+		_ = e.Locals()
+		return debugger.Continue, nil
+	}, false)
+	d.SetBreakpoint("<string>", 11)
+	e.SetDebugHook(d)
+	tree, err := golan.Parse(workers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Add(tree)
+	var out bytes.Buffer
+	e.SetOutput(&out)
+	if _, err := e.Execute(tree); err != nil {
+		t.Fatal(err)
+	}
+	checkWorkers(t, e, out.String())
+	close(stops)
+	n := 0
+	for f := range stops {
+		if f != "work" {
+			t.Errorf("stopped in %s, want work", f)
+		}
+		n++
+	}
+	if n != 3 {
+		t.Errorf("stopped %d times, want 3", n)
+	}
+	if len(p.Lines()) == 0 {
+		t.Error("no lines profiled")
+	}
+	if err := p.WritePprof(&bytes.Buffer{}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConcurrentAttributeWrites(t *testing.T) {
+	e := golan.NewEngine()
+	run(t, e, `
+struct P { x, y }
+class C {
+  func init(self) {
+    self.x = 0
+  }
+}
+p = P(0, 0)
+c = C()
+func bump(o) {
+  i = 0
+  while i < 100 {
+    o.x = o.x + 1
+    i = i + 1
+  }
+}
+join(spawn bump(p), spawn bump(p), spawn bump(c), spawn bump(c))
+px = p.x
+cx = c.x
+`)
+	g := e.Globals()
+	for _, name := range []string{"px", "cx"} {
+		if x, ok := g[name].(golan.Integer); !ok || x < 100 || x > 200 {
+			t.Errorf("%s = %v, want 100..200", name, g[name])
+		}
+	}
+}
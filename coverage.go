@@ -0,0 +1,221 @@
+package golan
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Coverage counts how often the statements and branches of registered
+// trees are executed. Statements and branches are keyed by the Position of
+// their node, so nodes of trees that were not registered are ignored.
+type Coverage struct {
+	mu         sync.Mutex
+	statements map[Position]int
+	branches   map[branchKey]int
+}
+
+type branchKey struct {
+	position Position
+	branch   string
+}
+
+// Branch names. An If takes "then" or "else", whether it has an else
+// clause or not; a While takes "body" each time its condition holds and
+// "exit" when it does not, and a For "body" for each element and "exit"
+// when they run out. A Select takes "case 1", "case 2" and so on, or
+// "default".
+const (
+	BranchThen    = "then"
+	BranchElse    = "else"
+	BranchBody    = "body"
+	BranchExit    = "exit"
+	BranchDefault = "default"
+)
+
+// selectBranch is the name of the branch of the i-th case of a Select,
+// counting from zero.
+func selectBranch(i int) string {
+	return fmt.Sprintf("case %d", i+1)
+}
+
+// StatementCoverage is the execution count of a statement.
+type StatementCoverage struct {
+	Position Position
+	Count    int
+}
+
+// BranchCoverage is the number of times a branch of an If, While, For or
+// Select was taken.
+type BranchCoverage struct {
+	Position Position
+	Branch   string
+	Count    int
+}
+
+func NewCoverage() *Coverage {
+	return &Coverage{
+		statements: map[Position]int{},
+		branches:   map[branchKey]int{},
+	}
+}
+
+// SetCoverage makes e count into c, or stops counting when c is nil.
+// Modules imported while counting are registered to c.
+func (e *Engine) SetCoverage(c *Coverage) {
+	e.coverage = c
+}
+
+// Add registers the statements and branches of tree with a count of zero.
+func (c *Coverage) Add(tree Node) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	Inspect(tree, func(n Node) bool {
+		switch n := n.(type) {
+		case *Block:
+			for _, s := range n.statements {
+				if _, ok := c.statements[*s.Position()]; !ok {
+					c.statements[*s.Position()] = 0
+				}
+			}
+		case *If:
+			c.register(n.position, BranchThen, BranchElse)
+		case *While:
+			c.register(n.position, BranchBody, BranchExit)
+		case *For:
+			c.register(n.position, BranchBody, BranchExit)
+		case *Select:
+			for i := range n.Cases {
+				c.register(n.position, selectBranch(i))
+			}
+			if n.Default != nil {
+				c.register(n.position, BranchDefault)
+			}
+		}
+		return true
+	})
+}
+
+func (c *Coverage) register(p *Position, branches ...string) {
+	for _, b := range branches {
+		k := branchKey{*p, b}
+		if _, ok := c.branches[k]; !ok {
+			c.branches[k] = 0
+		}
+	}
+}
+
+// statement records that the statement at p is about to run.
+func (c *Coverage) statement(p *Position) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n, ok := c.statements[*p]; ok {
+		c.statements[*p] = n + 1
+	}
+}
+
+// branch records that branch of the node at p was taken.
+func (c *Coverage) branch(p *Position, branch string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	k := branchKey{*p, branch}
+	if n, ok := c.branches[k]; ok {
+		c.branches[k] = n + 1
+	}
+}
+
+func positionLess(a, b Position) bool {
+	if a.Source != b.Source {
+		return a.Source < b.Source
+	}
+	if a.FirstLineno != b.FirstLineno {
+		return a.FirstLineno < b.FirstLineno
+	}
+	if a.FirstColumn != b.FirstColumn {
+		return a.FirstColumn < b.FirstColumn
+	}
+	if a.LastLineno != b.LastLineno {
+		return a.LastLineno < b.LastLineno
+	}
+	return a.LastColumn < b.LastColumn
+}
+
+// Statements returns the registered statements in source order.
+func (c *Coverage) Statements() []StatementCoverage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r := []StatementCoverage{}
+	for p, n := range c.statements {
+		r = append(r, StatementCoverage{p, n})
+	}
+	sort.Slice(r, func(i, j int) bool { return positionLess(r[i].Position, r[j].Position) })
+	return r
+}
+
+// Branches returns the registered branches in source order.
+func (c *Coverage) Branches() []BranchCoverage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r := []BranchCoverage{}
+	for k, n := range c.branches {
+		r = append(r, BranchCoverage{k.position, k.branch, n})
+	}
+	sort.Slice(r, func(i, j int) bool {
+		if r[i].Position != r[j].Position {
+			return positionLess(r[i].Position, r[j].Position)
+		}
+		return r[i].Branch < r[j].Branch
+	})
+	return r
+}
+
+// Percent returns the percentage of statements and branches executed at
+// least once. A kind with nothing registered has nothing covered.
+func (c *Coverage) Percent() (statements float64, branches float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return percent(c.statements), percent(c.branches)
+}
+
+func percent[K comparable](counts map[K]int) float64 {
+	if len(counts) == 0 {
+		return 0
+	}
+	n := 0
+	for _, c := range counts {
+		if c > 0 {
+			n++
+		}
+	}
+	return 100 * float64(n) / float64(len(counts))
+}
+
+func rangeString(p Position) string {
+	return fmt.Sprintf("%s:%d.%d,%d.%d", p.Source, p.FirstLineno, p.FirstColumn, p.LastLineno, p.LastColumn)
+}
+
+// WriteProfile writes the counts in a text format modelled on Go's
+// coverprofile:
+//
+//	mode: count
+//	file:line.column,line.column 1 count
+//	branch file:line.column,line.column name count
+//
+// with one line for each statement followed by one for each branch.
+func (c *Coverage) WriteProfile(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "mode: count"); err != nil {
+		return err
+	}
+	for _, s := range c.Statements() {
+		if _, err := fmt.Fprintf(w, "%s 1 %d\n", rangeString(s.Position), s.Count); err != nil {
+			return err
+		}
+	}
+	for _, b := range c.Branches() {
+		if _, err := fmt.Fprintf(w, "branch %s %s %d\n", rangeString(b.Position), b.Branch, b.Count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
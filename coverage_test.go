@@ -0,0 +1,97 @@
+package golan_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+const covered = `i = 0
+while i < 2 {
+  i = i + 1
+}
+for x in [1, 2, 3] {
+  if x == 2 {
+    print(x)
+  } else {
+    i = i + x
+  }
+}
+if i > 100 {
+  print("big")
+}
+c = channel(1)
+select {
+  case v = recv(c) {
+    print(v)
+  }
+  default {
+    send(c, i)
+  }
+}
+`
+
+const coveredProfile = `mode: count
+<string>:1.1,1.5 1 1
+<string>:2.1,4.1 1 1
+<string>:3.3,3.11 1 2
+<string>:5.1,11.1 1 1
+<string>:6.3,10.3 1 3
+<string>:7.5,7.12 1 1
+<string>:9.5,9.13 1 2
+<string>:12.1,14.1 1 1
+<string>:13.3,13.14 1 0
+<string>:15.1,15.14 1 1
+<string>:16.1,23.1 1 1
+<string>:18.5,18.12 1 0
+<string>:21.5,21.14 1 1
+branch <string>:2.1,4.1 body 2
+branch <string>:2.1,4.1 exit 1
+branch <string>:5.1,11.1 body 3
+branch <string>:5.1,11.1 exit 1
+branch <string>:6.3,10.3 else 2
+branch <string>:6.3,10.3 then 1
+branch <string>:12.1,14.1 else 1
+branch <string>:12.1,14.1 then 0
+branch <string>:16.1,23.1 case 1 0
+branch <string>:16.1,23.1 default 1
+`
+
+func TestCoverageProfile(t *testing.T) {
+	tree, err := golan.Parse(covered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := golan.NewCoverage()
+	c.Add(tree)
+	e := golan.NewEngine(golan.WithOutput(&bytes.Buffer{}))
+	e.SetCoverage(c)
+	if _, err := e.Execute(tree); err != nil {
+		t.Fatal(err)
+	}
+	var profile bytes.Buffer
+	if err := c.WriteProfile(&profile); err != nil {
+		t.Fatal(err)
+	}
+	if profile.String() != coveredProfile {
+		t.Errorf("profile =\n%s\nwant\n%s", profile.String(), coveredProfile)
+	}
+	statements, branches := c.Percent()
+	if statements != 100*11.0/13 || branches != 80 {
+		t.Errorf("percent = %v, %v, want %v, 80", statements, branches, 100*11.0/13)
+	}
+}
+
+func TestCoverageUnregistered(t *testing.T) {
+	c := golan.NewCoverage()
+	if s, b := c.Percent(); s != 0 || b != 0 {
+		t.Errorf("percent of nothing = %v, %v, want 0, 0", s, b)
+	}
+	e := golan.NewEngine()
+	e.SetCoverage(c)
+	run(t, e, "if true {\n  x = 1\n}\n")
+	if len(c.Statements()) != 0 || len(c.Branches()) != 0 {
+		t.Errorf("counted unregistered tree: %v %v", c.Statements(), c.Branches())
+	}
+}
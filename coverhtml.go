@@ -0,0 +1,134 @@
+package golan
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+const coverHTMLHead = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>golan coverage</title>
+<style>
+body { font-family: sans-serif; background: #fafafa; }
+pre { background: #fff; border: 1px solid #ddd; padding: 8px; }
+.line { display: block; }
+.lineno { color: #999; display: inline-block; width: 4em; text-align: right; margin-right: 1em; }
+.covered { background: #d8f5d8; }
+.uncovered { background: #f8d0d0; }
+.partial { background: #fbeeb8; }
+</style>
+</head>
+<body>
+`
+
+// lineCoverage is what the report knows about one source line.
+type lineCoverage struct {
+	statements []StatementCoverage
+	branches   []BranchCoverage
+}
+
+func (l *lineCoverage) class() string {
+	if len(l.statements) == 0 {
+		return ""
+	}
+	for _, s := range l.statements {
+		if s.Count == 0 {
+			return "uncovered"
+		}
+	}
+	for _, b := range l.branches {
+		if b.Count == 0 {
+			return "partial"
+		}
+	}
+	return "covered"
+}
+
+func (l *lineCoverage) title() string {
+	parts := []string{}
+	for _, s := range l.statements {
+		parts = append(parts, fmt.Sprintf("%d.%d: %d", s.Position.FirstLineno, s.Position.FirstColumn, s.Count))
+	}
+	for _, b := range l.branches {
+		parts = append(parts, fmt.Sprintf("%s: %d", b.Branch, b.Count))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// WriteHTML writes a report showing the source of every registered file
+// with its lines highlighted: green when all statements starting on the
+// line ran, red when one did not, and yellow when a branch of a statement
+// starting on the line was never taken. Hovering a line shows its counts.
+func (c *Coverage) WriteHTML(w io.Writer) error {
+	files := map[string]map[int]*lineCoverage{}
+	line := func(p Position) *lineCoverage {
+		lines, ok := files[p.Source]
+		if !ok {
+			lines = map[int]*lineCoverage{}
+			files[p.Source] = lines
+		}
+		l, ok := lines[p.FirstLineno]
+		if !ok {
+			l = &lineCoverage{}
+			lines[p.FirstLineno] = l
+		}
+		return l
+	}
+	for _, s := range c.Statements() {
+		l := line(s.Position)
+		l.statements = append(l.statements, s)
+	}
+	for _, b := range c.Branches() {
+		l := line(b.Position)
+		l.branches = append(l.branches, b)
+	}
+	sources := []string{}
+	for s := range files {
+		sources = append(sources, s)
+	}
+	sort.Strings(sources)
+
+	var b strings.Builder
+	b.WriteString(coverHTMLHead)
+	for _, source := range sources {
+		src, err := os.ReadFile(source)
+		if err != nil {
+			continue
+		}
+		lines := files[source]
+		total, covered := 0, 0
+		for _, l := range lines {
+			for _, s := range l.statements {
+				total++
+				if s.Count > 0 {
+					covered++
+				}
+			}
+		}
+		pct := 100.0
+		if total > 0 {
+			pct = 100 * float64(covered) / float64(total)
+		}
+		fmt.Fprintf(&b, "<h2>%s (%.1f%%)</h2>\n<pre>", html.EscapeString(source), pct)
+		for i, text := range strings.Split(strings.TrimSuffix(string(src), "\n"), "\n") {
+			text = html.EscapeString(strings.TrimSuffix(text, "\r"))
+			l, ok := lines[i+1]
+			if !ok || l.class() == "" {
+				fmt.Fprintf(&b, "<span class=\"line\"><span class=\"lineno\">%d</span>%s</span>", i+1, text)
+				continue
+			}
+			fmt.Fprintf(&b, "<span class=\"line %s\" title=\"%s\"><span class=\"lineno\">%d</span>%s</span>",
+				l.class(), html.EscapeString(l.title()), i+1, text)
+		}
+		b.WriteString("</pre>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,432 @@
+// Package dap implements a Debug Adapter Protocol server running golan
+// scripts under the debugger package.
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/arikui1911/golan"
+	"github.com/arikui1911/golan/debugger"
+	"github.com/arikui1911/golan/internal/jsonrpc"
+)
+
+// The script runs as the only thread.
+const threadID = 1
+
+// The variables of the frame with ID f have the references
+// 2*f+localsReference and 2*f+globalsReference.
+const (
+	localsReference = iota + 1
+	globalsReference
+)
+
+var errTerminated = errors.New("terminated by the debugger")
+
+// command is run by the script goroutine while it is stopped; fn inspects
+// the engine, otherwise the script resumes in mode.
+type command struct {
+	fn    func(e *golan.Engine)
+	mode  debugger.Mode
+	abort bool
+}
+
+// Server serves one debug session.
+type Server struct {
+	in      *bufio.Reader
+	out     io.Writer
+	wmu     sync.Mutex
+	seq     int
+	dbg     *debugger.Debugger
+	launch  *LaunchArguments
+	tree    golan.Node
+	mu      sync.Mutex
+	stopped bool
+	abort   bool
+	running bool
+	// after runs once the response to the current request is written.
+	after func()
+
+	commands chan command
+	finished chan struct{}
+}
+
+// NewServer returns a server reading requests from r and writing
+// responses and events to w.
+func NewServer(r io.Reader, w io.Writer) *Server {
+	s := &Server{
+		in:       bufio.NewReader(r),
+		out:      w,
+		commands: make(chan command),
+		finished: make(chan struct{}),
+	}
+	s.dbg = debugger.New(s.stop, false)
+	return s
+}
+
+// Serve handles requests until the client disconnects or closes the
+// stream.
+func (s *Server) Serve() error {
+	for {
+		msg, err := s.read()
+		if err == io.EOF {
+			s.terminate()
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Type != "request" {
+			continue
+		}
+		if msg.Command == "disconnect" {
+			s.terminate()
+			s.respond(msg, nil, nil)
+			return nil
+		}
+		s.handle(msg)
+	}
+}
+
+func (s *Server) read() (*message, error) {
+	body, err := jsonrpc.Read(s.in)
+	if err != nil {
+		return nil, err
+	}
+	msg := &message{}
+	if err := json.Unmarshal(body, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (s *Server) write(msg *message) {
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	s.seq++
+	msg.Seq = s.seq
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	jsonrpc.Write(s.out, body)
+}
+
+func (s *Server) respond(req *message, body any, err error) {
+	ok := err == nil
+	msg := &message{Type: "response", RequestSeq: req.Seq, Command: req.Command, Success: &ok, Body: body}
+	if err != nil {
+		msg.Message = err.Error()
+	}
+	s.write(msg)
+}
+
+func (s *Server) event(name string, body any) {
+	s.write(&message{Type: "event", Event: name, Body: body})
+}
+
+type handler func(s *Server, args json.RawMessage) (any, error)
+
+var handlers = map[string]handler{
+	"initialize":        (*Server).initialize,
+	"launch":            (*Server).launchRequest,
+	"setBreakpoints":    (*Server).setBreakpoints,
+	"configurationDone": (*Server).configurationDone,
+	"threads":           (*Server).threads,
+	"stackTrace":        (*Server).stackTrace,
+	"scopes":            (*Server).scopes,
+	"variables":         (*Server).variables,
+	"evaluate":          (*Server).evaluate,
+	"continue":          resume(debugger.Continue),
+	"next":              resume(debugger.StepOver),
+	"stepIn":            resume(debugger.StepIn),
+	"stepOut":           resume(debugger.StepOut),
+	"pause":             (*Server).pause,
+}
+
+func (s *Server) handle(msg *message) {
+	h, ok := handlers[msg.Command]
+	if !ok {
+		s.respond(msg, nil, fmt.Errorf("unsupported request - %s", msg.Command))
+		return
+	}
+	body, err := h(s, msg.Arguments)
+	s.respond(msg, body, err)
+	if err == nil && msg.Command == "initialize" {
+		s.event("initialized", nil)
+	}
+	if s.after != nil {
+		s.after()
+		s.after = nil
+	}
+}
+
+func (s *Server) initialize(json.RawMessage) (any, error) {
+	return map[string]any{
+		"supportsConfigurationDoneRequest": true,
+		"supportsEvaluateForHovers":        true,
+	}, nil
+}
+
+func (s *Server) launchRequest(raw json.RawMessage) (any, error) {
+	var args LaunchArguments
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	tree, err := golan.ParseFile(args.Program)
+	if err != nil {
+		return nil, err
+	}
+	s.launch, s.tree = &args, tree
+	return nil, nil
+}
+
+func (s *Server) setBreakpoints(raw json.RawMessage) (any, error) {
+	var args SetBreakpointsArguments
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	s.dbg.ClearBreakpoints(args.Source.Path)
+	r := []Breakpoint{}
+	for _, b := range args.Breakpoints {
+		s.dbg.SetBreakpoint(args.Source.Path, b.Line)
+		r = append(r, Breakpoint{Verified: true, Line: b.Line, Source: &args.Source})
+	}
+	return map[string]any{"breakpoints": r}, nil
+}
+
+// configurationDone starts the launched script.
+func (s *Server) configurationDone(json.RawMessage) (any, error) {
+	if s.tree == nil {
+		return nil, errors.New("no program launched")
+	}
+	s.mu.Lock()
+	s.running = true
+	s.mu.Unlock()
+	if s.launch.StopOnEntry {
+		s.dbg.Pause()
+	}
+	// The protocol runs on the standard streams of the process, so the
+	// script gets no input and its output is sent as output events.
+	e := golan.NewEngine(
+		golan.WithInput(strings.NewReader("")),
+		golan.WithOutput(&outputWriter{s, "stdout"}),
+		golan.WithErrorOutput(&outputWriter{s, "stderr"}),
+	)
+	if !s.launch.NoDebug {
+		e.SetDebugHook(s.dbg)
+	}
+	go s.run(e)
+	return nil, nil
+}
+
+func (s *Server) run(e *golan.Engine) {
+	defer close(s.finished)
+	code := 0
+	if _, err := e.Execute(s.tree); err != nil && !errors.Is(err, errTerminated) {
+		s.event("output", OutputEventBody{"stderr", err.Error() + "\n"})
+		code = 1
+	}
+	s.event("exited", ExitedEventBody{code})
+	s.event("terminated", nil)
+}
+
+// outputWriter sends what the script writes as output events.
+type outputWriter struct {
+	s        *Server
+	category string
+}
+
+func (w *outputWriter) Write(b []byte) (int, error) {
+	w.s.event("output", OutputEventBody{w.category, string(b)})
+	return len(b), nil
+}
+
+// stop is the debugger.StopFunc of the session.
+func (s *Server) stop(e *golan.Engine, statement golan.Node, reason string) (debugger.Mode, error) {
+	s.mu.Lock()
+	if s.abort {
+		s.mu.Unlock()
+		return debugger.Continue, errTerminated
+	}
+	s.stopped = true
+	s.mu.Unlock()
+	s.event("stopped", StoppedEventBody{reason, threadID, true})
+	for c := range s.commands {
+		if c.fn != nil {
+			c.fn(e)
+			continue
+		}
+		s.mu.Lock()
+		s.stopped = false
+		s.mu.Unlock()
+		if c.abort {
+			return debugger.Continue, errTerminated
+		}
+		return c.mode, nil
+	}
+	return debugger.Continue, errTerminated
+}
+
+// inspect runs f on the script goroutine while it is stopped.
+func (s *Server) inspect(f func(e *golan.Engine)) error {
+	s.mu.Lock()
+	stopped := s.stopped
+	s.mu.Unlock()
+	if !stopped {
+		return errors.New("not stopped")
+	}
+	done := make(chan struct{})
+	s.commands <- command{fn: func(e *golan.Engine) {
+		f(e)
+		close(done)
+	}}
+	<-done
+	return nil
+}
+
+// resume returns the handler of a request resuming the script in mode.
+func resume(mode debugger.Mode) handler {
+	return func(s *Server, _ json.RawMessage) (any, error) {
+		s.mu.Lock()
+		stopped := s.stopped
+		s.mu.Unlock()
+		if !stopped {
+			return nil, errors.New("not stopped")
+		}
+		s.after = func() { s.commands <- command{mode: mode} }
+		if mode == debugger.Continue {
+			return map[string]any{"allThreadsContinued": true}, nil
+		}
+		return nil, nil
+	}
+}
+
+func (s *Server) pause(json.RawMessage) (any, error) {
+	s.dbg.Pause()
+	return nil, nil
+}
+
+// terminate aborts a running script and waits for it to finish.
+func (s *Server) terminate() {
+	s.mu.Lock()
+	running, stopped := s.running, s.stopped
+	s.abort = true
+	s.mu.Unlock()
+	if !running {
+		return
+	}
+	if stopped {
+		s.commands <- command{abort: true}
+	} else {
+		s.dbg.Pause()
+	}
+	<-s.finished
+}
+
+func (s *Server) threads(json.RawMessage) (any, error) {
+	return map[string]any{"threads": []Thread{{threadID, "main"}}}, nil
+}
+
+func (s *Server) stackTrace(json.RawMessage) (any, error) {
+	frames := []StackFrame{}
+	err := s.inspect(func(e *golan.Engine) {
+		for i, f := range e.CallStack() {
+			sf := StackFrame{ID: i, Name: f.Function}
+			if p := f.Position; p != nil {
+				sf.Source = &Source{Name: filepath.Base(p.Source), Path: p.Source}
+				sf.Line, sf.Column = p.FirstLineno, p.FirstColumn
+			}
+			frames = append(frames, sf)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"stackFrames": frames, "totalFrames": len(frames)}, nil
+}
+
+func (s *Server) scopes(raw json.RawMessage) (any, error) {
+	var args ScopesArguments
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	n := 0
+	err := s.inspect(func(e *golan.Engine) {
+		n = len(e.CallStack())
+	})
+	if err != nil {
+		return nil, err
+	}
+	if args.FrameID < 0 || args.FrameID >= n {
+		return nil, fmt.Errorf("invalid frame - %d", args.FrameID)
+	}
+	return map[string]any{"scopes": []Scope{
+		{Name: "Locals", VariablesReference: 2*args.FrameID + localsReference},
+		{Name: "Globals", VariablesReference: 2*args.FrameID + globalsReference},
+	}}, nil
+}
+
+func (s *Server) variables(raw json.RawMessage) (any, error) {
+	var args VariablesArguments
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	frame, kind := (args.VariablesReference-1)/2, (args.VariablesReference-1)%2+1
+	vars := []Variable{}
+	err := s.inspect(func(e *golan.Engine) {
+		var values map[string]golan.Value
+		switch kind {
+		case localsReference:
+			values = e.FrameLocals(frame)
+		case globalsReference:
+			values = e.FrameGlobals(frame)
+		}
+		names := []string{}
+		for k := range values {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		for _, k := range names {
+			vars = append(vars, Variable{Name: k, Value: fmt.Sprint(values[k]), Type: typeName(values[k])})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"variables": vars}, nil
+}
+
+func (s *Server) evaluate(raw json.RawMessage) (any, error) {
+	var args EvaluateArguments
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	var v golan.Value
+	var evalErr error
+	err := s.inspect(func(e *golan.Engine) {
+		if args.FrameID < 0 || args.FrameID >= len(e.CallStack()) {
+			evalErr = fmt.Errorf("invalid frame - %d", args.FrameID)
+			return
+		}
+		v, evalErr = e.EvalFrame(args.Expression, args.FrameID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if evalErr != nil {
+		return nil, evalErr
+	}
+	return map[string]any{"result": fmt.Sprint(v), "type": typeName(v), "variablesReference": 0}, nil
+}
+
+func typeName(v golan.Value) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", v), "golan.")
+}
@@ -0,0 +1,248 @@
+package dap
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arikui1911/golan/internal/jsonrpc/jsonrpctest"
+)
+
+const testProgram = `g = 10
+func f(a) {
+  b = a * 2
+  return b + g
+}
+x = 7
+print(f(x))
+`
+
+// client numbers its requests and collects the output of the script.
+type client struct {
+	*jsonrpctest.Client
+	seq int
+	// output is what the script wrote, from output events.
+	output string
+}
+
+func newClient(t *testing.T) *client {
+	c := &client{}
+	c.Client = jsonrpctest.Start(t, func(r io.Reader, w io.Writer) error {
+		return NewServer(r, w).Serve()
+	}, func() {
+		c.seq++
+		c.Send(map[string]any{"seq": c.seq, "type": "request", "command": "disconnect"})
+	})
+	return c
+}
+
+func (c *client) receive() *message {
+	msg := &message{}
+	c.Receive(msg)
+	if msg.Event == "output" {
+		var body OutputEventBody
+		decode(c.T, msg.Body, &body)
+		c.output += body.Output
+	}
+	return msg
+}
+
+// request sends a request and returns its response, decoding the body into
+// body unless it is nil.
+func (c *client) request(command string, args any, body any) *message {
+	c.T.Helper()
+	c.seq++
+	c.Send(map[string]any{"seq": c.seq, "type": "request", "command": command, "arguments": args})
+	for {
+		msg := c.receive()
+		if msg.Type != "response" {
+			continue
+		}
+		if msg.RequestSeq != c.seq || msg.Command != command {
+			c.T.Fatalf("%s: got response to %s #%d", command, msg.Command, msg.RequestSeq)
+		}
+		if body != nil && msg.Success != nil && *msg.Success {
+			decode(c.T, msg.Body, body)
+		}
+		return msg
+	}
+}
+
+// mustRequest is request for a request that must succeed.
+func (c *client) mustRequest(command string, args any, body any) {
+	c.T.Helper()
+	if msg := c.request(command, args, body); msg.Success == nil || !*msg.Success {
+		c.T.Fatalf("%s: %s", command, msg.Message)
+	}
+}
+
+// wait reads messages until the event name, and decodes its body.
+func (c *client) wait(name string, body any) {
+	c.T.Helper()
+	for {
+		msg := c.receive()
+		if msg.Type == "event" && msg.Event == name {
+			if body != nil {
+				decode(c.T, msg.Body, body)
+			}
+			return
+		}
+	}
+}
+
+func decode(t *testing.T, v any, r any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, r); err != nil {
+		t.Fatalf("%v in %s", err, b)
+	}
+}
+
+// stopAt launches testProgram and runs it to a breakpoint on line.
+func stopAt(t *testing.T, line int) (*client, string) {
+	program := filepath.Join(t.TempDir(), "test.gl")
+	if err := os.WriteFile(program, []byte(testProgram), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := newClient(t)
+	var capabilities map[string]bool
+	c.mustRequest("initialize", map[string]any{"adapterID": "golan"}, &capabilities)
+	if !capabilities["supportsEvaluateForHovers"] {
+		t.Errorf("capabilities = %v", capabilities)
+	}
+	c.wait("initialized", nil)
+	c.mustRequest("launch", map[string]any{"program": program}, nil)
+	c.mustRequest("setBreakpoints", map[string]any{
+		"source":      map[string]any{"path": program},
+		"breakpoints": []any{map[string]any{"line": line}},
+	}, nil)
+	c.mustRequest("configurationDone", nil, nil)
+	var stopped StoppedEventBody
+	c.wait("stopped", &stopped)
+	if stopped.Reason != "breakpoint" || stopped.ThreadID != threadID {
+		t.Fatalf("stopped = %+v", stopped)
+	}
+	return c, program
+}
+
+func (c *client) stackTrace() []StackFrame {
+	var body struct {
+		StackFrames []StackFrame `json:"stackFrames"`
+	}
+	c.mustRequest("stackTrace", map[string]any{"threadId": threadID}, &body)
+	return body.StackFrames
+}
+
+// variables returns the variables of a scope of a frame as name=value.
+func (c *client) variables(frame int, scope string) map[string]string {
+	c.T.Helper()
+	var scopes struct {
+		Scopes []Scope `json:"scopes"`
+	}
+	c.mustRequest("scopes", map[string]any{"frameId": frame}, &scopes)
+	for _, s := range scopes.Scopes {
+		if s.Name != scope {
+			continue
+		}
+		var vars struct {
+			Variables []Variable `json:"variables"`
+		}
+		c.mustRequest("variables", map[string]any{"variablesReference": s.VariablesReference}, &vars)
+		r := map[string]string{}
+		for _, v := range vars.Variables {
+			r[v.Name] = v.Value
+		}
+		return r
+	}
+	c.T.Fatalf("no scope %s in %+v", scope, scopes.Scopes)
+	return nil
+}
+
+func TestStackTrace(t *testing.T) {
+	c, program := stopAt(t, 4)
+	frames := c.stackTrace()
+	if len(frames) != 2 {
+		t.Fatalf("stackFrames = %+v, want 2 frames", frames)
+	}
+	if f := frames[0]; f.Name != "f" || f.Line != 4 || f.Source == nil || f.Source.Path != program {
+		t.Errorf("frame 0 = %+v, want f at line 4", f)
+	}
+	if f := frames[1]; f.Line != 7 {
+		t.Errorf("frame 1 = %+v, want line 7", f)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	c, program := stopAt(t, 4)
+	var result struct {
+		Result string `json:"result"`
+		Type   string `json:"type"`
+	}
+	c.mustRequest("evaluate", map[string]any{"expression": "a + b", "frameId": 0, "context": "hover"}, &result)
+	if result.Result != "21" || result.Type != "Integer" {
+		t.Errorf("evaluate = %+v, want 21", result)
+	}
+	if msg := c.request("evaluate", map[string]any{"expression": "nope", "frameId": 0}, nil); *msg.Success {
+		t.Errorf("evaluate of an undefined variable succeeded")
+	}
+	// Evaluating must not move the frame to the evaluated source.
+	if f := c.stackTrace()[0]; f.Line != 4 || f.Column != 3 || f.Source == nil || f.Source.Path != program {
+		t.Errorf("frame 0 after evaluate = %+v, want line 4 of %s", f, program)
+	}
+}
+
+func TestEvaluateFrame(t *testing.T) {
+	c, _ := stopAt(t, 4)
+	var result struct {
+		Result string `json:"result"`
+	}
+	c.mustRequest("evaluate", map[string]any{"expression": "x + g", "frameId": 1, "context": "watch"}, &result)
+	if result.Result != "17" {
+		t.Errorf("evaluate in frame 1 = %+v, want 17", result)
+	}
+	if msg := c.request("evaluate", map[string]any{"expression": "a", "frameId": 1}, nil); *msg.Success {
+		t.Errorf("evaluate of a local of frame 0 in frame 1 succeeded")
+	}
+	if msg := c.request("evaluate", map[string]any{"expression": "g", "frameId": 2}, nil); *msg.Success {
+		t.Errorf("evaluate in frame 2 succeeded")
+	}
+	// The locals of frame 0 are back after evaluating in frame 1.
+	c.mustRequest("evaluate", map[string]any{"expression": "a + b", "frameId": 0}, &result)
+	if result.Result != "21" {
+		t.Errorf("evaluate in frame 0 = %+v, want 21", result)
+	}
+}
+
+func TestVariables(t *testing.T) {
+	c, _ := stopAt(t, 4)
+	if v := c.variables(0, "Locals"); len(v) != 2 || v["a"] != "7" || v["b"] != "14" {
+		t.Errorf("locals of frame 0 = %v, want a and b", v)
+	}
+	if v := c.variables(1, "Locals"); len(v) != 0 {
+		t.Errorf("locals of frame 1 = %v, want none", v)
+	}
+	if v := c.variables(1, "Globals"); v["g"] != "10" || v["x"] != "7" {
+		t.Errorf("globals of frame 1 = %v, want g and x", v)
+	}
+	if msg := c.request("scopes", map[string]any{"frameId": 2}, nil); *msg.Success {
+		t.Errorf("scopes of frame 2 succeeded")
+	}
+}
+
+func TestContinue(t *testing.T) {
+	c, _ := stopAt(t, 4)
+	c.mustRequest("continue", map[string]any{"threadId": threadID}, nil)
+	var exited ExitedEventBody
+	c.wait("exited", &exited)
+	if exited.ExitCode != 0 {
+		t.Errorf("exit code = %d", exited.ExitCode)
+	}
+	c.wait("terminated", nil)
+	if c.output != "24\n" {
+		t.Errorf("output = %q, want %q", c.output, "24\n")
+	}
+}
@@ -0,0 +1,176 @@
+package golan
+
+import (
+	"fmt"
+	"time"
+)
+
+// DebugHook is notified by the engine before each statement of a block is
+// executed. Returning an error aborts execution with that error. Tasks and
+// generators notify it from their own goroutines, passing engines of their
+// own, so a hook must be safe for concurrent use.
+type DebugHook interface {
+	BeforeStatement(e *Engine, statement Node) error
+}
+
+// SetDebugHook installs h, or removes the hook when h is nil.
+func (e *Engine) SetDebugHook(h DebugHook) {
+	e.hook = h
+}
+
+// Frame is an entry of the call stack: the function running and the
+// statement it is executing.
+type Frame struct {
+	Function string
+	Position *Position
+	// scope and env are the variables of a frame that called another,
+	// saved by pushFrame; those of the top frame are the engine's own.
+	scope *scope
+	env   map[string]Value
+}
+
+type callStack struct {
+	frames []*Frame
+	// last is the time of the latest event the profiler saw on the stack.
+	last time.Time
+}
+
+func newCallStack() *callStack {
+	return &callStack{frames: []*Frame{{Function: "main"}}}
+}
+
+func (s *callStack) push(name string) *Frame {
+	f := &Frame{Function: name}
+	s.frames = append(s.frames, f)
+	return f
+}
+
+// pushFrame saves the variables of the running frame and pushes a frame
+// for the function name.
+func (e *Engine) pushFrame(name string) *Frame {
+	f := e.stack.top()
+	f.scope, f.env = e.scope, e.env
+	return e.stack.push(name)
+}
+
+func (s *callStack) pop() {
+	s.frames = s.frames[:len(s.frames)-1]
+}
+
+func (s *callStack) top() *Frame {
+	return s.frames[len(s.frames)-1]
+}
+
+// CallStack returns the frames of the running script, innermost first.
+func (e *Engine) CallStack() []Frame {
+	r := []Frame{}
+	for i := len(e.stack.frames) - 1; i >= 0; i-- {
+		r = append(r, *e.stack.frames[i])
+	}
+	return r
+}
+
+// Eval parses src and executes it in the scope of the running statement.
+// The debug hook, the profiler and coverage do not see it, and the
+// position of the running frame is kept.
+func (e *Engine) Eval(src string) (Value, error) {
+	return e.EvalFrame(src, 0)
+}
+
+// EvalFrame is Eval in the scope of the frame i of CallStack.
+func (e *Engine) EvalFrame(src string, i int) (Value, error) {
+	s, env := e.frame(i)
+	if env == nil {
+		return nil, fmt.Errorf("invalid frame - %d", i)
+	}
+	tree, err := ParseSource("<eval>", src+"\n")
+	if err != nil {
+		return nil, err
+	}
+	top := e.stack.top()
+	position := top.Position
+	h, p, c := e.hook, e.profiler, e.coverage
+	scope, globals := e.scope, e.env
+	e.hook, e.profiler, e.coverage = nil, nil, nil
+	e.scope, e.env = s, env
+	defer func() {
+		e.hook, e.profiler, e.coverage = h, p, c
+		e.scope, e.env = scope, globals
+		top.Position = position
+	}()
+	statements := []Node{tree}
+	if b, ok := tree.(*Block); ok {
+		statements = b.statements
+	}
+	var v Value
+	for _, s := range statements {
+		v, err = e.execNode(s)
+		if r, ok := err.(*returnSignal); ok {
+			return r.value, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// Locals returns the variables of the running function and the functions
+// enclosing it; inner bindings hide outer ones.
+func (e *Engine) Locals() map[string]Value {
+	return e.FrameLocals(0)
+}
+
+// Globals returns the global variables of the running script or module.
+func (e *Engine) Globals() map[string]Value {
+	return e.FrameGlobals(0)
+}
+
+// FrameLocals is Locals of the frame i of CallStack, or nil if there is
+// no such frame.
+func (e *Engine) FrameLocals(i int) map[string]Value {
+	s, env := e.frame(i)
+	if env == nil {
+		return nil
+	}
+	e.vars.RLock()
+	defer e.vars.RUnlock()
+	r := map[string]Value{}
+	for ; s != nil; s = s.parent {
+		for k, v := range s.vars {
+			if _, ok := r[k]; !ok {
+				r[k] = v
+			}
+		}
+	}
+	return r
+}
+
+// FrameGlobals is Globals of the frame i of CallStack, or nil if there is
+// no such frame.
+func (e *Engine) FrameGlobals(i int) map[string]Value {
+	_, env := e.frame(i)
+	if env == nil {
+		return nil
+	}
+	e.vars.RLock()
+	defer e.vars.RUnlock()
+	r := map[string]Value{}
+	for k, v := range env {
+		r[k] = v
+	}
+	return r
+}
+
+// frame returns the variables of the frame i of CallStack.
+func (e *Engine) frame(i int) (*scope, map[string]Value) {
+	n := len(e.stack.frames)
+	switch {
+	case i == 0:
+		return e.scope, e.env
+	case i < 0 || i >= n:
+		return nil, nil
+	}
+	f := e.stack.frames[n-1-i]
+	return f.scope, f.env
+}
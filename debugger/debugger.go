@@ -0,0 +1,178 @@
+// Package debugger implements breakpoints and stepping on top of the
+// engine's debug hook. Front ends decide what happens when execution
+// stops.
+package debugger
+
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/arikui1911/golan"
+)
+
+// Mode is how execution resumes after a stop.
+type Mode int
+
+const (
+	// Continue runs until the next breakpoint.
+	Continue Mode = iota
+	// StepIn stops at the next statement.
+	StepIn
+	// StepOver stops at the next statement of the same or a calling
+	// function.
+	StepOver
+	// StepOut stops at the next statement of a calling function.
+	StepOut
+)
+
+// Reasons passed to a StopFunc.
+const (
+	ReasonEntry      = "entry"
+	ReasonBreakpoint = "breakpoint"
+	ReasonStep       = "step"
+	ReasonPause      = "pause"
+)
+
+// StopFunc is called when execution stops before statement. It returns how
+// to resume; a non-nil error aborts the script.
+type StopFunc func(e *golan.Engine, statement golan.Node, reason string) (Mode, error)
+
+// Breakpoint is a source line to stop at.
+type Breakpoint struct {
+	Source string
+	Line   int
+}
+
+// Debugger is a golan.DebugHook. Tasks stop one at a time: a task reaching
+// a stop while another is stopped waits until that one resumes.
+type Debugger struct {
+	mu          sync.Mutex
+	stopping    sync.Mutex
+	stop        StopFunc
+	breakpoints map[Breakpoint]bool
+	abs         map[string]string
+	mode        Mode
+	depth       int
+	started     bool
+	pause       bool
+}
+
+// New returns a debugger calling stop whenever execution stops. With
+// stopOnEntry, it stops before the first statement.
+func New(stop StopFunc, stopOnEntry bool) *Debugger {
+	d := &Debugger{
+		stop:        stop,
+		breakpoints: map[Breakpoint]bool{},
+		abs:         map[string]string{},
+		mode:        Continue,
+	}
+	if stopOnEntry {
+		d.mode = StepIn
+	}
+	return d
+}
+
+func (d *Debugger) path(source string) string {
+	if p, ok := d.abs[source]; ok {
+		return p
+	}
+	p, err := filepath.Abs(source)
+	if err != nil {
+		p = source
+	}
+	d.abs[source] = p
+	return p
+}
+
+// SetBreakpoint adds a breakpoint at line of source.
+func (d *Debugger) SetBreakpoint(source string, line int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.breakpoints[Breakpoint{d.path(source), line}] = true
+}
+
+// ClearBreakpoint removes the breakpoint at line of source and reports
+// whether there was one.
+func (d *Debugger) ClearBreakpoint(source string, line int) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	b := Breakpoint{d.path(source), line}
+	ok := d.breakpoints[b]
+	delete(d.breakpoints, b)
+	return ok
+}
+
+// ClearBreakpoints removes every breakpoint in source.
+func (d *Debugger) ClearBreakpoints(source string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	p := d.path(source)
+	for b := range d.breakpoints {
+		if b.Source == p {
+			delete(d.breakpoints, b)
+		}
+	}
+}
+
+// Breakpoints returns the breakpoints ordered by source and line.
+func (d *Debugger) Breakpoints() []Breakpoint {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	r := []Breakpoint{}
+	for b := range d.breakpoints {
+		r = append(r, b)
+	}
+	sort.Slice(r, func(i, j int) bool {
+		if r[i].Source != r[j].Source {
+			return r[i].Source < r[j].Source
+		}
+		return r[i].Line < r[j].Line
+	})
+	return r
+}
+
+// Pause makes execution stop before the next statement.
+func (d *Debugger) Pause() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pause = true
+}
+
+// BeforeStatement implements golan.DebugHook.
+func (d *Debugger) BeforeStatement(e *golan.Engine, statement golan.Node) error {
+	p := statement.Position()
+	depth := len(e.CallStack())
+
+	d.mu.Lock()
+	reason := ""
+	switch {
+	case d.pause:
+		reason = ReasonPause
+	case d.breakpoints[Breakpoint{d.path(p.Source), p.FirstLineno}]:
+		reason = ReasonBreakpoint
+	case d.mode == StepIn:
+		reason = ReasonStep
+	case d.mode == StepOver && depth <= d.depth:
+		reason = ReasonStep
+	case d.mode == StepOut && depth < d.depth:
+		reason = ReasonStep
+	}
+	if (reason == ReasonStep || reason == ReasonPause) && !d.started {
+		reason = ReasonEntry
+	}
+	d.started = true
+	d.pause = false
+	d.mu.Unlock()
+	if reason == "" {
+		return nil
+	}
+
+	d.stopping.Lock()
+	defer d.stopping.Unlock()
+	mode, err := d.stop(e, statement, reason)
+	d.mu.Lock()
+	d.mode, d.depth = mode, depth
+	d.mu.Unlock()
+	return err
+}
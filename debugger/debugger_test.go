@@ -0,0 +1,166 @@
+package debugger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+const script = `func f(a) {
+  b = a * 2
+  return b
+}
+x = f(1)
+y = f(2)
+print(x + y)
+`
+
+// parseScript writes script to a file and parses it.
+func parseScript(t *testing.T) (golan.Node, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "script.gl")
+	if err := os.WriteFile(path, []byte(script), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tree, err := golan.ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tree, path
+}
+
+// session runs script under a debugger resuming with modes in turn, and
+// returns the stops as line:reason.
+func session(t *testing.T, stopOnEntry bool, setup func(d *Debugger, path string), modes ...Mode) ([]string, error) {
+	t.Helper()
+	tree, path := parseScript(t)
+	stops := []string{}
+	d := New(func(e *golan.Engine, statement golan.Node, reason string) (Mode, error) {
+		stops = append(stops, fmt.Sprintf("%d:%s", statement.Position().FirstLineno, reason))
+		if len(modes) == 0 {
+			return Continue, nil
+		}
+		m := modes[0]
+		modes = modes[1:]
+		return m, nil
+	}, stopOnEntry)
+	if setup != nil {
+		setup(d, path)
+	}
+	var out bytes.Buffer
+	e := golan.NewEngine(golan.WithOutput(&out))
+	e.SetDebugHook(d)
+	_, err := e.Execute(tree)
+	if err == nil && out.String() != "6\n" {
+		t.Errorf("output = %q, want %q", out.String(), "6\n")
+	}
+	return stops, err
+}
+
+func TestStepping(t *testing.T) {
+	tests := []struct {
+		name        string
+		stopOnEntry bool
+		setup       func(d *Debugger, path string)
+		modes       []Mode
+		want        []string
+	}{
+		{"no stops", false, nil, nil, []string{}},
+		{"entry", true, nil, nil, []string{"1:entry"}},
+		{"step in", true, nil, []Mode{StepIn, StepIn, StepIn, StepIn, StepIn},
+			[]string{"1:entry", "5:step", "2:step", "3:step", "6:step", "2:step"}},
+		{"step over", true, nil, []Mode{StepIn, StepOver, StepOver},
+			[]string{"1:entry", "5:step", "6:step", "7:step"}},
+		{"step out", true, nil, []Mode{StepIn, StepIn, StepOut},
+			[]string{"1:entry", "5:step", "2:step", "6:step"}},
+		{"breakpoint", false, func(d *Debugger, path string) {
+			d.SetBreakpoint(path, 3)
+		}, nil, []string{"3:breakpoint", "3:breakpoint"}},
+		{"cleared breakpoint", false, func(d *Debugger, path string) {
+			d.SetBreakpoint(path, 3)
+			d.SetBreakpoint(path, 7)
+			if !d.ClearBreakpoint(path, 3) {
+				t.Error("ClearBreakpoint = false, want true")
+			}
+		}, nil, []string{"7:breakpoint"}},
+		{"cleared source", false, func(d *Debugger, path string) {
+			d.SetBreakpoint(path, 3)
+			d.SetBreakpoint(path, 7)
+			d.ClearBreakpoints(path)
+		}, nil, []string{}},
+		{"pause before start", false, func(d *Debugger, path string) {
+			d.Pause()
+		}, nil, []string{"1:entry"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := session(t, tt.stopOnEntry, tt.setup, tt.modes...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("stops = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakpoints(t *testing.T) {
+	d := New(nil, false)
+	d.SetBreakpoint("b.gl", 2)
+	d.SetBreakpoint("a.gl", 9)
+	d.SetBreakpoint("a.gl", 1)
+	d.SetBreakpoint("a.gl", 9)
+	a, _ := filepath.Abs("a.gl")
+	b, _ := filepath.Abs("b.gl")
+	want := []Breakpoint{{a, 1}, {a, 9}, {b, 2}}
+	if got := d.Breakpoints(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("breakpoints = %v, want %v", got, want)
+	}
+	if d.ClearBreakpoint("a.gl", 5) {
+		t.Error("ClearBreakpoint of a missing breakpoint = true, want false")
+	}
+}
+
+func TestStopError(t *testing.T) {
+	abort := errors.New("abort")
+	tree, _ := parseScript(t)
+	var out bytes.Buffer
+	e := golan.NewEngine(golan.WithOutput(&out))
+	e.SetDebugHook(New(func(*golan.Engine, golan.Node, string) (Mode, error) {
+		return Continue, abort
+	}, true))
+	if _, err := e.Execute(tree); !errors.Is(err, abort) {
+		t.Errorf("error = %v, want %v", err, abort)
+	}
+	if out.Len() != 0 {
+		t.Errorf("output = %q, want none", out.String())
+	}
+}
+
+func TestPause(t *testing.T) {
+	tree, path := parseScript(t)
+	stops := []string{}
+	var d *Debugger
+	d = New(func(e *golan.Engine, statement golan.Node, reason string) (Mode, error) {
+		stops = append(stops, fmt.Sprintf("%d:%s", statement.Position().FirstLineno, reason))
+		if reason == ReasonBreakpoint {
+			d.Pause()
+		}
+		return Continue, nil
+	}, false)
+	d.SetBreakpoint(path, 6)
+	e := golan.NewEngine(golan.WithOutput(&bytes.Buffer{}))
+	e.SetDebugHook(d)
+	if _, err := e.Execute(tree); err != nil {
+		t.Fatal(err)
+	}
+	if want := "[6:breakpoint 2:pause]"; fmt.Sprint(stops) != want {
+		t.Errorf("stops = %v, want %v", stops, want)
+	}
+}
@@ -1,79 +1,236 @@
 package golan
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"sync"
 )
 
+// Engine executes scripts, one at a time; Fork makes engines to run
+// programs concurrently. Tasks started by spawn run on engines of their
+// own which share the globals, modules and settings of the engine that
+// started them.
 type Engine struct {
-	env map[string]Value
+	builtins map[string]Value
+	env      map[string]Value
+	scope    *scope
+	// vars guards env and the scopes, which tasks may share.
+	vars *sync.RWMutex
+	// importing is the chain of modules being imported, outermost first.
+	importing []string
+	modules   *moduleLoader
+	// program is the program running, if any.
+	program  *Program
+	hook     DebugHook
+	stack    *callStack
+	profiler *Profiler
+	coverage *Coverage
+	// generator is the generator whose body is running, if any.
+	generator *Generator
+	stdout    io.Writer
+	stderr    io.Writer
+	// maxDepth is the number of frames past which calls fail.
+	maxDepth int
 }
 
-func NewEngine() *Engine {
-	return &Engine{
-		env: map[string]Value{
-			"print": NativeFunction(func(e *Engine, args []Value) (Value, error) {
-				for _, v := range args {
-					fmt.Println(v)
-				}
-				return Undefined{}, nil
-			}),
-			"file_readline": NativeFunction(func(e *Engine, args []Value) (Value, error) {
-				if len(args) != 1 {
-					return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1)", len(args))
-				}
-				h, ok := args[0].(*NativeValueHandle)
-				if !ok || h.Info != "File" {
-					return nil, fmt.Errorf("not a NativeValueHandle(File) - %v(%T)", args[0], args[0])
-				}
-				line, err := h.Value.(*bufio.Reader).ReadString('\n')
-				switch err {
-				case nil:
-					return String(line), nil
-				case io.EOF:
-					if len(line) == 0 {
-						return Undefined{}, nil
-					}
-					return String(line), nil
-				}
-				return nil, err
-			}),
-			"stdin": &NativeValueHandle{
-				Info:  "File",
-				Value: bufio.NewReader(os.Stdin),
-			},
-			"len": NativeFunction(func(e *Engine, args []Value) (Value, error) {
-				if len(args) != 1 {
-					return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1)", len(args))
-				}
-				s, ok := args[0].(String)
-				if !ok {
-					return nil, fmt.Errorf("not a String - %v(%T)", args[0], args[0])
-				}
-				return Integer(len(s)), nil
-			}),
-			"format": NativeFunction(func(e *Engine, args []Value) (Value, error) {
-				if len(args) < 1 {
-					return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1..)", len(args))
-				}
-				s, ok := args[0].(String)
-				if !ok {
-					return nil, fmt.Errorf("not a String - %v(%T)", args[0], args[0])
-				}
-				vals := []any{}
-				for _, v := range args[1:] {
-					vals = append(vals, v)
-				}
-				return String(fmt.Sprintf(string(s), vals...)), nil
-			}),
-		},
+// NewEngine returns an engine with the builtins bound, reading and writing
+// the standard streams of the process unless options say otherwise.
+func NewEngine(options ...Option) *Engine {
+	e := &Engine{
+		env:      map[string]Value{},
+		vars:     &sync.RWMutex{},
+		modules:  newModuleLoader(),
+		stack:    newCallStack(),
+		builtins: map[string]Value{},
+		maxDepth: DefaultMaxCallDepth,
+	}
+	for name, b := range builtins {
+		if b.fn != nil {
+			e.builtins[name] = b.fn
+		}
+	}
+	e.SetInput(os.Stdin)
+	e.SetOutput(os.Stdout)
+	e.SetErrorOutput(os.Stderr)
+	for _, o := range options {
+		o(e)
+	}
+	return e
+}
+
+// builtin is a binding of NewEngine. fn is nil for the streams, which
+// the options may replace. signature describes its usage to users, and
+// typ is its type in the notation of the typecheck package.
+type builtin struct {
+	fn        NativeFunction
+	signature string
+	typ       string
+}
+
+var builtins = map[string]builtin{
+	"print":          {builtinPrint, "print(values...)", "func(any...): undefined"},
+	"write":          {builtinWrite, "write(values...)", "func(any...): undefined"},
+	"puts":           {builtinPuts, "puts(values...)", "func(any...): undefined"},
+	"eprint":         {builtinEprint, "eprint(values...)", "func(any...): undefined"},
+	"file_readline":  {builtinFileReadline, "file_readline(file) -> String or undefined", "func(file): string or undefined"},
+	"open":           {builtinOpen, "open(path[, mode]) -> File", "func(string, string?): file"},
+	"file_read_all":  {builtinFileReadAll, "file_read_all(file) -> String", "func(file): string"},
+	"file_write":     {builtinFileWrite, "file_write(file, values...) -> Integer", "func(file, any...): int"},
+	"file_close":     {builtinFileClose, "file_close(file)", "func(file): undefined"},
+	"stdin":          {nil, "stdin: File", "file"},
+	"stdout":         {nil, "stdout: File", "file"},
+	"stderr":         {nil, "stderr: File", "file"},
+	"len":            {builtinLen, "len(x) -> Integer", "func(string or list or map or range or channel): int"},
+	"map":            {builtinMap, "map(key, value, ...) -> Map", "func(any...): map"},
+	"range":          {builtinRange, "range([start,] stop[, step]) -> Range", "func(int or float, int or float?, int or float?): range"},
+	"channel":        {builtinChannel, "channel([capacity]) -> Channel", "func(int?): channel"},
+	"send":           {builtinSend, "send(channel, value)", "func(channel, any): undefined"},
+	"recv":           {builtinRecv, "recv(channel) -> value or undefined", "func(channel): any"},
+	"close":          {builtinClose, "close(channel)", "func(channel): undefined"},
+	"wait":           {builtinWait, "wait(task) -> value", "func(task): any"},
+	"join":           {builtinJoin, "join(tasks...) -> List", "func(task or list...): list"},
+	"format":         {builtinFormat, "format(fmt, values...) -> String", "func(string, any...): string"},
+	"error":          {builtinError, "error([kind,] message) -> Error", "func(string, string?): error"},
+	"error_message":  {builtinErrorMessage, "error_message(e) -> String", "func(error): string"},
+	"error_kind":     {builtinErrorKind, "error_kind(e) -> String", "func(error): string"},
+	"error_position": {builtinErrorPosition, "error_position(e) -> String or undefined", "func(error): string or undefined"},
+	"assert":         {builtinAssert, "assert(cond[, message])", "func(any, string?): undefined"},
+	"assert_eq":      {builtinAssertEq, "assert_eq(actual, expected[, message])", "func(any, any, string?): undefined"},
+	"assert_ne":      {builtinAssertNe, "assert_ne(actual, unexpected[, message])", "func(any, any, string?): undefined"},
+	"assert_raises":  {builtinAssertRaises, "assert_raises(f[, kind]) -> Error", "func(func, string?): error"},
+}
+
+// BuiltinNames returns the names NewEngine binds in sorted order, for
+// tools that need them without an engine.
+func BuiltinNames() []string {
+	names := []string{}
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuiltinSignature returns a one-line usage description of a builtin.
+func BuiltinSignature(name string) (string, bool) {
+	b, ok := builtins[name]
+	return b.signature, ok
+}
+
+// BuiltinType returns the type of a builtin in the notation of the
+// typecheck package, such as "func(string, any...): string".
+func BuiltinType(name string) (string, bool) {
+	b, ok := builtins[name]
+	return b.typ, ok
+}
+
+func builtinPrint(e *Engine, args []Value) (Value, error) {
+	for _, v := range args {
+		fmt.Fprintln(e.stdout, v)
+	}
+	return Undefined{}, nil
+}
+
+func builtinLen(e *Engine, args []Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1)", len(args))
+	}
+	s, ok := args[0].(SizedValue)
+	if !ok {
+		return nil, fmt.Errorf("not a sized value - %v(%T)", args[0], args[0])
 	}
+	return Integer(s.OpLen()), nil
 }
 
+func builtinFormat(e *Engine, args []Value) (Value, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1..)", len(args))
+	}
+	s, ok := args[0].(String)
+	if !ok {
+		return nil, fmt.Errorf("not a String - %v(%T)", args[0], args[0])
+	}
+	vals := []any{}
+	for _, v := range args[1:] {
+		vals = append(vals, v)
+	}
+	return String(fmt.Sprintf(string(s), vals...)), nil
+}
+
+func builtinError(e *Engine, args []Value) (Value, error) {
+	switch len(args) {
+	case 1:
+		s, ok := args[0].(String)
+		if !ok {
+			return nil, fmt.Errorf("not a String - %v(%T)", args[0], args[0])
+		}
+		return &Error{Kind: "RuntimeError", Message: string(s)}, nil
+	case 2:
+		k, ok := args[0].(String)
+		if !ok {
+			return nil, fmt.Errorf("not a String - %v(%T)", args[0], args[0])
+		}
+		s, ok := args[1].(String)
+		if !ok {
+			return nil, fmt.Errorf("not a String - %v(%T)", args[1], args[1])
+		}
+		return &Error{Kind: string(k), Message: string(s)}, nil
+	}
+	return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1..2)", len(args))
+}
+
+func builtinErrorMessage(e *Engine, args []Value) (Value, error) {
+	x, err := errorArgument(args)
+	if err != nil {
+		return nil, err
+	}
+	return String(x.Message), nil
+}
+
+func builtinErrorKind(e *Engine, args []Value) (Value, error) {
+	x, err := errorArgument(args)
+	if err != nil {
+		return nil, err
+	}
+	return String(x.Kind), nil
+}
+
+func builtinErrorPosition(e *Engine, args []Value) (Value, error) {
+	x, err := errorArgument(args)
+	if err != nil {
+		return nil, err
+	}
+	if x.Position == nil {
+		return Undefined{}, nil
+	}
+	return String(x.Position.String()), nil
+}
+
+func errorArgument(args []Value) (*Error, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1)", len(args))
+	}
+	x, ok := args[0].(*Error)
+	if !ok {
+		return nil, fmt.Errorf("not an Error - %v(%T)", args[0], args[0])
+	}
+	return x, nil
+}
+
+// Execute runs tree. Importing the files tree was parsed from is reported
+// as a circular import.
 func (e *Engine) Execute(tree Node) (Value, error) {
-	return e.execNode(tree)
+	if e.importing == nil {
+		e.importing = entryFiles(tree)
+		defer func() { e.importing = nil }()
+	}
+	v, err := e.execNode(tree)
+	if r, ok := err.(*returnSignal); ok {
+		return r.value, nil
+	}
+	return v, err
 }
 
 func (e *Engine) execNode(node Node) (Value, error) {
@@ -81,6 +238,18 @@ func (e *Engine) execNode(node Node) (Value, error) {
 	case *Block:
 		var r Value
 		for _, c := range n.statements {
+			if e.profiler != nil {
+				e.profiler.statement(e.stack, c.Position())
+			}
+			if e.coverage != nil {
+				e.coverage.statement(c.Position())
+			}
+			e.stack.top().Position = c.Position()
+			if e.hook != nil {
+				if err := e.hook.BeforeStatement(e, c); err != nil {
+					return nil, err
+				}
+			}
 			v, err := e.execNode(c)
 			if err != nil {
 				return nil, err
@@ -96,8 +265,14 @@ func (e *Engine) execNode(node Node) (Value, error) {
 				return nil, err
 			}
 			if !ValueTest(v) {
+				if e.coverage != nil {
+					e.coverage.branch(n.position, BranchExit)
+				}
 				break
 			}
+			if e.coverage != nil {
+				e.coverage.branch(n.position, BranchBody)
+			}
 			v, err = e.execNode(n.Body)
 			if err != nil {
 				return nil, err
@@ -105,24 +280,106 @@ func (e *Engine) execNode(node Node) (Value, error) {
 			r = v
 		}
 		return r, nil
+	case *For:
+		return e.execFor(n)
+	case *Select:
+		return e.execSelect(n)
 	case *If:
 		v, err := e.execNode(n.Test)
 		if err != nil {
 			return nil, err
 		}
 		if ValueTest(v) {
+			if e.coverage != nil {
+				e.coverage.branch(n.position, BranchThen)
+			}
 			return e.execNode(n.Then)
 		}
+		if e.coverage != nil {
+			e.coverage.branch(n.position, BranchElse)
+		}
 		if n.Alt == nil {
 			return Undefined{}, nil
 		}
 		return e.execNode(n.Alt)
+	case *Begin:
+		return e.execBegin(n)
+	case *Raise:
+		v, err := e.execNode(n.Expression)
+		if err != nil {
+			return nil, err
+		}
+		switch x := v.(type) {
+		case String:
+			return nil, newError(n.position, "RuntimeError", "%s", x)
+		case *Error:
+			return nil, errorAt(x, x.Kind, n.position)
+		}
+		return nil, newError(n.position, "TypeError", "exception must be a String or an Error - %v(%T)", v, v)
+	case *Import:
+		m, err := e.importModule(n)
+		if err != nil {
+			return nil, err
+		}
+		e.assign(n.Name, m)
+		return m, nil
+	case *Function:
+		c := &Closure{Definition: n, env: e.env, scope: e.scope, generator: e.isGenerator(n)}
+		if n.Name != "" {
+			e.assign(n.Name, c)
+		}
+		return c, nil
+	case *Class:
+		t := &ClassType{Name: n.Name, methods: map[string]*Closure{}, engine: e.task("<class "+n.Name+">", n.position)}
+		for _, m := range n.Methods() {
+			if e.coverage != nil {
+				e.coverage.statement(m.position)
+			}
+			t.methods[m.Name] = &Closure{Definition: m, env: e.env, scope: e.scope, generator: e.isGenerator(m)}
+		}
+		e.assign(n.Name, t)
+		return t, nil
+	case *Struct:
+		t := &StructType{Name: n.Name, Fields: n.Fields}
+		e.assign(n.Name, t)
+		return t, nil
+	case *Return:
+		var v Value = Undefined{}
+		if n.Expression != nil {
+			var err error
+			if v, err = e.execNode(n.Expression); err != nil {
+				return nil, err
+			}
+		}
+		return nil, &returnSignal{v}
+	case *Yield:
+		v, err := e.execNode(n.Expression)
+		if err != nil {
+			return nil, err
+		}
+		if e.generator == nil {
+			return nil, newError(n.position, "RuntimeError", "yield outside of a generator")
+		}
+		if err := e.generator.suspend(v, n.position); err != nil {
+			return nil, err
+		}
+		return Undefined{}, nil
 	case *Assign:
 		v, err := e.execNode(n.Expression)
 		if err != nil {
 			return nil, err
 		}
-		e.env[n.Destination.(*Identifier).Name] = v
+		if a, ok := n.Destination.(*Attribute); ok {
+			x, err := e.execNode(a.Receiver)
+			if err != nil {
+				return nil, err
+			}
+			if err := SetAttribute(x, a.Name, v); err != nil {
+				return nil, errorAt(err, "AttributeError", a.position)
+			}
+			return v, nil
+		}
+		e.assign(n.Destination.(*Identifier).Name, v)
 		return v, nil
 	case *Equal:
 		return e.execCmp(n.Left, n.Right, n.Position(), CMP_EQ)
@@ -140,16 +397,25 @@ func (e *Engine) execNode(node Node) (Value, error) {
 		return e.execCmp(n.Left, n.Right, n.Position(), CMP_GREATER)
 	case *LessThan:
 		return e.execCmp(n.Left, n.Right, n.Position(), CMP_LESS)
+	case *In:
+		return e.execBinArith(n.Left, n.Right, n.Position(), "", func(x Value, y Value) (Value, error) {
+			ok, err := Contains(y, x)
+			return Boolean(ok), err
+		})
+	case *RangeLiteral:
+		return e.execBinArith(n.Start, n.Stop, n.Position(), "", func(x Value, y Value) (Value, error) {
+			return NewRange(x, y, Integer(1), !n.Exclusive)
+		})
 	case *Addition:
-		return e.execBinArith(n.Left, n.Right, n.Position(), AddValues)
+		return e.execBinArith(n.Left, n.Right, n.Position(), "op_add", AddValues)
 	case *Subtraction:
-		return e.execBinArith(n.Left, n.Right, n.Position(), SubtractValues)
+		return e.execBinArith(n.Left, n.Right, n.Position(), "op_sub", SubtractValues)
 	case *Multiplication:
-		return e.execBinArith(n.Left, n.Right, n.Position(), MultiplyValues)
+		return e.execBinArith(n.Left, n.Right, n.Position(), "op_mul", MultiplyValues)
 	case *Division:
-		return e.execBinArith(n.Left, n.Right, n.Position(), DivideValues)
+		return e.execBinArith(n.Left, n.Right, n.Position(), "op_div", DivideValues)
 	case *Modulo:
-		return e.execBinArith(n.Left, n.Right, n.Position(), ModuloValues)
+		return e.execBinArith(n.Left, n.Right, n.Position(), "op_mod", ModuloValues)
 	case *Plus:
 		val, err := e.execNode(n.Expression)
 		if err != nil {
@@ -157,7 +423,10 @@ func (e *Engine) execNode(node Node) (Value, error) {
 		}
 		v, ok := val.(SignableValue)
 		if !ok {
-			return nil, fmt.Errorf("%s: invalid plus sign with %v(%T)", n.Position(), val, val)
+			return nil, newError(n.Position(), "TypeError", "invalid plus sign with %v(%T)", val, val)
+		}
+		if i, ok := v.(*ClassInstance); ok {
+			return i.unary(e, "op_plus")
 		}
 		return v.OpPlus()
 	case *Minus:
@@ -167,7 +436,10 @@ func (e *Engine) execNode(node Node) (Value, error) {
 		}
 		v, ok := val.(SignableValue)
 		if !ok {
-			return nil, fmt.Errorf("%s: invalid minus sign with %v(%T)", n.Position(), val, val)
+			return nil, newError(n.Position(), "TypeError", "invalid minus sign with %v(%T)", val, val)
+		}
+		if i, ok := v.(*ClassInstance); ok {
+			return i.unary(e, "op_minus")
 		}
 		return v.OpMinus()
 	case *Not:
@@ -184,16 +456,77 @@ func (e *Engine) execNode(node Node) (Value, error) {
 		return Float(n.Value), nil
 	case *StringLiteral:
 		return String(n.Value), nil
+	case *ListLiteral:
+		l := &List{Elements: []Value{}}
+		for _, x := range n.Elements {
+			v, err := e.execNode(x)
+			if err != nil {
+				return nil, err
+			}
+			l.Elements = append(l.Elements, v)
+		}
+		return l, nil
 	case *Identifier:
-		v, ok := e.env[n.Name]
+		v, ok := e.lookup(n.Name)
 		if !ok {
-			return nil, fmt.Errorf("%s: undefined variable - %s", n.position, n.Name)
+			return nil, newError(n.position, "NameError", "undefined variable - %s", n.Name)
 		}
 		return v, nil
 	case *Apply:
 		return e.execApply(n)
+	case *Spawn:
+		return e.execSpawn(n)
+	case *Attribute:
+		v, err := e.execNode(n.Receiver)
+		if err != nil {
+			return nil, err
+		}
+		r, err := GetAttribute(v, n.Name)
+		if err != nil {
+			return nil, errorAt(err, "AttributeError", n.position)
+		}
+		return r, nil
+	case *Index:
+		v, err := e.execNode(n.Receiver)
+		if err != nil {
+			return nil, err
+		}
+		k, err := e.execNode(n.Key)
+		if err != nil {
+			return nil, err
+		}
+		r, err := GetIndex(v, k)
+		if err != nil {
+			return nil, errorAt(err, "TypeError", n.position)
+		}
+		return r, nil
 	}
-	panic("must not happen")
+	return nil, &Error{Kind: "RuntimeError", Message: fmt.Sprintf("cannot execute %T", node)}
+}
+
+// task returns an engine to run a task on another goroutine. It shares
+// the globals, modules and settings of e but has a call stack of its own,
+// starting with a frame for name at p.
+func (e *Engine) task(name string, p *Position) *Engine {
+	t := *e
+	t.scope, t.generator = nil, nil
+	t.stack = &callStack{frames: []*Frame{{Function: name, Position: p}}}
+	return &t
+}
+
+func (e *Engine) lookup(name string) (Value, bool) {
+	e.vars.RLock()
+	defer e.vars.RUnlock()
+	for s := e.scope; s != nil; s = s.parent {
+		if v, ok := s.vars[name]; ok {
+			return v, true
+		}
+	}
+	if v, ok := e.env[name]; ok {
+		return v, true
+	}
+	v, ok := e.builtins[name]
+	return v, ok
 }
 
 func (e *Engine) execCmp(left Node, right Node, p *Position, wants ...CompareResult) (Value, error) {
@@ -205,9 +538,12 @@ func (e *Engine) execCmp(left Node, right Node, p *Position, wants ...CompareRes
 	if err != nil {
 		return nil, err
 	}
-	result, err := CompareValues(l, r)
+	result, err := compareWith(e, l, r)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", p, err)
+		return nil, errorAt(err, "TypeError", p)
+	}
+	if result == CMP_NE && wants[0] != CMP_EQ {
+		return nil, newError(p, "TypeError", "not an ordered value - %v(%T)", l, l)
 	}
 	for _, want := range wants {
 		if result == want {
@@ -217,7 +553,9 @@ func (e *Engine) execCmp(left Node, right Node, p *Position, wants ...CompareRes
 	return Boolean(false), nil
 }
 
-func (e *Engine) execBinArith(left Node, right Node, p *Position, op func(Value, Value) (Value, error)) (Value, error) {
+// execBinArith applies op to the values of left and right. method names
+// the operator method of classes standing for op, if any.
+func (e *Engine) execBinArith(left Node, right Node, p *Position, method string, op func(Value, Value) (Value, error)) (Value, error) {
 	l, err := e.execNode(left)
 	if err != nil {
 		return nil, err
@@ -226,29 +564,104 @@ func (e *Engine) execBinArith(left Node, right Node, p *Position, op func(Value,
 	if err != nil {
 		return nil, err
 	}
-	result, err := op(l, r)
+	result, err := e.operate(method, op, l, r)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", p, err)
+		return nil, errorAt(err, "TypeError", p)
 	}
 	return result, nil
 }
 
 func (e *Engine) execApply(a *Apply) (Value, error) {
-	v, err := e.execNode(a.function)
+	f, args, err := e.evalApply(a)
 	if err != nil {
 		return nil, err
 	}
-	f, ok := v.(NativeFunction)
-	if !ok {
-		return nil, fmt.Errorf("%s: not a function - %v(%T)", a.function.Position(), v, v)
+	r, err := e.call(calleeName(a.function), a.position, f, args)
+	if err != nil {
+		return nil, errorAt(err, "RuntimeError", a.position)
+	}
+	return r, nil
+}
+
+// evalApply evaluates the function and the arguments of an application.
+func (e *Engine) evalApply(a *Apply) (Value, []Value, error) {
+	f, err := e.execNode(a.function)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch f.(type) {
+	case NativeFunction, *Closure, CallableValue:
+	default:
+		return nil, nil, newError(a.function.Position(), "TypeError", "not a function - %v(%T)", f, f)
 	}
 	args := []Value{}
 	for _, x := range a.arguments {
 		v, err := e.execNode(x)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		args = append(args, v)
 	}
-	return f(e, args)
+	return f, args, nil
+}
+
+func (e *Engine) execFor(f *For) (Value, error) {
+	v, err := e.execNode(f.Iterable)
+	if err != nil {
+		return nil, err
+	}
+	it, err := Iterate(v)
+	if err != nil {
+		return nil, errorAt(err, "TypeError", f.Iterable.Position())
+	}
+	name := f.Variable.(*Identifier).Name
+	var r Value
+	for {
+		x, ok, err := it.Next()
+		if err != nil {
+			closeIterator(it)
+			return nil, errorAt(err, "RuntimeError", f.Iterable.Position())
+		}
+		if !ok {
+			if e.coverage != nil {
+				e.coverage.branch(f.position, BranchExit)
+			}
+			break
+		}
+		if e.coverage != nil {
+			e.coverage.branch(f.position, BranchBody)
+		}
+		e.assign(name, x)
+		v, err := e.execNode(f.Body)
+		if err != nil {
+			// a failure of the body is more telling than one of the cleanup
+			if closeErr := closeIterator(it); closeErr != nil && isSignal(err) {
+				err = errorAt(closeErr, "RuntimeError", f.Iterable.Position())
+			}
+			return nil, err
+		}
+		r = v
+	}
+	return r, nil
+}
+
+func (e *Engine) execBegin(b *Begin) (r Value, err error) {
+	if b.Ensure != nil {
+		defer func() {
+			if _, ensureErr := e.execNode(b.Ensure); ensureErr != nil {
+				r, err = nil, ensureErr
+			}
+		}()
+	}
+	r, err = e.execNode(b.Body)
+	if err == nil || b.Rescue == nil {
+		return
+	}
+	if isSignal(err) {
+		return
+	}
+	if b.Variable != nil {
+		e.assign(b.Variable.(*Identifier).Name, errorAt(err, "RuntimeError", b.Body.Position()))
+	}
+	return e.execNode(b.Rescue)
 }
@@ -0,0 +1,83 @@
+package golan_test
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+func TestRescueKinds(t *testing.T) {
+	tests := []struct {
+		statement string
+		kind      string
+		column    int
+		message   string
+	}{
+		{"x = 1 / 0", "ZeroDivisionError", 7, "divide by zero"},
+		{"y", "NameError", 3, "undefined variable - y"},
+		{"[1][3]", "IndexError", 3, "index out of range - 3 (length 1)"},
+		{`map()["k"]`, "KeyError", 3, `key not found - "k"`},
+		{`raise "plain"`, "RuntimeError", 3, "plain"},
+		{`raise error("ValueError", "bad")`, "ValueError", 3, "bad"},
+		{"raise 1", "TypeError", 3, "exception must be a String or an Error - 1(golan.Integer)"},
+		{`open("/nonexistent/x")`, "IOError", 3, "open /nonexistent/x: no such file or directory"},
+	}
+	for _, tt := range tests {
+		src := "begin {\n  " + tt.statement + "\n} rescue e {\n  print(e.kind, e.position, e.message)\n  print(error_kind(e) == e.kind, error_position(e) == e.position)\n}\n"
+		want := tt.kind + "\n<string>:2:" + strconv.Itoa(tt.column) + "\n" + tt.message + "\ntrue\ntrue\n"
+		if got := run(t, golan.NewEngine(), src); got != want {
+			t.Errorf("%s: output = %q, want %q", tt.statement, got, want)
+		}
+	}
+}
+
+func TestUncaughtError(t *testing.T) {
+	tree, err := golan.Parse(`begin {
+  raise error("ValueError", "first")
+} rescue e {
+  raise e
+} ensure {
+  print("ensure")
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := golan.NewEngine()
+	var out bytes.Buffer
+	e.SetOutput(&out)
+	_, err = e.Execute(tree)
+	var x *golan.Error
+	if !errors.As(err, &x) {
+		t.Fatalf("error = %v (%T), want *golan.Error", err, err)
+	}
+	if x.Kind != "ValueError" || x.Message != "first" || x.Position.String() != "<string>:2:3" {
+		t.Errorf("error = %s %q at %s", x.Kind, x.Message, x.Position)
+	}
+	if out.String() != "ensure\n" {
+		t.Errorf("output = %q, want %q", out.String(), "ensure\n")
+	}
+}
+
+func TestEnsure(t *testing.T) {
+	expectOutput(t, `
+func f() {
+  begin {
+    return 1
+  } ensure {
+    print("ensure")
+  }
+}
+print(f())
+begin {
+  print("body")
+} rescue e {
+  print("unreachable")
+} ensure {
+  print("done")
+}
+`, "ensure\n1\nbody\ndone\n")
+}
@@ -0,0 +1,455 @@
+package golan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// nodeField is a named attribute of a node as exported to JSON and
+// S-expressions. value is a Node, a []Node, a []*Comment or a scalar.
+type nodeField struct {
+	name  string
+	value any
+}
+
+func nodeKind(n Node) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", n), "*golan.")
+}
+
+func nodeFields(node Node) []nodeField {
+	switch n := node.(type) {
+	case *Comment:
+		return []nodeField{{"text", n.Text}}
+	case *Block:
+		fs := []nodeField{{"statements", n.statements}}
+		if len(n.comments) > 0 {
+			fs = append(fs, nodeField{"comments", n.comments})
+		}
+		return fs
+	case *While:
+		return []nodeField{{"condition", n.Condition}, {"body", n.Body}}
+	case *For:
+		return []nodeField{{"variable", n.Variable}, {"iterable", n.Iterable}, {"body", n.Body}}
+	case *Select:
+		cases := []Node{}
+		for _, c := range n.Cases {
+			cases = append(cases, c)
+		}
+		return []nodeField{{"cases", cases}, {"default", n.Default}}
+	case *SelectCase:
+		return []nodeField{{"variable", n.Variable}, {"channel", n.Channel}, {"value", n.Value}, {"body", n.Body}}
+	case *If:
+		return []nodeField{{"test", n.Test}, {"then", n.Then}, {"alt", n.Alt}}
+	case *Begin:
+		return []nodeField{{"body", n.Body}, {"variable", n.Variable}, {"rescue", n.Rescue}, {"ensure", n.Ensure}}
+	case *Raise:
+		return []nodeField{{"expression", n.Expression}}
+	case *Import:
+		return []nodeField{{"path", n.Path}, {"name", n.Name}}
+	case *Struct:
+		return []nodeField{{"name", n.Name}, {"fields", n.Fields}}
+	case *Class:
+		return []nodeField{{"name", n.Name}, {"body", n.Body}}
+	case *Function:
+		params := []Node{}
+		for _, x := range n.Parameters {
+			params = append(params, x)
+		}
+		return []nodeField{{"name", n.Name}, {"parameters", params}, {"return_type", n.ReturnType}, {"body", n.Body}}
+	case *Parameter:
+		return []nodeField{{"name", n.Name}, {"type", n.Type}}
+	case *Return:
+		return []nodeField{{"expression", n.Expression}}
+	case *Spawn:
+		return []nodeField{{"call", n.Call}}
+	case *Yield:
+		return []nodeField{{"expression", n.Expression}}
+	case *Assign:
+		return []nodeField{{"destination", n.Destination}, {"expression", n.Expression}, {"type", n.Type}}
+	case *Equal:
+		return []nodeField{{"left", n.Left}, {"right", n.Right}}
+	case *NotEqual:
+		return []nodeField{{"left", n.Left}, {"right", n.Right}}
+	case *GreaterThanEqual:
+		return []nodeField{{"left", n.Left}, {"right", n.Right}}
+	case *LessThanEqual:
+		return []nodeField{{"left", n.Left}, {"right", n.Right}}
+	case *GreaterThan:
+		return []nodeField{{"left", n.Left}, {"right", n.Right}}
+	case *LessThan:
+		return []nodeField{{"left", n.Left}, {"right", n.Right}}
+	case *In:
+		return []nodeField{{"left", n.Left}, {"right", n.Right}}
+	case *RangeLiteral:
+		return []nodeField{{"start", n.Start}, {"stop", n.Stop}, {"exclusive", n.Exclusive}}
+	case *Addition:
+		return []nodeField{{"left", n.Left}, {"right", n.Right}}
+	case *Subtraction:
+		return []nodeField{{"left", n.Left}, {"right", n.Right}}
+	case *Multiplication:
+		return []nodeField{{"left", n.Left}, {"right", n.Right}}
+	case *Division:
+		return []nodeField{{"left", n.Left}, {"right", n.Right}}
+	case *Modulo:
+		return []nodeField{{"left", n.Left}, {"right", n.Right}}
+	case *Plus:
+		return []nodeField{{"expression", n.Expression}}
+	case *Minus:
+		return []nodeField{{"expression", n.Expression}}
+	case *Not:
+		return []nodeField{{"expression", n.Expression}}
+	case *BooleanLiteral:
+		return []nodeField{{"value", n.Value}}
+	case *IntLiteral:
+		return []nodeField{{"value", n.Value}}
+	case *FloatLiteral:
+		return []nodeField{{"value", n.Value}}
+	case *StringLiteral:
+		return []nodeField{{"value", n.Value}}
+	case *Identifier:
+		return []nodeField{{"name", n.Name}}
+	case *Apply:
+		return []nodeField{{"function", n.function}, {"arguments", n.arguments}}
+	case *Attribute:
+		return []nodeField{{"receiver", n.Receiver}, {"name", n.Name}}
+	case *ListLiteral:
+		return []nodeField{{"elements", n.Elements}}
+	case *Index:
+		return []nodeField{{"receiver", n.Receiver}, {"key", n.Key}}
+	}
+	panic("must not happen")
+}
+
+// marshalNode encodes node as a JSON object with its kind, position and
+// fields, in that order.
+func marshalNode(node Node) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `{"kind":%q,"position":`, nodeKind(node))
+	pos, err := json.Marshal(node.Position())
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(pos)
+	for _, f := range nodeFields(node) {
+		b, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, ",%q:", f.name)
+		buf.Write(b)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (c *Comment) MarshalJSON() ([]byte, error)          { return marshalNode(c) }
+func (b *Block) MarshalJSON() ([]byte, error)            { return marshalNode(b) }
+func (w *While) MarshalJSON() ([]byte, error)            { return marshalNode(w) }
+func (f *For) MarshalJSON() ([]byte, error)              { return marshalNode(f) }
+func (s *Select) MarshalJSON() ([]byte, error)           { return marshalNode(s) }
+func (c *SelectCase) MarshalJSON() ([]byte, error)       { return marshalNode(c) }
+func (i *If) MarshalJSON() ([]byte, error)               { return marshalNode(i) }
+func (b *Begin) MarshalJSON() ([]byte, error)            { return marshalNode(b) }
+func (r *Raise) MarshalJSON() ([]byte, error)            { return marshalNode(r) }
+func (i *Import) MarshalJSON() ([]byte, error)           { return marshalNode(i) }
+func (s *Struct) MarshalJSON() ([]byte, error)           { return marshalNode(s) }
+func (c *Class) MarshalJSON() ([]byte, error)            { return marshalNode(c) }
+func (f *Function) MarshalJSON() ([]byte, error)         { return marshalNode(f) }
+func (p *Parameter) MarshalJSON() ([]byte, error)        { return marshalNode(p) }
+func (r *Return) MarshalJSON() ([]byte, error)           { return marshalNode(r) }
+func (y *Yield) MarshalJSON() ([]byte, error)            { return marshalNode(y) }
+func (s *Spawn) MarshalJSON() ([]byte, error)            { return marshalNode(s) }
+func (a *Assign) MarshalJSON() ([]byte, error)           { return marshalNode(a) }
+func (e *Equal) MarshalJSON() ([]byte, error)            { return marshalNode(e) }
+func (N *NotEqual) MarshalJSON() ([]byte, error)         { return marshalNode(N) }
+func (G *GreaterThanEqual) MarshalJSON() ([]byte, error) { return marshalNode(G) }
+func (L *LessThanEqual) MarshalJSON() ([]byte, error)    { return marshalNode(L) }
+func (G *GreaterThan) MarshalJSON() ([]byte, error)      { return marshalNode(G) }
+func (L *LessThan) MarshalJSON() ([]byte, error)         { return marshalNode(L) }
+func (i *In) MarshalJSON() ([]byte, error)               { return marshalNode(i) }
+func (r *RangeLiteral) MarshalJSON() ([]byte, error)     { return marshalNode(r) }
+func (a *Addition) MarshalJSON() ([]byte, error)         { return marshalNode(a) }
+func (s *Subtraction) MarshalJSON() ([]byte, error)      { return marshalNode(s) }
+func (m *Multiplication) MarshalJSON() ([]byte, error)   { return marshalNode(m) }
+func (d *Division) MarshalJSON() ([]byte, error)         { return marshalNode(d) }
+func (m *Modulo) MarshalJSON() ([]byte, error)           { return marshalNode(m) }
+func (p *Plus) MarshalJSON() ([]byte, error)             { return marshalNode(p) }
+func (m *Minus) MarshalJSON() ([]byte, error)            { return marshalNode(m) }
+func (n *Not) MarshalJSON() ([]byte, error)              { return marshalNode(n) }
+func (b *BooleanLiteral) MarshalJSON() ([]byte, error)   { return marshalNode(b) }
+func (i *IntLiteral) MarshalJSON() ([]byte, error)       { return marshalNode(i) }
+func (f *FloatLiteral) MarshalJSON() ([]byte, error)     { return marshalNode(f) }
+func (s *StringLiteral) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (l *ListLiteral) MarshalJSON() ([]byte, error)      { return marshalNode(l) }
+func (i *Identifier) MarshalJSON() ([]byte, error)       { return marshalNode(i) }
+func (a *Apply) MarshalJSON() ([]byte, error)            { return marshalNode(a) }
+func (a *Attribute) MarshalJSON() ([]byte, error)        { return marshalNode(a) }
+func (i *Index) MarshalJSON() ([]byte, error)            { return marshalNode(i) }
+
+// UnmarshalNode rebuilds a tree from the JSON form written by the
+// MarshalJSON methods of nodes.
+func UnmarshalNode(data []byte) (Node, error) {
+	d := &nodeDecoder{}
+	n := d.node(data)
+	if d.err != nil {
+		return nil, d.err
+	}
+	return n, nil
+}
+
+type nodeDecoder struct {
+	err error
+}
+
+func (d *nodeDecoder) fail(format string, args ...any) {
+	if d.err == nil {
+		d.err = fmt.Errorf(format, args...)
+	}
+}
+
+func (d *nodeDecoder) value(data json.RawMessage, v any) {
+	if d.err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		d.err = err
+	}
+}
+
+func (d *nodeDecoder) nodes(data json.RawMessage) []Node {
+	var raws []json.RawMessage
+	d.value(data, &raws)
+	r := []Node{}
+	for _, raw := range raws {
+		r = append(r, d.node(raw))
+	}
+	return r
+}
+
+func (d *nodeDecoder) node(data json.RawMessage) Node {
+	if d.err != nil || len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	var obj map[string]json.RawMessage
+	d.value(data, &obj)
+	var kind string
+	d.value(obj["kind"], &kind)
+	var pos *Position
+	d.value(obj["position"], &pos)
+	if d.err != nil {
+		return nil
+	}
+	str := func(name string) string {
+		var s string
+		d.value(obj[name], &s)
+		return s
+	}
+	child := func(name string) Node { return d.node(obj[name]) }
+
+	switch kind {
+	case "Comment":
+		return &Comment{pos, str("text")}
+	case "Block":
+		b := &Block{position: pos, statements: d.nodes(obj["statements"])}
+		if raw, ok := obj["comments"]; ok {
+			comments := []*Comment{}
+			for _, n := range d.nodes(raw) {
+				c, ok := n.(*Comment)
+				if !ok {
+					d.fail("not a Comment - %T", n)
+					return nil
+				}
+				comments = append(comments, c)
+			}
+			attachComments(b, comments)
+		}
+		return b
+	case "While":
+		return &While{pos, child("condition"), child("body")}
+	case "For":
+		return &For{pos, child("variable"), child("iterable"), child("body")}
+	case "Select":
+		s := &Select{pos, []*SelectCase{}, child("default")}
+		for _, n := range d.nodes(obj["cases"]) {
+			c, ok := n.(*SelectCase)
+			if !ok {
+				d.fail("not a SelectCase - %T", n)
+				return nil
+			}
+			s.Cases = append(s.Cases, c)
+		}
+		return s
+	case "SelectCase":
+		return &SelectCase{pos, child("variable"), child("channel"), child("value"), child("body")}
+	case "If":
+		return &If{pos, child("test"), child("then"), child("alt")}
+	case "Begin":
+		return &Begin{pos, child("body"), child("variable"), child("rescue"), child("ensure")}
+	case "Raise":
+		return &Raise{pos, child("expression")}
+	case "Import":
+		return &Import{pos, str("path"), str("name")}
+	case "Struct":
+		fields := []string{}
+		d.value(obj["fields"], &fields)
+		return &Struct{pos, str("name"), fields}
+	case "Class":
+		return &Class{pos, str("name"), child("body")}
+	case "Function":
+		f := &Function{pos, str("name"), []*Parameter{}, str("return_type"), child("body")}
+		for _, n := range d.nodes(obj["parameters"]) {
+			p, ok := n.(*Parameter)
+			if !ok {
+				d.fail("not a Parameter - %T", n)
+				return nil
+			}
+			f.Parameters = append(f.Parameters, p)
+		}
+		return f
+	case "Parameter":
+		return &Parameter{pos, str("name"), str("type")}
+	case "Return":
+		return &Return{pos, child("expression")}
+	case "Spawn":
+		return &Spawn{pos, child("call")}
+	case "Yield":
+		return &Yield{pos, child("expression")}
+	case "Assign":
+		return &Assign{pos, child("destination"), child("expression"), str("type")}
+	case "Equal":
+		return &Equal{pos, child("left"), child("right")}
+	case "NotEqual":
+		return &NotEqual{pos, child("left"), child("right")}
+	case "GreaterThanEqual":
+		return &GreaterThanEqual{pos, child("left"), child("right")}
+	case "LessThanEqual":
+		return &LessThanEqual{pos, child("left"), child("right")}
+	case "GreaterThan":
+		return &GreaterThan{pos, child("left"), child("right")}
+	case "LessThan":
+		return &LessThan{pos, child("left"), child("right")}
+	case "In":
+		return &In{pos, child("left"), child("right")}
+	case "RangeLiteral":
+		var exclusive bool
+		d.value(obj["exclusive"], &exclusive)
+		return &RangeLiteral{pos, child("start"), child("stop"), exclusive}
+	case "Addition":
+		return &Addition{pos, child("left"), child("right")}
+	case "Subtraction":
+		return &Subtraction{pos, child("left"), child("right")}
+	case "Multiplication":
+		return &Multiplication{pos, child("left"), child("right")}
+	case "Division":
+		return &Division{pos, child("left"), child("right")}
+	case "Modulo":
+		return &Modulo{pos, child("left"), child("right")}
+	case "Plus":
+		return &Plus{pos, child("expression")}
+	case "Minus":
+		return &Minus{pos, child("expression")}
+	case "Not":
+		return &Not{pos, child("expression")}
+	case "BooleanLiteral":
+		var v bool
+		d.value(obj["value"], &v)
+		return &BooleanLiteral{pos, v}
+	case "IntLiteral":
+		var v int64
+		d.value(obj["value"], &v)
+		return &IntLiteral{pos, v}
+	case "FloatLiteral":
+		var v float64
+		d.value(obj["value"], &v)
+		return &FloatLiteral{pos, v}
+	case "StringLiteral":
+		return &StringLiteral{pos, str("value")}
+	case "Identifier":
+		return &Identifier{pos, str("name")}
+	case "Apply":
+		return &Apply{pos, child("function"), d.nodes(obj["arguments"])}
+	case "Attribute":
+		return &Attribute{pos, child("receiver"), str("name")}
+	case "ListLiteral":
+		return &ListLiteral{pos, d.nodes(obj["elements"])}
+	case "Index":
+		return &Index{pos, child("receiver"), child("key")}
+	}
+	d.fail("unknown node kind - %q", kind)
+	return nil
+}
+
+// FprintSexp writes tree as an S-expression. Every node is a list of its
+// kind, its position as (source first-line first-column last-line
+// last-column) and keyword/value pairs of its fields. Fields with no node
+// and annotations left out of the source are omitted.
+func FprintSexp(w io.Writer, tree Node) error {
+	var buf bytes.Buffer
+	writeSexp(&buf, tree, 0)
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// emptyField tells fields FprintSexp omits.
+func emptyField(f nodeField) bool {
+	switch f.name {
+	case "type", "return_type", "name":
+		return f.value == ""
+	}
+	return f.value == nil
+}
+
+func writeSexp(buf *bytes.Buffer, v any, n int) {
+	switch x := v.(type) {
+	case []Node:
+		buf.WriteByte('(')
+		for i, c := range x {
+			if i > 0 {
+				buf.WriteString("\n" + strings.Repeat("  ", n+1))
+			}
+			writeSexp(buf, c, n+1)
+		}
+		buf.WriteByte(')')
+	case []*Comment:
+		nodes := []Node{}
+		for _, c := range x {
+			nodes = append(nodes, c)
+		}
+		writeSexp(buf, nodes, n)
+	case Node:
+		fields := []nodeField{}
+		sep := " "
+		for _, f := range nodeFields(x) {
+			if emptyField(f) {
+				continue
+			}
+			switch f.value.(type) {
+			case Node, []Node, []*Comment:
+				sep = "\n" + strings.Repeat("  ", n+1)
+			}
+			fields = append(fields, f)
+		}
+		buf.WriteString("(" + nodeKind(x))
+		if p := x.Position(); p != nil {
+			fmt.Fprintf(buf, " :pos (%s %d %d %d %d)", strconv.Quote(p.Source), p.FirstLineno, p.FirstColumn, p.LastLineno, p.LastColumn)
+		}
+		for _, f := range fields {
+			buf.WriteString(sep + ":" + f.name + " ")
+			writeSexp(buf, f.value, n+1)
+		}
+		buf.WriteByte(')')
+	case nil:
+		buf.WriteString("nil")
+	case string:
+		buf.WriteString(strconv.Quote(x))
+	case float64:
+		buf.WriteString(formatFloat(x))
+	default:
+		fmt.Fprint(buf, x)
+	}
+}
@@ -0,0 +1,97 @@
+package golan_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+const exported = `# header
+import "lib/m"
+struct P { x, y }
+class C {
+  func init(self, v: Integer) {
+    self.v = v
+  }
+}
+func f(a, b): Integer {
+  return a + b # sum
+}
+x: Integer = -f(1, 2) * 3
+for i in 0...10 {
+  if i % 2 == 0 {
+    print([i, "s", 1.5][0])
+  } else {
+    x = x / 2
+  }
+}
+begin {
+  raise "no"
+} rescue err {
+  print(err.message)
+} ensure {
+  print(!false)
+}
+`
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, src := range []string{exported, "print(1)\n", "\n"} {
+		tree, err := golan.ParseSource("a.gl", src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(tree)
+		if err != nil {
+			t.Fatal(err)
+		}
+		back, err := golan.UnmarshalNode(data)
+		if err != nil {
+			t.Fatalf("%v in %s", err, data)
+		}
+		again, err := json.Marshal(back)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, again) {
+			t.Errorf("round trip changed the JSON:\n%s\n%s", data, again)
+		}
+		var dump, backDump bytes.Buffer
+		golan.DumpTree(tree, &dump)
+		golan.DumpTree(back, &backDump)
+		if dump.String() != backDump.String() {
+			t.Errorf("round trip changed the tree:\n%s\n%s", dump.String(), backDump.String())
+		}
+	}
+}
+
+func TestUnmarshalNodeErrors(t *testing.T) {
+	for _, data := range []string{`{`, `{"kind":"Nope"}`, `[]`} {
+		if n, err := golan.UnmarshalNode([]byte(data)); err == nil {
+			t.Errorf("UnmarshalNode(%s) = %v, want an error", data, n)
+		}
+	}
+}
+
+func TestFprintSexp(t *testing.T) {
+	tree, err := golan.ParseSource("a.gl", "x = f(1) # one\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := golan.FprintSexp(&out, tree); err != nil {
+		t.Fatal(err)
+	}
+	want := `(Block :pos ("a.gl" 1 1 1 8)
+  :statements ((Assign :pos ("a.gl" 1 1 1 8)
+      :destination (Identifier :pos ("a.gl" 1 1 1 1) :name "x")
+      :expression (Apply :pos ("a.gl" 1 5 1 8)
+        :function (Identifier :pos ("a.gl" 1 5 1 5) :name "f")
+        :arguments ((IntLiteral :pos ("a.gl" 1 7 1 7) :value 1)))))
+  :comments ((Comment :pos ("a.gl" 1 10 1 14) :text "# one")))
+`
+	if out.String() != want {
+		t.Errorf("sexp =\n%s\nwant\n%s", out.String(), want)
+	}
+}
@@ -0,0 +1,290 @@
+package golan
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// File is an open file or standard stream. Operations on a closed file
+// fail with an IOError. Tasks may share a File; its operations take turns.
+type File struct {
+	Name   string
+	mu     sync.Mutex
+	reader *bufio.Reader
+	writer io.Writer
+	closer io.Closer
+	closed bool
+	// seeker is the file read and written when it is open for both.
+	seeker io.ReadSeeker
+}
+
+// NewFile wraps r and w as a File named name; either may be nil when the
+// file is not open for reading or writing. Closing the File closes c
+// unless it is nil.
+func NewFile(name string, r io.Reader, w io.Writer, c io.Closer) *File {
+	f := &File{Name: name, writer: w, closer: c}
+	if r != nil {
+		f.reader = bufio.NewReader(r)
+	}
+	return f
+}
+
+func (f *File) String() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return fmt.Sprintf("#<file %s (closed)>", f.Name)
+	}
+	return fmt.Sprintf("#<file %s>", f.Name)
+}
+
+func (f *File) OpGetAttr(name string) (Value, error) {
+	switch name {
+	case "name":
+		return String(f.Name), nil
+	case "closed":
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		return Boolean(f.closed), nil
+	}
+	return nil, fmt.Errorf("undefined attribute - %s", name)
+}
+
+func ioError(format string, args ...any) *Error {
+	return &Error{Kind: "IOError", Message: fmt.Sprintf(format, args...)}
+}
+
+func (f *File) readable() error {
+	if f.closed {
+		return ioError("file is closed - %s", f.Name)
+	}
+	if f.reader == nil {
+		return ioError("file is not open for reading - %s", f.Name)
+	}
+	return nil
+}
+
+func (f *File) writable() error {
+	if f.closed {
+		return ioError("file is closed - %s", f.Name)
+	}
+	if f.writer == nil {
+		return ioError("file is not open for writing - %s", f.Name)
+	}
+	return nil
+}
+
+// ReadLine returns the next line including its newline, or Undefined at
+// the end of the file.
+func (f *File) ReadLine() (Value, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.readable(); err != nil {
+		return nil, err
+	}
+	line, err := f.reader.ReadString('\n')
+	switch err {
+	case nil:
+		return String(line), nil
+	case io.EOF:
+		if len(line) == 0 {
+			return Undefined{}, nil
+		}
+		return String(line), nil
+	}
+	return nil, ioError("%v", err)
+}
+
+// OpIter iterates over the remaining lines of the file.
+func (f *File) OpIter() (Iterator, error) {
+	f.mu.Lock()
+	err := f.readable()
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return IteratorFunc(func() (Value, bool, error) {
+		line, err := f.ReadLine()
+		if err != nil {
+			return nil, false, err
+		}
+		if IsUndefined(line) {
+			return nil, false, nil
+		}
+		return line, true, nil
+	}), nil
+}
+
+// ReadAll returns the rest of the file.
+func (f *File) ReadAll() (Value, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.readable(); err != nil {
+		return nil, err
+	}
+	b, err := io.ReadAll(f.reader)
+	if err != nil {
+		return nil, ioError("%v", err)
+	}
+	return String(b), nil
+}
+
+// Write writes s and returns the number of bytes written.
+func (f *File) Write(s string) (Value, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.writable(); err != nil {
+		return nil, err
+	}
+	if err := f.unread(); err != nil {
+		return nil, ioError("%v", err)
+	}
+	n, err := io.WriteString(f.writer, s)
+	if err != nil {
+		return nil, ioError("%v", err)
+	}
+	return Integer(n), nil
+}
+
+// unread moves a file open for reading and writing back over what the
+// reader buffered ahead, so a write lands where reading stopped.
+func (f *File) unread() error {
+	if f.seeker == nil || f.reader.Buffered() == 0 {
+		return nil
+	}
+	if _, err := f.seeker.Seek(-int64(f.reader.Buffered()), io.SeekCurrent); err != nil {
+		return err
+	}
+	f.reader.Reset(f.seeker)
+	return nil
+}
+
+// Close closes the file. Closing a file twice is an error.
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return ioError("file is closed - %s", f.Name)
+	}
+	f.closed = true
+	if f.closer != nil {
+		if err := f.closer.Close(); err != nil {
+			return ioError("%v", err)
+		}
+	}
+	return nil
+}
+
+// fileModes maps the modes of open to flags of os.OpenFile.
+var fileModes = map[string]int{
+	"r":  os.O_RDONLY,
+	"w":  os.O_WRONLY | os.O_CREATE | os.O_TRUNC,
+	"a":  os.O_WRONLY | os.O_CREATE | os.O_APPEND,
+	"r+": os.O_RDWR,
+	"w+": os.O_RDWR | os.O_CREATE | os.O_TRUNC,
+	"a+": os.O_RDWR | os.O_CREATE | os.O_APPEND,
+}
+
+func builtinOpen(e *Engine, args []Value) (Value, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1..2)", len(args))
+	}
+	path, ok := args[0].(String)
+	if !ok {
+		return nil, fmt.Errorf("not a String - %v(%T)", args[0], args[0])
+	}
+	mode := "r"
+	if len(args) == 2 {
+		m, ok := args[1].(String)
+		if !ok {
+			return nil, fmt.Errorf("not a String - %v(%T)", args[1], args[1])
+		}
+		mode = string(m)
+	}
+	flag, ok := fileModes[mode]
+	if !ok {
+		return nil, &Error{Kind: "ValueError", Message: fmt.Sprintf("invalid file mode - %q", mode)}
+	}
+	fp, err := os.OpenFile(string(path), flag, 0666)
+	if err != nil {
+		return nil, ioError("%v", err)
+	}
+	var r io.Reader
+	var w io.Writer
+	if flag&(os.O_WRONLY|os.O_RDWR) != os.O_WRONLY {
+		r = fp
+	}
+	if flag&(os.O_WRONLY|os.O_RDWR) != 0 {
+		w = fp
+	}
+	f := NewFile(string(path), r, w, fp)
+	if flag&os.O_RDWR != 0 {
+		f.seeker = fp
+	}
+	return f, nil
+}
+
+func fileArgument(args []Value) (*File, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1)", len(args))
+	}
+	f, ok := args[0].(*File)
+	if !ok {
+		return nil, fmt.Errorf("not a File - %v(%T)", args[0], args[0])
+	}
+	return f, nil
+}
+
+func builtinFileReadline(e *Engine, args []Value) (Value, error) {
+	f, err := fileArgument(args)
+	if err != nil {
+		return nil, err
+	}
+	return f.ReadLine()
+}
+
+func builtinFileReadAll(e *Engine, args []Value) (Value, error) {
+	f, err := fileArgument(args)
+	if err != nil {
+		return nil, err
+	}
+	return f.ReadAll()
+}
+
+// builtinFileWrite writes the values one after another, formatted as by
+// print but without separators, and returns the number of bytes written.
+func builtinFileWrite(e *Engine, args []Value) (Value, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1..)", len(args))
+	}
+	f, err := fileArgument(args[:1])
+	if err != nil {
+		return nil, err
+	}
+	return f.Write(concatValues(args[1:]))
+}
+
+func builtinFileClose(e *Engine, args []Value) (Value, error) {
+	f, err := fileArgument(args)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	return Undefined{}, nil
+}
+
+// concatValues formats values as print does and joins them without
+// separators.
+func concatValues(values []Value) string {
+	var b strings.Builder
+	for _, v := range values {
+		fmt.Fprint(&b, v)
+	}
+	return b.String()
+}
@@ -0,0 +1,86 @@
+package golan_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+// runOnFile runs src, in which %q stands for the path of a file holding
+// content, and returns what it printed, the error it failed with and the
+// content of the file afterwards.
+func runOnFile(t *testing.T, content string, src string) (string, error, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "data.txt")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tree, err := golan.Parse(fmt.Sprintf(src, path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	_, err = golan.NewEngine(golan.WithOutput(&out)).Execute(tree)
+	b, readErr := os.ReadFile(path)
+	if readErr != nil {
+		t.Fatal(readErr)
+	}
+	return out.String(), err, string(b)
+}
+
+func TestFileModes(t *testing.T) {
+	tests := []struct {
+		mode    string
+		src     string
+		out     string
+		content string
+	}{
+		{"r", "write(file_read_all(f))", "one\ntwo\n", "one\ntwo\n"},
+		{"w", `file_write(f, "new")`, "", "new"},
+		{"a", `file_write(f, "new")`, "", "one\ntwo\nnew"},
+		{"r+", `write(file_readline(f))
+file_write(f, "T")
+write(file_read_all(f))`, "one\nwo\n", "one\nTwo\n"},
+		{"w+", `file_write(f, "new")
+write(file_read_all(f))`, "", "new"},
+		{"a+", `file_write(f, "new")`, "", "one\ntwo\nnew"},
+	}
+	for _, tt := range tests {
+		src := "f = open(%q, \"" + tt.mode + "\")\n" + tt.src + "\nfile_close(f)\n"
+		out, err, content := runOnFile(t, "one\ntwo\n", src)
+		if err != nil {
+			t.Errorf("%s: %v", tt.mode, err)
+			continue
+		}
+		if out != tt.out || content != tt.content {
+			t.Errorf("%s: output %q and content %q, want %q and %q", tt.mode, out, content, tt.out, tt.content)
+		}
+	}
+}
+
+func TestFileErrors(t *testing.T) {
+	tests := []struct {
+		src     string
+		kind    string
+		message string
+	}{
+		{"f = open(%q, \"rw\")", "ValueError", `invalid file mode - "rw"`},
+		{"f = open(%q)\nfile_write(f, \"x\")", "IOError", "file is not open for writing - "},
+		{"f = open(%q, \"w\")\nfile_readline(f)", "IOError", "file is not open for reading - "},
+		{"f = open(%q)\nfile_close(f)\nfile_readline(f)", "IOError", "file is closed - "},
+		{"f = open(%q)\nfile_close(f)\nfor line in f {\n}", "IOError", "file is closed - "},
+		{"f = open(%q)\nfile_close(f)\nfile_close(f)", "IOError", "file is closed - "},
+	}
+	for _, tt := range tests {
+		_, err, _ := runOnFile(t, "", tt.src)
+		x, ok := err.(*golan.Error)
+		if !ok || x.Kind != tt.kind || !strings.HasPrefix(x.Message, tt.message) {
+			t.Errorf("%q: error = %v, want %s: %s", tt.src, err, tt.kind, tt.message)
+		}
+	}
+}
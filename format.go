@@ -0,0 +1,550 @@
+package golan
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format parses src and returns it reprinted in canonical form.
+func Format(src []byte) ([]byte, error) {
+	tree, err := Parse(string(src))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := Fprint(&buf, tree); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Fprint writes tree to w as golan source: one tab per indentation level,
+// single spaces around binary operators, elsif/else/rescue/ensure on the
+// closing brace line and parentheses only where precedence requires them.
+// Comments attached to the statements of blocks are kept.
+func Fprint(w io.Writer, tree Node) error {
+	p := &printer{}
+	if root, ok := tree.(*Block); ok {
+		p.statements(root)
+	} else {
+		p.statement(tree)
+	}
+	if p.buf.Len() > 0 {
+		p.buf.WriteByte('\n')
+	}
+	_, err := w.Write(p.buf.Bytes())
+	return err
+}
+
+type printer struct {
+	buf    bytes.Buffer
+	indent int
+	last   int
+	fresh  bool
+	// inner are the comments inside argument lists of the statement
+	// being printed, which stay next to the arguments.
+	inner []*Comment
+}
+
+const (
+	precAssign = iota + 1
+	precEquality
+	precCompare
+	precRange
+	precAdditive
+	precMultitive
+	precUnary
+	precPostfix
+	precPrimary
+)
+
+func precedence(n Node) int {
+	switch n.(type) {
+	case *Assign:
+		return precAssign
+	case *Equal, *NotEqual:
+		return precEquality
+	case *GreaterThanEqual, *LessThanEqual, *GreaterThan, *LessThan, *In:
+		return precCompare
+	case *RangeLiteral:
+		return precRange
+	case *Addition, *Subtraction:
+		return precAdditive
+	case *Multiplication, *Division, *Modulo:
+		return precMultitive
+	case *Plus, *Minus, *Not, *Spawn:
+		return precUnary
+	case *Apply, *Attribute, *Index:
+		return precPostfix
+	}
+	return precPrimary
+}
+
+func (p *printer) print(args ...any) {
+	for _, a := range args {
+		fmt.Fprint(&p.buf, a)
+	}
+}
+
+func (p *printer) newline() {
+	p.buf.WriteByte('\n')
+	p.buf.WriteString(strings.Repeat("\t", p.indent))
+}
+
+// line starts a new line for an item beginning at source line l, keeping
+// at most one blank line from the source.
+func (p *printer) line(l int) {
+	if p.buf.Len() > 0 {
+		if !p.fresh && l > p.last+1 {
+			p.buf.WriteByte('\n')
+		}
+		p.newline()
+	}
+	p.fresh = false
+}
+
+// comment prints c on its own line; l is the source line it is placed at.
+func (p *printer) comment(c *Comment, l int) {
+	p.line(l)
+	p.print(c.Text)
+	p.last = l
+}
+
+func (p *printer) statements(b *Block) {
+	for _, s := range b.statements {
+		pos := s.Position()
+		for _, c := range b.LeadingComments(s) {
+			if inArguments(s, c) {
+				p.inner = append(p.inner, c)
+				continue
+			}
+			l := c.position.FirstLineno
+			if l > pos.FirstLineno {
+				l = pos.FirstLineno
+			}
+			p.comment(c, l)
+		}
+		p.line(pos.FirstLineno)
+		p.statement(s)
+		if c := b.TrailingComment(s); c != nil {
+			p.print(" ", c.Text)
+		}
+		p.last = pos.LastLineno
+	}
+	for _, c := range b.DanglingComments() {
+		p.comment(c, c.position.FirstLineno)
+	}
+}
+
+// inArguments tells whether c is inside the arguments of a call in the
+// statement s.
+func inArguments(s Node, c *Comment) bool {
+	found := false
+	Inspect(s, func(n Node) bool {
+		if a, ok := n.(*Apply); ok && a.encloses(c) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// encloses tells whether c is between the end of the function of a and
+// the end of a.
+func (a *Apply) encloses(c *Comment) bool {
+	f, p, q := a.function.Position(), a.position, c.position
+	return (q.FirstLineno > f.LastLineno || (q.FirstLineno == f.LastLineno && q.FirstColumn > f.LastColumn)) &&
+		(q.FirstLineno < p.LastLineno || (q.FirstLineno == p.LastLineno && q.FirstColumn < p.LastColumn))
+}
+
+// takeComments removes and returns the inner comments that match.
+func (p *printer) takeComments(match func(c *Comment) bool) []*Comment {
+	r := []*Comment{}
+	rest := p.inner[:0]
+	for _, c := range p.inner {
+		if match(c) {
+			r = append(r, c)
+		} else {
+			rest = append(rest, c)
+		}
+	}
+	p.inner = rest
+	return r
+}
+
+// arguments prints the arguments of a, one per line when comments are
+// inside them so that each comment stays with its argument.
+func (p *printer) arguments(a *Apply) {
+	hasComments := false
+	for _, c := range p.inner {
+		hasComments = hasComments || a.encloses(c)
+	}
+	p.print("(")
+	if !hasComments {
+		for i, x := range a.arguments {
+			if i > 0 {
+				p.print(", ")
+			}
+			p.expr(x, precAssign)
+		}
+		p.print(")")
+		return
+	}
+	p.indent++
+	for _, x := range a.arguments {
+		first := x.Position().FirstLineno
+		for _, c := range p.takeComments(func(c *Comment) bool { return a.encloses(c) && c.position.FirstLineno < first }) {
+			p.newline()
+			p.print(c.Text)
+		}
+		p.newline()
+		p.expr(x, precAssign)
+		p.print(",")
+		last := x.Position().LastLineno
+		for _, c := range p.takeComments(func(c *Comment) bool { return a.encloses(c) && c.position.FirstLineno == last }) {
+			p.print(" ", c.Text)
+		}
+	}
+	for _, c := range p.takeComments(a.encloses) {
+		p.newline()
+		p.print(c.Text)
+	}
+	p.indent--
+	p.newline()
+	p.print(")")
+}
+
+// unary prints op before x, apart from it when x starts with the same
+// operator so that the two do not run together.
+func (p *printer) unary(op string, x Node) {
+	p.print(op)
+	switch n := x.(type) {
+	case *Plus:
+		if op == "+" {
+			p.print(" ")
+		}
+	case *Minus:
+		if op == "-" {
+			p.print(" ")
+		}
+	case *IntLiteral:
+		if op == "-" && n.Value < 0 {
+			p.print(" ")
+		}
+	case *FloatLiteral:
+		if op == "-" && n.Value < 0 {
+			p.print(" ")
+		}
+	}
+	p.expr(x, precUnary)
+}
+
+func (p *printer) block(b *Block) {
+	if len(b.statements) == 0 && len(b.dangling) == 0 {
+		p.print("{}")
+		return
+	}
+	p.print("{")
+	p.indent++
+	p.fresh = true
+	p.statements(b)
+	p.indent--
+	p.newline()
+	p.print("}")
+	p.last = b.Position().LastLineno
+}
+
+func (p *printer) body(n Node) {
+	if b, ok := n.(*Block); ok {
+		p.block(b)
+		return
+	}
+	p.print("{")
+	p.indent++
+	p.newline()
+	p.statement(n)
+	p.indent--
+	p.newline()
+	p.print("}")
+}
+
+func (p *printer) statement(n Node) {
+	switch n := n.(type) {
+	case *Block:
+		p.block(n)
+	case *While:
+		p.print("while ")
+		p.expr(n.Condition, precAssign)
+		p.print(" ")
+		p.body(n.Body)
+	case *For:
+		p.print("for ")
+		p.expr(n.Variable, precAssign)
+		p.print(" in ")
+		p.expr(n.Iterable, precAssign)
+		p.print(" ")
+		p.body(n.Body)
+	case *Select:
+		p.print("select {")
+		p.indent++
+		for _, c := range n.Cases {
+			p.newline()
+			p.print("case ")
+			if c.Value != nil {
+				p.print("send(")
+				p.expr(c.Channel, precAssign)
+				p.print(", ")
+				p.expr(c.Value, precAssign)
+			} else {
+				if c.Variable != nil {
+					p.expr(c.Variable, precAssign)
+					p.print(" = ")
+				}
+				p.print("recv(")
+				p.expr(c.Channel, precAssign)
+			}
+			p.print(") ")
+			p.body(c.Body)
+		}
+		if n.Default != nil {
+			p.newline()
+			p.print("default ")
+			p.body(n.Default)
+		}
+		p.indent--
+		p.newline()
+		p.print("}")
+	case *If:
+		p.print("if ")
+		for {
+			p.expr(n.Test, precAssign)
+			p.print(" ")
+			p.body(n.Then)
+			alt, ok := n.Alt.(*If)
+			if !ok {
+				break
+			}
+			p.print(" elsif ")
+			n = alt
+		}
+		if n.Alt != nil {
+			p.print(" else ")
+			p.body(n.Alt)
+		}
+	case *Begin:
+		p.print("begin ")
+		p.body(n.Body)
+		if n.Rescue != nil {
+			p.print(" rescue ")
+			if n.Variable != nil {
+				p.expr(n.Variable, precAssign)
+				p.print(" ")
+			}
+			p.body(n.Rescue)
+		}
+		if n.Ensure != nil {
+			p.print(" ensure ")
+			p.body(n.Ensure)
+		}
+	case *Raise:
+		p.print("raise ")
+		p.expr(n.Expression, precAssign)
+	case *Import:
+		p.print("import ", strconv.Quote(n.Path))
+	case *Struct:
+		p.print("struct ", n.Name, " {")
+		if len(n.Fields) > 0 {
+			p.print(" ", strings.Join(n.Fields, ", "), " ")
+		}
+		p.print("}")
+	case *Class:
+		p.print("class ", n.Name, " ")
+		p.body(n.Body)
+	case *Function:
+		p.print("func")
+		if n.Name != "" {
+			p.print(" ", n.Name)
+		}
+		p.print("(")
+		for i, x := range n.Parameters {
+			if i > 0 {
+				p.print(", ")
+			}
+			p.print(x.Name)
+			if x.Type != "" {
+				p.print(": ", x.Type)
+			}
+		}
+		p.print(")")
+		if n.ReturnType != "" {
+			p.print(": ", n.ReturnType)
+		}
+		p.print(" ")
+		p.body(n.Body)
+	case *Return:
+		p.print("return")
+		if n.Expression != nil {
+			p.print(" ")
+			p.expr(n.Expression, precAssign)
+		}
+	case *Yield:
+		p.print("yield ")
+		p.expr(n.Expression, precAssign)
+	default:
+		p.expr(n, precAssign)
+	}
+}
+
+func (p *printer) binary(op string, prec int, l Node, r Node) {
+	p.expr(l, prec)
+	p.print(" ", op, " ")
+	p.expr(r, prec+1)
+}
+
+func (p *printer) expr(n Node, prec int) {
+	if precedence(n) < prec {
+		p.print("(")
+		p.expr(n, precAssign)
+		p.print(")")
+		return
+	}
+	switch n := n.(type) {
+	case *Assign:
+		p.expr(n.Destination, precPostfix)
+		if n.Type != "" {
+			p.print(": ", n.Type, " = ")
+			p.expr(n.Expression, precAssign)
+			return
+		}
+		if op, r := compoundAssign(n); op != "" {
+			p.print(" ", op, "= ")
+			p.expr(r, precAssign)
+			return
+		}
+		p.print(" = ")
+		p.expr(n.Expression, precAssign)
+	case *Equal:
+		p.binary("==", precEquality, n.Left, n.Right)
+	case *NotEqual:
+		p.binary("!=", precEquality, n.Left, n.Right)
+	case *GreaterThanEqual:
+		p.binary(">=", precCompare, n.Left, n.Right)
+	case *LessThanEqual:
+		p.binary("<=", precCompare, n.Left, n.Right)
+	case *GreaterThan:
+		p.binary(">", precCompare, n.Left, n.Right)
+	case *LessThan:
+		p.binary("<", precCompare, n.Left, n.Right)
+	case *In:
+		p.binary("in", precCompare, n.Left, n.Right)
+	case *RangeLiteral:
+		// ranges do not chain, so neither operand may be a range
+		p.expr(n.Start, precRange+1)
+		if n.Exclusive {
+			p.print("...")
+		} else {
+			p.print("..")
+		}
+		p.expr(n.Stop, precRange+1)
+	case *Addition:
+		p.binary("+", precAdditive, n.Left, n.Right)
+	case *Subtraction:
+		p.binary("-", precAdditive, n.Left, n.Right)
+	case *Multiplication:
+		p.binary("*", precMultitive, n.Left, n.Right)
+	case *Division:
+		p.binary("/", precMultitive, n.Left, n.Right)
+	case *Modulo:
+		p.binary("%", precMultitive, n.Left, n.Right)
+	case *Plus:
+		p.unary("+", n.Expression)
+	case *Minus:
+		p.unary("-", n.Expression)
+	case *Not:
+		p.print("!")
+		p.expr(n.Expression, precUnary)
+	case *Spawn:
+		p.print("spawn ")
+		p.expr(n.Call, precPostfix)
+	case *Apply:
+		p.expr(n.function, precPostfix)
+		p.arguments(n)
+	case *Attribute:
+		p.expr(n.Receiver, precPostfix)
+		p.print(".", n.Name)
+	case *Index:
+		p.expr(n.Receiver, precPostfix)
+		p.print("[")
+		p.expr(n.Key, precAssign)
+		p.print("]")
+	case *ListLiteral:
+		p.print("[")
+		for i, x := range n.Elements {
+			if i > 0 {
+				p.print(", ")
+			}
+			p.expr(x, precAssign)
+		}
+		p.print("]")
+	case *BooleanLiteral:
+		p.print(n.Value)
+	case *IntLiteral:
+		p.print(n.Value)
+	case *FloatLiteral:
+		p.print(formatFloat(n.Value))
+	case *StringLiteral:
+		p.print(`"`, n.Value, `"`)
+	case *Identifier:
+		p.print(n.Name)
+	default:
+		p.statement(n)
+	}
+}
+
+// compoundAssign recognizes the tree built for `x op= y`, where the
+// operation has the position of the assignment and its left operand that
+// of the destination.
+func compoundAssign(a *Assign) (string, Node) {
+	var op string
+	var l, r Node
+	switch x := a.Expression.(type) {
+	case *Addition:
+		op, l, r = "+", x.Left, x.Right
+	case *Subtraction:
+		op, l, r = "-", x.Left, x.Right
+	case *Multiplication:
+		op, l, r = "*", x.Left, x.Right
+	case *Division:
+		op, l, r = "/", x.Left, x.Right
+	case *Modulo:
+		op, l, r = "%", x.Left, x.Right
+	default:
+		return "", nil
+	}
+	if !samePosition(a.Expression.Position(), a.position) || !samePosition(l.Position(), a.Destination.Position()) {
+		return "", nil
+	}
+	return op, r
+}
+
+// samePosition compares positions by value, since trees decoded by
+// UnmarshalNode share no position objects.
+func samePosition(p *Position, q *Position) bool {
+	return p == q || (p != nil && q != nil && *p == *q)
+}
+
+// formatFloat formats f so that it reads back as a float literal.
+func formatFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	mantissa, exponent, _ := strings.Cut(s, "e")
+	if !strings.Contains(mantissa, ".") {
+		mantissa += ".0"
+	}
+	if exponent != "" {
+		return mantissa + "e" + exponent
+	}
+	return mantissa
+}
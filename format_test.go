@@ -0,0 +1,47 @@
+package golan_test
+
+import (
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"nested minus", "x = -(-1)\n", "x = - -1\n"},
+		{"spaced minus", "x = - -y\n", "x = - -y\n"},
+		{"nested plus", "x = +(+1)\n", "x = + +1\n"},
+		{"mixed signs", "x = -(+1)\n", "x = -+1\n"},
+		{"grouping", "x = (1 + 2) * 3\n", "x = (1 + 2) * 3\n"},
+		{"one-line call", "print(1,2)\n", "print(1, 2)\n"},
+		{"comments in arguments",
+			"print(\n  1, # one\n  # before two\n  2\n  # last\n)\n",
+			"print(\n\t1, # one\n\t# before two\n\t2,\n\t# last\n)\n"},
+		{"comments in nested call",
+			"print(foo(2, # two\n  3))\n",
+			"print(\n\tfoo(\n\t\t2, # two\n\t\t3,\n\t),\n)\n"},
+		{"trailing comment", "print(x) # done\n", "print(x) # done\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := golan.Format([]byte(tt.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(out) != tt.want {
+				t.Errorf("formatted = %q, want %q", out, tt.want)
+			}
+			again, err := golan.Format(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(again) != string(out) {
+				t.Errorf("formatting twice = %q, want %q", again, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package golan
+
+import "fmt"
+
+// scope holds the local variables of one function call. Names missing
+// from every scope are looked up in the globals of the engine.
+type scope struct {
+	vars   map[string]Value
+	parent *scope
+}
+
+// Closure is a script function together with the variables visible where
+// it was defined. Calling a generator function returns a Generator instead
+// of running the body.
+type Closure struct {
+	Definition *Function
+	env        map[string]Value
+	scope      *scope
+	generator  bool
+}
+
+func (c *Closure) String() string {
+	if c.Definition.Name == "" {
+		return "#<func>"
+	}
+	return fmt.Sprintf("#<func %s>", c.Definition.Name)
+}
+
+func (c *Closure) name() string {
+	if c.Definition.Name == "" {
+		return "<func>"
+	}
+	return c.Definition.Name
+}
+
+// returnSignal carries the value of a return statement up to the call
+// that is left.
+type returnSignal struct {
+	value Value
+}
+
+func (*returnSignal) Error() string { return "return outside of a function" }
+
+// isSignal reports whether err unwinds the execution rather than reports
+// a failure, so rescue clauses must let it pass.
+func isSignal(err error) bool {
+	switch err.(type) {
+	case *returnSignal, *generatorExit:
+		return true
+	}
+	return false
+}
+
+// DefaultMaxCallDepth is the depth of the call stack past which a call
+// raises a RecursionError, unless SetMaxCallDepth changes it.
+const DefaultMaxCallDepth = 5000
+
+// WithMaxCallDepth makes calls deeper than n frames raise a
+// RecursionError.
+func WithMaxCallDepth(n int) Option {
+	return func(e *Engine) { e.SetMaxCallDepth(n) }
+}
+
+// SetMaxCallDepth makes calls deeper than n frames raise a RecursionError,
+// so that runaway recursion fails as a script error rather than
+// overflowing the stack of the host.
+func (e *Engine) SetMaxCallDepth(n int) {
+	e.maxDepth = n
+}
+
+// Call applies a NativeFunction, a Closure or a CallableValue to args.
+func (e *Engine) Call(f Value, args []Value) (Value, error) {
+	switch f := f.(type) {
+	case NativeFunction:
+		return f(e, args)
+	case *Closure:
+		return e.callClosure(f, args)
+	case CallableValue:
+		return f.OpCall(e, args)
+	}
+	return nil, &Error{Kind: "TypeError", Message: fmt.Sprintf("not a function - %v(%T)", f, f)}
+}
+
+func (e *Engine) callClosure(c *Closure, args []Value) (Value, error) {
+	params := c.Definition.Parameters
+	if len(args) != len(params) {
+		return nil, &Error{
+			Kind:    "TypeError",
+			Message: fmt.Sprintf("wrong number of arguments (given %d, expected %d)", len(args), len(params)),
+		}
+	}
+	s := &scope{vars: map[string]Value{}, parent: c.scope}
+	for i, p := range params {
+		s.vars[p.Name] = args[i]
+	}
+	if c.generator {
+		return newGenerator(e, c, s), nil
+	}
+	if len(e.stack.frames) >= e.maxDepth {
+		return nil, &Error{
+			Kind:    "RecursionError",
+			Message: fmt.Sprintf("maximum call depth exceeded - %d", e.maxDepth),
+		}
+	}
+	if e.profiler != nil {
+		e.profiler.mark(e.stack)
+	}
+	e.pushFrame(c.name()).Position = c.Definition.Position()
+	env, outer := e.env, e.scope
+	e.env, e.scope = c.env, s
+	defer func() {
+		if e.profiler != nil {
+			e.profiler.mark(e.stack)
+		}
+		e.env, e.scope = env, outer
+		e.stack.pop()
+	}()
+
+	_, err := e.execNode(c.Definition.Body)
+	if r, ok := err.(*returnSignal); ok {
+		return r.value, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Undefined{}, nil
+}
+
+// assign binds name in the innermost scope already binding it, falling
+// back to the globals and then to the current scope.
+func (e *Engine) assign(name string, v Value) {
+	e.vars.Lock()
+	defer e.vars.Unlock()
+	for s := e.scope; s != nil; s = s.parent {
+		if _, ok := s.vars[name]; ok {
+			s.vars[name] = v
+			return
+		}
+	}
+	if _, ok := e.env[name]; ok || e.scope == nil {
+		e.env[name] = v
+		return
+	}
+	e.scope.vars[name] = v
+}
@@ -0,0 +1,66 @@
+package golan_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+func TestRecursionError(t *testing.T) {
+	expectOutput(t, `
+func f(n) {
+  return f(n + 1)
+}
+begin {
+  f(0)
+} rescue err {
+  print(err.kind)
+}
+`, "RecursionError\n")
+}
+
+func TestMaxCallDepth(t *testing.T) {
+	src := `
+func depth(n) {
+  if n == 0 {
+    return 0
+  }
+  return depth(n - 1) + 1
+}
+print(depth(20))
+`
+	if got := run(t, golan.NewEngine(golan.WithMaxCallDepth(30)), src); got != "20\n" {
+		t.Errorf("output = %q, want 20", got)
+	}
+	tree, err := golan.Parse(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := golan.NewEngine(golan.WithMaxCallDepth(10))
+	_, err = e.Execute(tree)
+	if x, ok := err.(*golan.Error); !ok || x.Kind != "RecursionError" {
+		t.Errorf("error = %v, want a RecursionError", err)
+	}
+}
+
+func TestMaxCallDepthInModule(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "m.gl"), []byte("func f() {\n  return 1\n}\nx = f()\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tree, err := golan.ParseSource(filepath.Join(dir, "main.gl"), "import \"m\"\nprint(m.x)\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	e := golan.NewEngine(golan.WithOutput(&out), golan.WithMaxCallDepth(10))
+	if _, err := e.Execute(tree); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "1\n" {
+		t.Errorf("output = %q, want 1", out.String())
+	}
+}
@@ -0,0 +1,161 @@
+package golan
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Generator is the result of calling a function containing yield. Its
+// body runs as a task of its own, taking turns with the consumer: Next
+// resumes the body and waits until it yields or ends.
+//
+// A generator suspended at a yield keeps its goroutine until it is resumed
+// to the end or closed. For loops close the generators they leave early;
+// Go code iterating one by hand should do the same.
+type Generator struct {
+	closure *Closure
+	engine  *Engine
+	// mu is held while the body runs, so that a generator resumed by
+	// itself or by two tasks at once is reported rather than deadlocked.
+	mu      sync.Mutex
+	state   generatorState
+	closing bool
+	resume  chan struct{}
+	results chan generatorResult
+}
+
+type generatorState int
+
+const (
+	generatorCreated generatorState = iota
+	generatorSuspended
+	generatorDone
+)
+
+// generatorResult is what the body hands back to the consumer: a yielded
+// value, or the end of the body with the error it ended with.
+type generatorResult struct {
+	value Value
+	done  bool
+	err   error
+}
+
+// generatorExit unwinds the body of a generator closed at a yield, running
+// its ensure clauses on the way out.
+type generatorExit struct{}
+
+func (*generatorExit) Error() string { return "generator closed" }
+
+// IsGenerator reports whether f is a generator function, that is, whether
+// its body yields outside of nested functions.
+func IsGenerator(f *Function) bool {
+	found := false
+	Inspect(f.Body, func(n Node) bool {
+		switch n.(type) {
+		case *Yield:
+			found = true
+		case *Function:
+			return false
+		}
+		return !found
+	})
+	return found
+}
+
+func newGenerator(e *Engine, c *Closure, s *scope) *Generator {
+	g := &Generator{
+		closure: c,
+		resume:  make(chan struct{}),
+		results: make(chan generatorResult),
+	}
+	g.engine = e.task(c.name(), c.Definition.Position())
+	g.engine.env, g.engine.scope, g.engine.generator = c.env, s, g
+	return g
+}
+
+func (g *Generator) String() string {
+	if g.closure.Definition.Name == "" {
+		return "#<generator>"
+	}
+	return fmt.Sprintf("#<generator %s>", g.closure.Definition.Name)
+}
+
+func (g *Generator) OpIter() (Iterator, error) { return g, nil }
+
+// Next runs the body until its next yield and returns the yielded value.
+// Errors raised by the body are returned once, after which the generator
+// is done.
+func (g *Generator) Next() (Value, bool, error) {
+	if !g.mu.TryLock() {
+		return nil, false, errGeneratorRunning()
+	}
+	defer g.mu.Unlock()
+	var r generatorResult
+	switch g.state {
+	case generatorCreated:
+		go g.run()
+		r = <-g.results
+	case generatorSuspended:
+		g.resume <- struct{}{}
+		r = <-g.results
+	case generatorDone:
+		return nil, false, nil
+	}
+	if r.done {
+		g.state = generatorDone
+		return nil, false, r.err
+	}
+	g.state = generatorSuspended
+	return r.value, true, nil
+}
+
+// Close stops a generator suspended at a yield, running the ensure
+// clauses around it. Closing a generator that has not started or has
+// ended does nothing.
+func (g *Generator) Close() error {
+	if !g.mu.TryLock() {
+		return errGeneratorRunning()
+	}
+	defer g.mu.Unlock()
+	if g.state != generatorSuspended {
+		g.state = generatorDone
+		return nil
+	}
+	g.closing = true
+	close(g.resume)
+	r := <-g.results
+	g.state = generatorDone
+	return r.err
+}
+
+func errGeneratorRunning() error {
+	return &Error{Kind: "RuntimeError", Message: "generator already running"}
+}
+
+func (g *Generator) run() {
+	_, err := g.engine.execNode(g.closure.Definition.Body)
+	if isSignal(err) {
+		err = nil
+	}
+	g.results <- generatorResult{done: true, err: err}
+}
+
+// suspend hands v, yielded at p, to the consumer and waits to be resumed.
+// It returns a generatorExit when the generator is closed instead.
+func (g *Generator) suspend(v Value, p *Position) error {
+	if g.closing {
+		return newError(p, "RuntimeError", "generator yielded while being closed")
+	}
+	e := g.engine
+	if e.profiler != nil {
+		e.profiler.mark(e.stack)
+	}
+	g.results <- generatorResult{value: v}
+	if _, ok := <-g.resume; !ok {
+		return &generatorExit{}
+	}
+	if e.profiler != nil {
+		e.profiler.resume(e.stack)
+	}
+	return nil
+}
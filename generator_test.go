@@ -0,0 +1,124 @@
+package golan_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+const counter = `func count(n) {
+  begin {
+    i = 0
+    while i < n {
+      yield i
+      i = i + 1
+    }
+  } ensure {
+    print("ensure", i)
+  }
+}
+`
+
+func TestGeneratorEnsure(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"exhausted", "for x in count(2) {\n  print(x)\n}\n", "0\n1\nensure\n2\n"},
+		{"left by return", "func first(g) {\n  for x in g {\n    return x\n  }\n}\nprint(first(count(5)))\n", "ensure\n0\n0\n"},
+		{"left by raise", "begin {\n  for x in count(5) {\n    raise \"stop\"\n  }\n} rescue e {\n  print(e.message)\n}\n", "ensure\n0\nstop\n"},
+		{"never started", "g = count(5)\nprint(\"unused\")\n", "unused\n"},
+	}
+	for _, tt := range tests {
+		if got := run(t, golan.NewEngine(), counter+tt.src); got != tt.want {
+			t.Errorf("%s: output = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// generator returns the generator that src, run after counter, ends with.
+func generator(t *testing.T, src string) (*golan.Generator, *bytes.Buffer) {
+	t.Helper()
+	tree, err := golan.Parse(counter + src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	v, err := golan.NewEngine(golan.WithOutput(&out)).Execute(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, ok := v.(*golan.Generator)
+	if !ok {
+		t.Fatalf("result = %v(%T), want a generator", v, v)
+	}
+	return g, &out
+}
+
+func TestGeneratorClose(t *testing.T) {
+	g, out := generator(t, "count(5)\n")
+	for want := golan.Integer(0); want < 2; want++ {
+		v, ok, err := g.Next()
+		if err != nil || !ok || v != want {
+			t.Fatalf("Next = %v, %v, %v, want %v", v, ok, err, want)
+		}
+	}
+	if err := g.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "ensure\n1\n" {
+		t.Errorf("output = %q, want %q", out.String(), "ensure\n1\n")
+	}
+	if err := g.Close(); err != nil {
+		t.Errorf("second Close = %v", err)
+	}
+	if v, ok, err := g.Next(); ok || err != nil {
+		t.Errorf("Next after Close = %v, %v, %v", v, ok, err)
+	}
+	if out.String() != "ensure\n1\n" {
+		t.Errorf("ensure ran again: output = %q", out.String())
+	}
+}
+
+func TestGeneratorCloseUnstarted(t *testing.T) {
+	g, out := generator(t, "count(5)\n")
+	if err := g.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok, err := g.Next(); ok || err != nil {
+		t.Errorf("Next after Close = %v, %v, %v", v, ok, err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("output = %q, want none", out.String())
+	}
+}
+
+func TestGeneratorYieldInEnsure(t *testing.T) {
+	g, _ := generator(t, "func stubborn() {\n  begin {\n    yield 1\n  } ensure {\n    yield 2\n  }\n}\nstubborn()\n")
+	if _, ok, err := g.Next(); !ok || err != nil {
+		t.Fatalf("Next = %v, %v", ok, err)
+	}
+	err := g.Close()
+	var x *golan.Error
+	if !errors.As(err, &x) || x.Message != "generator yielded while being closed" || x.Position.FirstLineno != 16 {
+		t.Errorf("Close = %v", err)
+	}
+}
+
+func TestGeneratorError(t *testing.T) {
+	g, _ := generator(t, "func failing() {\n  yield 1\n  raise \"broken\"\n}\nfailing()\n")
+	if _, ok, err := g.Next(); !ok || err != nil {
+		t.Fatalf("Next = %v, %v", ok, err)
+	}
+	_, ok, err := g.Next()
+	var x *golan.Error
+	if ok || !errors.As(err, &x) || x.Message != "broken" {
+		t.Errorf("Next = %v, %v, want broken", ok, err)
+	}
+	if _, ok, err := g.Next(); ok || err != nil {
+		t.Errorf("Next after error = %v, %v", ok, err)
+	}
+}
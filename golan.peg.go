@@ -24,30 +24,49 @@ const (
 	rulestatement
 	ruleblock
 	rulewhile
+	rulefor
+	ruleselect
+	ruleselectcase
 	ruleif
+	rulebegin
+	ruleraise
+	rulereturn
+	ruleyield
+	rulefunction
+	ruleparameters
+	ruleparameter
+	ruletypename
+	rulestruct
+	ruleclass
+	ruleimport
 	ruleexpression
 	ruleassign
 	ruleequality
 	rulecompare
+	rulerange
 	ruleadditive
 	rulemultitive
 	rulefactor
 	ruleunary
 	rulepostfix
+	ruleattribute
+	ruleindex
 	rulefuncall
 	ruleprimary
+	rulelist
 	rulefloat
 	ruleinteger
 	rulestring
 	ruleidentifier
+	rulekeyword
 	rule_
 	rulenl
 	rulecomment
 	rulesp
 	ruleAction0
 	ruleAction1
-	rulePegText
 	ruleAction2
+	rulePegText
 	ruleAction3
 	ruleAction4
 	ruleAction5
@@ -89,6 +108,52 @@ const (
 	ruleAction41
 	ruleAction42
 	ruleAction43
+	ruleAction44
+	ruleAction45
+	ruleAction46
+	ruleAction47
+	ruleAction48
+	ruleAction49
+	ruleAction50
+	ruleAction51
+	ruleAction52
+	ruleAction53
+	ruleAction54
+	ruleAction55
+	ruleAction56
+	ruleAction57
+	ruleAction58
+	ruleAction59
+	ruleAction60
+	ruleAction61
+	ruleAction62
+	ruleAction63
+	ruleAction64
+	ruleAction65
+	ruleAction66
+	ruleAction67
+	ruleAction68
+	ruleAction69
+	ruleAction70
+	ruleAction71
+	ruleAction72
+	ruleAction73
+	ruleAction74
+	ruleAction75
+	ruleAction76
+	ruleAction77
+	ruleAction78
+	ruleAction79
+	ruleAction80
+	ruleAction81
+	ruleAction82
+	ruleAction83
+	ruleAction84
+	ruleAction85
+	ruleAction86
+	ruleAction87
+	ruleAction88
+	ruleAction89
 )
 
 var rul3s = [...]string{
@@ -99,30 +164,49 @@ var rul3s = [...]string{
 	"statement",
 	"block",
 	"while",
+	"for",
+	"select",
+	"selectcase",
 	"if",
+	"begin",
+	"raise",
+	"return",
+	"yield",
+	"function",
+	"parameters",
+	"parameter",
+	"typename",
+	"struct",
+	"class",
+	"import",
 	"expression",
 	"assign",
 	"equality",
 	"compare",
+	"range",
 	"additive",
 	"multitive",
 	"factor",
 	"unary",
 	"postfix",
+	"attribute",
+	"index",
 	"funcall",
 	"primary",
+	"list",
 	"float",
 	"integer",
 	"string",
 	"identifier",
+	"keyword",
 	"_",
 	"nl",
 	"comment",
 	"sp",
 	"Action0",
 	"Action1",
-	"PegText",
 	"Action2",
+	"PegText",
 	"Action3",
 	"Action4",
 	"Action5",
@@ -164,6 +248,52 @@ var rul3s = [...]string{
 	"Action41",
 	"Action42",
 	"Action43",
+	"Action44",
+	"Action45",
+	"Action46",
+	"Action47",
+	"Action48",
+	"Action49",
+	"Action50",
+	"Action51",
+	"Action52",
+	"Action53",
+	"Action54",
+	"Action55",
+	"Action56",
+	"Action57",
+	"Action58",
+	"Action59",
+	"Action60",
+	"Action61",
+	"Action62",
+	"Action63",
+	"Action64",
+	"Action65",
+	"Action66",
+	"Action67",
+	"Action68",
+	"Action69",
+	"Action70",
+	"Action71",
+	"Action72",
+	"Action73",
+	"Action74",
+	"Action75",
+	"Action76",
+	"Action77",
+	"Action78",
+	"Action79",
+	"Action80",
+	"Action81",
+	"Action82",
+	"Action83",
+	"Action84",
+	"Action85",
+	"Action86",
+	"Action87",
+	"Action88",
+	"Action89",
 }
 
 type token32 struct {
@@ -280,7 +410,7 @@ type Parser struct {
 
 	Buffer string
 	buffer []rune
-	rules  [72]func() bool
+	rules  [137]func() bool
 	parse  func(rule ...int) error
 	reset  func()
 	Pretty bool
@@ -385,91 +515,183 @@ func (p *Parser) Execute() {
 		case ruleAction0:
 			p.PushExpressionStatement()
 		case ruleAction1:
-			p.PopBlock()
+			p.PushExpressionStatement()
 		case ruleAction2:
-			p.PushBlock(begin)
+			p.PopBlock()
 		case ruleAction3:
-			p.CompleteBlock(end)
+			p.PushBlock(begin)
 		case ruleAction4:
-			p.PushWhile(begin)
+			p.CompleteBlock(end)
 		case ruleAction5:
-			p.CompleteWhile()
+			p.PushWhile(begin)
 		case ruleAction6:
-			p.PushIfPart(begin)
+			p.CompleteWhile()
 		case ruleAction7:
-			p.CompleteIfPart()
+			p.PushFor(begin)
 		case ruleAction8:
-			p.PushElsifPart(begin)
+			p.CompleteFor()
 		case ruleAction9:
-			p.CompleteElsifPart()
+			p.PushSelect(begin)
 		case ruleAction10:
-			p.PushElsePart(begin)
+			p.PushSelectDefault()
 		case ruleAction11:
-			p.CompleteElsePart()
+			p.CompleteSelect(end)
 		case ruleAction12:
-			p.CompleteIf()
+			p.PushSelectCase(begin)
 		case ruleAction13:
-			p.PushAssign("")
+			p.CompleteSelectSend()
 		case ruleAction14:
-			p.PushAssign("+")
+			p.CompleteSelectRecv()
 		case ruleAction15:
-			p.PushAssign("-")
+			p.CompleteSelectCase()
 		case ruleAction16:
-			p.PushAssign("*")
+			p.PushIfPart(begin)
 		case ruleAction17:
-			p.PushAssign("/")
+			p.CompleteIfPart()
 		case ruleAction18:
-			p.PushAssign("%")
+			p.PushElsifPart(begin)
 		case ruleAction19:
-			p.PushBinOp("==")
+			p.CompleteElsifPart()
 		case ruleAction20:
-			p.PushBinOp("!=")
+			p.PushElsePart(begin)
 		case ruleAction21:
-			p.PushBinOp("<=")
+			p.CompleteElsePart()
 		case ruleAction22:
-			p.PushBinOp(">=")
+			p.CompleteIf()
 		case ruleAction23:
-			p.PushBinOp("<")
+			p.PushBegin(begin)
 		case ruleAction24:
-			p.PushBinOp(">")
+			p.CompleteBeginBody()
 		case ruleAction25:
-			p.PushBinOp("+")
+			p.PushRescuePart(begin)
 		case ruleAction26:
-			p.PushBinOp("-")
+			p.CompleteRescuePart()
 		case ruleAction27:
-			p.PushBinOp("*")
+			p.PushEnsurePart(begin)
 		case ruleAction28:
-			p.PushBinOp("/")
+			p.CompleteEnsurePart()
 		case ruleAction29:
-			p.PushBinOp("%")
+			p.CompleteBegin()
 		case ruleAction30:
-			p.PushUnaryOp(begin, end, "-")
+			p.PushRaise(begin)
 		case ruleAction31:
-			p.PushUnaryOp(begin, end, "+")
+			p.CompleteRaise()
 		case ruleAction32:
-			p.PushUnaryOp(begin, end, "!")
+			p.PushReturn(begin, end)
 		case ruleAction33:
-			p.CompleteUnary()
+			p.CompleteReturn()
 		case ruleAction34:
-			p.PushApply()
+			p.PushYield(begin)
 		case ruleAction35:
-			p.CompleteApply(end)
+			p.CompleteYield()
 		case ruleAction36:
-			p.PushApply()
+			p.PushFunction(begin)
 		case ruleAction37:
-			p.CompleteApply(end)
+			p.CompleteFunction()
 		case ruleAction38:
-			p.PushBooleanLiteral(begin, end, true)
+			p.PushParameter()
 		case ruleAction39:
-			p.PushBooleanLiteral(begin, end, false)
+			p.PushTypeName(begin, end, text)
 		case ruleAction40:
-			p.PushFloatLiteral(begin, end, text)
+			p.PushStruct(begin)
 		case ruleAction41:
-			p.PushIntLiteral(begin, end, text)
+			p.CompleteStruct(end)
 		case ruleAction42:
-			p.PushStringLiteral(begin, end, text)
+			p.PushClass(begin)
 		case ruleAction43:
+			p.CompleteClass()
+		case ruleAction44:
+			p.PushImport(begin)
+		case ruleAction45:
+			p.CompleteImport()
+		case ruleAction46:
+			p.PushTypedAssign()
+		case ruleAction47:
+			p.PushAssign("")
+		case ruleAction48:
+			p.PushAssign("+")
+		case ruleAction49:
+			p.PushAssign("-")
+		case ruleAction50:
+			p.PushAssign("*")
+		case ruleAction51:
+			p.PushAssign("/")
+		case ruleAction52:
+			p.PushAssign("%")
+		case ruleAction53:
+			p.PushBinOp("==")
+		case ruleAction54:
+			p.PushBinOp("!=")
+		case ruleAction55:
+			p.PushBinOp("<=")
+		case ruleAction56:
+			p.PushBinOp(">=")
+		case ruleAction57:
+			p.PushBinOp("<")
+		case ruleAction58:
+			p.PushBinOp(">")
+		case ruleAction59:
+			p.PushBinOp("in")
+		case ruleAction60:
+			p.PushBinOp("...")
+		case ruleAction61:
+			p.PushBinOp("..")
+		case ruleAction62:
+			p.PushBinOp("+")
+		case ruleAction63:
+			p.PushBinOp("-")
+		case ruleAction64:
+			p.PushBinOp("*")
+		case ruleAction65:
+			p.PushBinOp("/")
+		case ruleAction66:
+			p.PushBinOp("%")
+		case ruleAction67:
+			p.PushUnaryOp(begin, end, "-")
+		case ruleAction68:
+			p.PushUnaryOp(begin, end, "+")
+		case ruleAction69:
+			p.PushUnaryOp(begin, end, "!")
+		case ruleAction70:
+			p.CompleteUnary()
+		case ruleAction71:
+			p.PushAttribute()
+		case ruleAction72:
+			p.PushIndex(end)
+		case ruleAction73:
+			p.PushApply()
+		case ruleAction74:
+			p.CompleteApply(end)
+		case ruleAction75:
+			p.PushApply()
+		case ruleAction76:
+			p.CompleteApply(end)
+		case ruleAction77:
+			p.PushBooleanLiteral(begin, end, true)
+		case ruleAction78:
+			p.PushBooleanLiteral(begin, end, false)
+		case ruleAction79:
+			p.PushSpawn(begin)
+		case ruleAction80:
+			p.CompleteSpawn()
+		case ruleAction81:
+			p.PushFunction(begin)
+		case ruleAction82:
+			p.CompleteFunction()
+		case ruleAction83:
+			p.PushList(begin)
+		case ruleAction84:
+			p.CompleteList(end)
+		case ruleAction85:
+			p.PushFloatLiteral(begin, end, text)
+		case ruleAction86:
+			p.PushIntLiteral(begin, end, text)
+		case ruleAction87:
+			p.PushStringLiteral(begin, end, text)
+		case ruleAction88:
 			p.PushIdentifier(begin, end, text)
+		case ruleAction89:
+			p.PushComment(begin, end, text)
 
 		}
 	}
@@ -642,54 +864,117 @@ func (p *Parser) Init(options ...func(*Parser) error) error {
 			position, tokenIndex = position5, tokenIndex5
 			return false
 		},
-		/* 3 statement <- <((expression _ comment? nl Action0) / (block Action1) / while / if)> */
+		/* 3 statement <- <((function Action0) / (expression _ comment? nl Action1) / (block Action2) / while / for / select / if / begin / raise / return / yield / import / struct / class)> */
 		func() bool {
 			position9, tokenIndex9 := position, tokenIndex
 			{
 				position10 := position
 				{
 					position11, tokenIndex11 := position, tokenIndex
-					if !_rules[ruleexpression]() {
+					if !_rules[rulefunction]() {
 						goto l12
 					}
-					if !_rules[rule_]() {
+					if !_rules[ruleAction0]() {
 						goto l12
 					}
+					goto l11
+				l12:
+					position, tokenIndex = position11, tokenIndex11
+					if !_rules[ruleexpression]() {
+						goto l13
+					}
+					if !_rules[rule_]() {
+						goto l13
+					}
 					{
-						position13, tokenIndex13 := position, tokenIndex
+						position14, tokenIndex14 := position, tokenIndex
 						if !_rules[rulecomment]() {
-							goto l13
+							goto l14
 						}
-						goto l14
-					l13:
-						position, tokenIndex = position13, tokenIndex13
+						goto l15
+					l14:
+						position, tokenIndex = position14, tokenIndex14
 					}
-				l14:
+				l15:
 					if !_rules[rulenl]() {
-						goto l12
+						goto l13
 					}
-					if !_rules[ruleAction0]() {
-						goto l12
+					if !_rules[ruleAction1]() {
+						goto l13
 					}
 					goto l11
-				l12:
+				l13:
 					position, tokenIndex = position11, tokenIndex11
 					if !_rules[ruleblock]() {
-						goto l15
+						goto l16
 					}
-					if !_rules[ruleAction1]() {
-						goto l15
+					if !_rules[ruleAction2]() {
+						goto l16
 					}
 					goto l11
-				l15:
+				l16:
 					position, tokenIndex = position11, tokenIndex11
 					if !_rules[rulewhile]() {
-						goto l16
+						goto l17
 					}
 					goto l11
-				l16:
+				l17:
+					position, tokenIndex = position11, tokenIndex11
+					if !_rules[rulefor]() {
+						goto l18
+					}
+					goto l11
+				l18:
+					position, tokenIndex = position11, tokenIndex11
+					if !_rules[ruleselect]() {
+						goto l19
+					}
+					goto l11
+				l19:
 					position, tokenIndex = position11, tokenIndex11
 					if !_rules[ruleif]() {
+						goto l20
+					}
+					goto l11
+				l20:
+					position, tokenIndex = position11, tokenIndex11
+					if !_rules[rulebegin]() {
+						goto l21
+					}
+					goto l11
+				l21:
+					position, tokenIndex = position11, tokenIndex11
+					if !_rules[ruleraise]() {
+						goto l22
+					}
+					goto l11
+				l22:
+					position, tokenIndex = position11, tokenIndex11
+					if !_rules[rulereturn]() {
+						goto l23
+					}
+					goto l11
+				l23:
+					position, tokenIndex = position11, tokenIndex11
+					if !_rules[ruleyield]() {
+						goto l24
+					}
+					goto l11
+				l24:
+					position, tokenIndex = position11, tokenIndex11
+					if !_rules[ruleimport]() {
+						goto l25
+					}
+					goto l11
+				l25:
+					position, tokenIndex = position11, tokenIndex11
+					if !_rules[rulestruct]() {
+						goto l26
+					}
+					goto l11
+				l26:
+					position, tokenIndex = position11, tokenIndex11
+					if !_rules[ruleclass]() {
 						goto l9
 					}
 				}
@@ -701,1875 +986,4765 @@ func (p *Parser) Init(options ...func(*Parser) error) error {
 			position, tokenIndex = position9, tokenIndex9
 			return false
 		},
-		/* 4 block <- <(<'{'> Action2 statements <'}'> Action3)> */
+		/* 4 block <- <(<'{'> Action3 statements <'}'> Action4)> */
 		func() bool {
-			position17, tokenIndex17 := position, tokenIndex
+			position27, tokenIndex27 := position, tokenIndex
 			{
-				position18 := position
+				position28 := position
 				{
-					position19 := position
+					position29 := position
 					if buffer[position] != rune('{') {
-						goto l17
+						goto l27
 					}
 					position++
-					add(rulePegText, position19)
+					add(rulePegText, position29)
 				}
-				if !_rules[ruleAction2]() {
-					goto l17
+				if !_rules[ruleAction3]() {
+					goto l27
 				}
 				if !_rules[rulestatements]() {
-					goto l17
+					goto l27
 				}
 				{
-					position20 := position
+					position30 := position
 					if buffer[position] != rune('}') {
-						goto l17
+						goto l27
 					}
 					position++
-					add(rulePegText, position20)
+					add(rulePegText, position30)
 				}
-				if !_rules[ruleAction3]() {
-					goto l17
+				if !_rules[ruleAction4]() {
+					goto l27
 				}
-				add(ruleblock, position18)
+				add(ruleblock, position28)
 			}
 			return true
-		l17:
-			position, tokenIndex = position17, tokenIndex17
+		l27:
+			position, tokenIndex = position27, tokenIndex27
 			return false
 		},
-		/* 5 while <- <(<('w' 'h' 'i' 'l' 'e')> Action4 _ expression _ block Action5)> */
+		/* 5 while <- <(<('w' 'h' 'i' 'l' 'e')> Action5 _ expression _ block Action6)> */
 		func() bool {
-			position21, tokenIndex21 := position, tokenIndex
+			position31, tokenIndex31 := position, tokenIndex
 			{
-				position22 := position
+				position32 := position
 				{
-					position23 := position
+					position33 := position
 					if buffer[position] != rune('w') {
-						goto l21
+						goto l31
 					}
 					position++
 					if buffer[position] != rune('h') {
-						goto l21
+						goto l31
 					}
 					position++
 					if buffer[position] != rune('i') {
-						goto l21
+						goto l31
 					}
 					position++
 					if buffer[position] != rune('l') {
-						goto l21
+						goto l31
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l21
+						goto l31
 					}
 					position++
-					add(rulePegText, position23)
+					add(rulePegText, position33)
 				}
-				if !_rules[ruleAction4]() {
-					goto l21
+				if !_rules[ruleAction5]() {
+					goto l31
 				}
 				if !_rules[rule_]() {
-					goto l21
+					goto l31
 				}
 				if !_rules[ruleexpression]() {
-					goto l21
+					goto l31
 				}
 				if !_rules[rule_]() {
-					goto l21
+					goto l31
 				}
 				if !_rules[ruleblock]() {
-					goto l21
+					goto l31
 				}
-				if !_rules[ruleAction5]() {
-					goto l21
+				if !_rules[ruleAction6]() {
+					goto l31
 				}
-				add(rulewhile, position22)
+				add(rulewhile, position32)
 			}
 			return true
-		l21:
-			position, tokenIndex = position21, tokenIndex21
+		l31:
+			position, tokenIndex = position31, tokenIndex31
 			return false
 		},
-		/* 6 if <- <(<('i' 'f')> Action6 _ expression _ sp _ block Action7 (sp _ <('e' 'l' 's' 'i' 'f')> Action8 _ expression _ sp _ block Action9)* (sp _ <('e' 'l' 's' 'e')> Action10 _ sp _ block Action11)? Action12)> */
+		/* 6 for <- <(<('f' 'o' 'r')> !('_' / [a-z] / [A-Z] / [0-9]) Action7 _ identifier _ ('i' 'n') !('_' / [a-z] / [A-Z] / [0-9]) _ expression _ block Action8)> */
 		func() bool {
-			position24, tokenIndex24 := position, tokenIndex
+			position34, tokenIndex34 := position, tokenIndex
 			{
-				position25 := position
+				position35 := position
 				{
-					position26 := position
-					if buffer[position] != rune('i') {
-						goto l24
+					position36 := position
+					if buffer[position] != rune('f') {
+						goto l34
 					}
 					position++
-					if buffer[position] != rune('f') {
-						goto l24
+					if buffer[position] != rune('o') {
+						goto l34
 					}
 					position++
-					add(rulePegText, position26)
-				}
-				if !_rules[ruleAction6]() {
-					goto l24
+					if buffer[position] != rune('r') {
+						goto l34
+					}
+					position++
+					add(rulePegText, position36)
 				}
-				if !_rules[rule_]() {
-					goto l24
+				{
+					position37, tokenIndex37 := position, tokenIndex
+					{
+						position38, tokenIndex38 := position, tokenIndex
+						if buffer[position] != rune('_') {
+							goto l39
+						}
+						position++
+						goto l38
+					l39:
+						position, tokenIndex = position38, tokenIndex38
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l40
+						}
+						position++
+						goto l38
+					l40:
+						position, tokenIndex = position38, tokenIndex38
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l41
+						}
+						position++
+						goto l38
+					l41:
+						position, tokenIndex = position38, tokenIndex38
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l37
+						}
+						position++
+					}
+				l38:
+					goto l34
+				l37:
+					position, tokenIndex = position37, tokenIndex37
 				}
-				if !_rules[ruleexpression]() {
-					goto l24
+				if !_rules[ruleAction7]() {
+					goto l34
 				}
 				if !_rules[rule_]() {
-					goto l24
+					goto l34
 				}
-				if !_rules[rulesp]() {
-					goto l24
+				if !_rules[ruleidentifier]() {
+					goto l34
 				}
 				if !_rules[rule_]() {
-					goto l24
+					goto l34
 				}
-				if !_rules[ruleblock]() {
-					goto l24
+				if buffer[position] != rune('i') {
+					goto l34
 				}
-				if !_rules[ruleAction7]() {
-					goto l24
+				position++
+				if buffer[position] != rune('n') {
+					goto l34
 				}
-			l27:
+				position++
 				{
-					position28, tokenIndex28 := position, tokenIndex
-					if !_rules[rulesp]() {
-						goto l28
-					}
-					if !_rules[rule_]() {
-						goto l28
-					}
+					position42, tokenIndex42 := position, tokenIndex
 					{
-						position29 := position
-						if buffer[position] != rune('e') {
-							goto l28
-						}
-						position++
-						if buffer[position] != rune('l') {
-							goto l28
+						position43, tokenIndex43 := position, tokenIndex
+						if buffer[position] != rune('_') {
+							goto l44
 						}
 						position++
-						if buffer[position] != rune('s') {
-							goto l28
+						goto l43
+					l44:
+						position, tokenIndex = position43, tokenIndex43
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l45
 						}
 						position++
-						if buffer[position] != rune('i') {
-							goto l28
+						goto l43
+					l45:
+						position, tokenIndex = position43, tokenIndex43
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l46
 						}
 						position++
-						if buffer[position] != rune('f') {
-							goto l28
+						goto l43
+					l46:
+						position, tokenIndex = position43, tokenIndex43
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l42
 						}
 						position++
-						add(rulePegText, position29)
-					}
-					if !_rules[ruleAction8]() {
-						goto l28
 					}
-					if !_rules[rule_]() {
-						goto l28
-					}
-					if !_rules[ruleexpression]() {
-						goto l28
+				l43:
+					goto l34
+				l42:
+					position, tokenIndex = position42, tokenIndex42
+				}
+				if !_rules[rule_]() {
+					goto l34
+				}
+				if !_rules[ruleexpression]() {
+					goto l34
+				}
+				if !_rules[rule_]() {
+					goto l34
+				}
+				if !_rules[ruleblock]() {
+					goto l34
+				}
+				if !_rules[ruleAction8]() {
+					goto l34
+				}
+				add(rulefor, position35)
+			}
+			return true
+		l34:
+			position, tokenIndex = position34, tokenIndex34
+			return false
+		},
+		/* 7 select <- <(<('s' 'e' 'l' 'e' 'c' 't')> !('_' / [a-z] / [A-Z] / [0-9]) Action9 _ '{' sp _ (selectcase sp _)* ('d' 'e' 'f' 'a' 'u' 'l' 't' !('_' / [a-z] / [A-Z] / [0-9]) _ block Action10 sp _)? <'}'> Action11)> */
+		func() bool {
+			position47, tokenIndex47 := position, tokenIndex
+			{
+				position48 := position
+				{
+					position49 := position
+					if buffer[position] != rune('s') {
+						goto l47
 					}
-					if !_rules[rule_]() {
-						goto l28
+					position++
+					if buffer[position] != rune('e') {
+						goto l47
 					}
-					if !_rules[rulesp]() {
-						goto l28
+					position++
+					if buffer[position] != rune('l') {
+						goto l47
 					}
-					if !_rules[rule_]() {
-						goto l28
+					position++
+					if buffer[position] != rune('e') {
+						goto l47
 					}
-					if !_rules[ruleblock]() {
-						goto l28
+					position++
+					if buffer[position] != rune('c') {
+						goto l47
 					}
-					if !_rules[ruleAction9]() {
-						goto l28
+					position++
+					if buffer[position] != rune('t') {
+						goto l47
 					}
-					goto l27
-				l28:
-					position, tokenIndex = position28, tokenIndex28
+					position++
+					add(rulePegText, position49)
 				}
 				{
-					position30, tokenIndex30 := position, tokenIndex
-					if !_rules[rulesp]() {
-						goto l30
-					}
-					if !_rules[rule_]() {
-						goto l30
-					}
+					position50, tokenIndex50 := position, tokenIndex
 					{
-						position32 := position
-						if buffer[position] != rune('e') {
-							goto l30
+						position51, tokenIndex51 := position, tokenIndex
+						if buffer[position] != rune('_') {
+							goto l52
 						}
 						position++
-						if buffer[position] != rune('l') {
-							goto l30
+						goto l51
+					l52:
+						position, tokenIndex = position51, tokenIndex51
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l53
 						}
 						position++
-						if buffer[position] != rune('s') {
-							goto l30
+						goto l51
+					l53:
+						position, tokenIndex = position51, tokenIndex51
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l54
 						}
 						position++
-						if buffer[position] != rune('e') {
-							goto l30
+						goto l51
+					l54:
+						position, tokenIndex = position51, tokenIndex51
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l50
 						}
 						position++
-						add(rulePegText, position32)
 					}
-					if !_rules[ruleAction10]() {
-						goto l30
+				l51:
+					goto l47
+				l50:
+					position, tokenIndex = position50, tokenIndex50
+				}
+				if !_rules[ruleAction9]() {
+					goto l47
+				}
+				if !_rules[rule_]() {
+					goto l47
+				}
+				if buffer[position] != rune('{') {
+					goto l47
+				}
+				position++
+				if !_rules[rulesp]() {
+					goto l47
+				}
+				if !_rules[rule_]() {
+					goto l47
+				}
+			l55:
+				{
+					position56, tokenIndex56 := position, tokenIndex
+					if !_rules[ruleselectcase]() {
+						goto l56
+					}
+					if !_rules[rulesp]() {
+						goto l56
 					}
 					if !_rules[rule_]() {
-						goto l30
+						goto l56
 					}
-					if !_rules[rulesp]() {
-						goto l30
+					goto l55
+				l56:
+					position, tokenIndex = position56, tokenIndex56
+				}
+				{
+					position57, tokenIndex57 := position, tokenIndex
+					if buffer[position] != rune('d') {
+						goto l57
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l57
+					}
+					position++
+					if buffer[position] != rune('f') {
+						goto l57
+					}
+					position++
+					if buffer[position] != rune('a') {
+						goto l57
+					}
+					position++
+					if buffer[position] != rune('u') {
+						goto l57
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l57
+					}
+					position++
+					if buffer[position] != rune('t') {
+						goto l57
+					}
+					position++
+					{
+						position59, tokenIndex59 := position, tokenIndex
+						{
+							position60, tokenIndex60 := position, tokenIndex
+							if buffer[position] != rune('_') {
+								goto l61
+							}
+							position++
+							goto l60
+						l61:
+							position, tokenIndex = position60, tokenIndex60
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l62
+							}
+							position++
+							goto l60
+						l62:
+							position, tokenIndex = position60, tokenIndex60
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l63
+							}
+							position++
+							goto l60
+						l63:
+							position, tokenIndex = position60, tokenIndex60
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l59
+							}
+							position++
+						}
+					l60:
+						goto l57
+					l59:
+						position, tokenIndex = position59, tokenIndex59
 					}
 					if !_rules[rule_]() {
-						goto l30
+						goto l57
 					}
 					if !_rules[ruleblock]() {
-						goto l30
+						goto l57
 					}
-					if !_rules[ruleAction11]() {
-						goto l30
+					if !_rules[ruleAction10]() {
+						goto l57
 					}
-					goto l31
-				l30:
-					position, tokenIndex = position30, tokenIndex30
-				}
-			l31:
-				if !_rules[ruleAction12]() {
-					goto l24
+					if !_rules[rulesp]() {
+						goto l57
+					}
+					if !_rules[rule_]() {
+						goto l57
+					}
+					goto l58
+				l57:
+					position, tokenIndex = position57, tokenIndex57
 				}
-				add(ruleif, position25)
-			}
-			return true
-		l24:
-			position, tokenIndex = position24, tokenIndex24
-			return false
-		},
-		/* 7 expression <- <(assign / equality)> */
-		func() bool {
-			position33, tokenIndex33 := position, tokenIndex
-			{
-				position34 := position
+			l58:
 				{
-					position35, tokenIndex35 := position, tokenIndex
-					if !_rules[ruleassign]() {
-						goto l36
-					}
-					goto l35
-				l36:
-					position, tokenIndex = position35, tokenIndex35
-					if !_rules[ruleequality]() {
-						goto l33
+					position64 := position
+					if buffer[position] != rune('}') {
+						goto l47
 					}
+					position++
+					add(rulePegText, position64)
 				}
-			l35:
-				add(ruleexpression, position34)
+				if !_rules[ruleAction11]() {
+					goto l47
+				}
+				add(ruleselect, position48)
 			}
 			return true
-		l33:
-			position, tokenIndex = position33, tokenIndex33
+		l47:
+			position, tokenIndex = position47, tokenIndex47
 			return false
 		},
-		/* 8 assign <- <((identifier _ '=' _ expression Action13) / (identifier _ ('+' '=') _ expression Action14) / (identifier _ ('-' '=') _ expression Action15) / (identifier _ ('*' '=') _ expression Action16) / (identifier _ ('/' '=') _ expression Action17) / (identifier _ ('%' '=') _ expression Action18))> */
+		/* 8 selectcase <- <(<('c' 'a' 's' 'e')> !('_' / [a-z] / [A-Z] / [0-9]) Action12 _ (('s' 'e' 'n' 'd' _ '(' sp _ expression _ ',' sp _ expression sp _ ')' Action13) / ((identifier _ '=' _)? ('r' 'e' 'c' 'v') _ '(' sp _ expression sp _ ')' Action14)) _ block Action15)> */
 		func() bool {
-			position37, tokenIndex37 := position, tokenIndex
+			position65, tokenIndex65 := position, tokenIndex
 			{
-				position38 := position
+				position66 := position
 				{
-					position39, tokenIndex39 := position, tokenIndex
-					if !_rules[ruleidentifier]() {
-						goto l40
-					}
-					if !_rules[rule_]() {
-						goto l40
+					position67 := position
+					if buffer[position] != rune('c') {
+						goto l65
 					}
-					if buffer[position] != rune('=') {
-						goto l40
+					position++
+					if buffer[position] != rune('a') {
+						goto l65
 					}
 					position++
-					if !_rules[rule_]() {
-						goto l40
+					if buffer[position] != rune('s') {
+						goto l65
 					}
-					if !_rules[ruleexpression]() {
-						goto l40
+					position++
+					if buffer[position] != rune('e') {
+						goto l65
 					}
-					if !_rules[ruleAction13]() {
-						goto l40
+					position++
+					add(rulePegText, position67)
+				}
+				{
+					position68, tokenIndex68 := position, tokenIndex
+					{
+						position69, tokenIndex69 := position, tokenIndex
+						if buffer[position] != rune('_') {
+							goto l70
+						}
+						position++
+						goto l69
+					l70:
+						position, tokenIndex = position69, tokenIndex69
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l71
+						}
+						position++
+						goto l69
+					l71:
+						position, tokenIndex = position69, tokenIndex69
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l72
+						}
+						position++
+						goto l69
+					l72:
+						position, tokenIndex = position69, tokenIndex69
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l68
+						}
+						position++
 					}
-					goto l39
-				l40:
-					position, tokenIndex = position39, tokenIndex39
-					if !_rules[ruleidentifier]() {
-						goto l41
+				l69:
+					goto l65
+				l68:
+					position, tokenIndex = position68, tokenIndex68
+				}
+				if !_rules[ruleAction12]() {
+					goto l65
+				}
+				if !_rules[rule_]() {
+					goto l65
+				}
+				{
+					position73, tokenIndex73 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l74
 					}
-					if !_rules[rule_]() {
-						goto l41
+					position++
+					if buffer[position] != rune('e') {
+						goto l74
 					}
-					if buffer[position] != rune('+') {
-						goto l41
+					position++
+					if buffer[position] != rune('n') {
+						goto l74
 					}
 					position++
-					if buffer[position] != rune('=') {
-						goto l41
+					if buffer[position] != rune('d') {
+						goto l74
 					}
 					position++
 					if !_rules[rule_]() {
-						goto l41
+						goto l74
 					}
-					if !_rules[ruleexpression]() {
-						goto l41
+					if buffer[position] != rune('(') {
+						goto l74
 					}
-					if !_rules[ruleAction14]() {
-						goto l41
+					position++
+					if !_rules[rulesp]() {
+						goto l74
 					}
-					goto l39
-				l41:
-					position, tokenIndex = position39, tokenIndex39
-					if !_rules[ruleidentifier]() {
-						goto l42
+					if !_rules[rule_]() {
+						goto l74
+					}
+					if !_rules[ruleexpression]() {
+						goto l74
 					}
 					if !_rules[rule_]() {
-						goto l42
+						goto l74
 					}
-					if buffer[position] != rune('-') {
-						goto l42
+					if buffer[position] != rune(',') {
+						goto l74
 					}
 					position++
-					if buffer[position] != rune('=') {
-						goto l42
+					if !_rules[rulesp]() {
+						goto l74
 					}
-					position++
 					if !_rules[rule_]() {
-						goto l42
+						goto l74
 					}
 					if !_rules[ruleexpression]() {
-						goto l42
+						goto l74
 					}
-					if !_rules[ruleAction15]() {
-						goto l42
-					}
-					goto l39
-				l42:
-					position, tokenIndex = position39, tokenIndex39
-					if !_rules[ruleidentifier]() {
-						goto l43
+					if !_rules[rulesp]() {
+						goto l74
 					}
 					if !_rules[rule_]() {
-						goto l43
-					}
-					if buffer[position] != rune('*') {
-						goto l43
+						goto l74
 					}
-					position++
-					if buffer[position] != rune('=') {
-						goto l43
+					if buffer[position] != rune(')') {
+						goto l74
 					}
 					position++
-					if !_rules[rule_]() {
-						goto l43
-					}
-					if !_rules[ruleexpression]() {
-						goto l43
+					if !_rules[ruleAction13]() {
+						goto l74
 					}
-					if !_rules[ruleAction16]() {
-						goto l43
+					goto l73
+				l74:
+					position, tokenIndex = position73, tokenIndex73
+					{
+						position75, tokenIndex75 := position, tokenIndex
+						if !_rules[ruleidentifier]() {
+							goto l75
+						}
+						if !_rules[rule_]() {
+							goto l75
+						}
+						if buffer[position] != rune('=') {
+							goto l75
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l75
+						}
+						goto l76
+					l75:
+						position, tokenIndex = position75, tokenIndex75
 					}
-					goto l39
-				l43:
-					position, tokenIndex = position39, tokenIndex39
-					if !_rules[ruleidentifier]() {
-						goto l44
+				l76:
+					if buffer[position] != rune('r') {
+						goto l65
 					}
-					if !_rules[rule_]() {
-						goto l44
+					position++
+					if buffer[position] != rune('e') {
+						goto l65
 					}
-					if buffer[position] != rune('/') {
-						goto l44
+					position++
+					if buffer[position] != rune('c') {
+						goto l65
 					}
 					position++
-					if buffer[position] != rune('=') {
-						goto l44
+					if buffer[position] != rune('v') {
+						goto l65
 					}
 					position++
 					if !_rules[rule_]() {
-						goto l44
-					}
-					if !_rules[ruleexpression]() {
-						goto l44
+						goto l65
 					}
-					if !_rules[ruleAction17]() {
-						goto l44
+					if buffer[position] != rune('(') {
+						goto l65
 					}
-					goto l39
-				l44:
-					position, tokenIndex = position39, tokenIndex39
-					if !_rules[ruleidentifier]() {
-						goto l37
+					position++
+					if !_rules[rulesp]() {
+						goto l65
 					}
 					if !_rules[rule_]() {
-						goto l37
+						goto l65
 					}
-					if buffer[position] != rune('%') {
-						goto l37
+					if !_rules[ruleexpression]() {
+						goto l65
 					}
-					position++
-					if buffer[position] != rune('=') {
-						goto l37
+					if !_rules[rulesp]() {
+						goto l65
 					}
-					position++
 					if !_rules[rule_]() {
-						goto l37
+						goto l65
 					}
-					if !_rules[ruleexpression]() {
-						goto l37
+					if buffer[position] != rune(')') {
+						goto l65
 					}
-					if !_rules[ruleAction18]() {
-						goto l37
+					position++
+					if !_rules[ruleAction14]() {
+						goto l65
 					}
 				}
-			l39:
-				add(ruleassign, position38)
+			l73:
+				if !_rules[rule_]() {
+					goto l65
+				}
+				if !_rules[ruleblock]() {
+					goto l65
+				}
+				if !_rules[ruleAction15]() {
+					goto l65
+				}
+				add(ruleselectcase, position66)
 			}
 			return true
-		l37:
-			position, tokenIndex = position37, tokenIndex37
+		l65:
+			position, tokenIndex = position65, tokenIndex65
 			return false
 		},
-		/* 9 equality <- <(compare ((_ ('=' '=') _ compare Action19) / (_ ('!' '=') _ compare Action20))*)> */
+		/* 9 if <- <(<('i' 'f')> Action16 _ expression _ sp _ block Action17 (sp _ <('e' 'l' 's' 'i' 'f')> Action18 _ expression _ sp _ block Action19)* (sp _ <('e' 'l' 's' 'e')> Action20 _ sp _ block Action21)? Action22)> */
 		func() bool {
-			position45, tokenIndex45 := position, tokenIndex
+			position77, tokenIndex77 := position, tokenIndex
 			{
-				position46 := position
-				if !_rules[rulecompare]() {
-					goto l45
+				position78 := position
+				{
+					position79 := position
+					if buffer[position] != rune('i') {
+						goto l77
+					}
+					position++
+					if buffer[position] != rune('f') {
+						goto l77
+					}
+					position++
+					add(rulePegText, position79)
+				}
+				if !_rules[ruleAction16]() {
+					goto l77
 				}
-			l47:
+				if !_rules[rule_]() {
+					goto l77
+				}
+				if !_rules[ruleexpression]() {
+					goto l77
+				}
+				if !_rules[rule_]() {
+					goto l77
+				}
+				if !_rules[rulesp]() {
+					goto l77
+				}
+				if !_rules[rule_]() {
+					goto l77
+				}
+				if !_rules[ruleblock]() {
+					goto l77
+				}
+				if !_rules[ruleAction17]() {
+					goto l77
+				}
+			l80:
 				{
-					position48, tokenIndex48 := position, tokenIndex
-					{
-						position49, tokenIndex49 := position, tokenIndex
-						if !_rules[rule_]() {
-							goto l50
-						}
-						if buffer[position] != rune('=') {
-							goto l50
+					position81, tokenIndex81 := position, tokenIndex
+					if !_rules[rulesp]() {
+						goto l81
+					}
+					if !_rules[rule_]() {
+						goto l81
+					}
+					{
+						position82 := position
+						if buffer[position] != rune('e') {
+							goto l81
 						}
 						position++
-						if buffer[position] != rune('=') {
-							goto l50
+						if buffer[position] != rune('l') {
+							goto l81
 						}
 						position++
-						if !_rules[rule_]() {
-							goto l50
-						}
-						if !_rules[rulecompare]() {
-							goto l50
-						}
-						if !_rules[ruleAction19]() {
-							goto l50
+						if buffer[position] != rune('s') {
+							goto l81
 						}
-						goto l49
-					l50:
-						position, tokenIndex = position49, tokenIndex49
-						if !_rules[rule_]() {
-							goto l48
+						position++
+						if buffer[position] != rune('i') {
+							goto l81
 						}
-						if buffer[position] != rune('!') {
-							goto l48
+						position++
+						if buffer[position] != rune('f') {
+							goto l81
 						}
 						position++
-						if buffer[position] != rune('=') {
-							goto l48
+						add(rulePegText, position82)
+					}
+					if !_rules[ruleAction18]() {
+						goto l81
+					}
+					if !_rules[rule_]() {
+						goto l81
+					}
+					if !_rules[ruleexpression]() {
+						goto l81
+					}
+					if !_rules[rule_]() {
+						goto l81
+					}
+					if !_rules[rulesp]() {
+						goto l81
+					}
+					if !_rules[rule_]() {
+						goto l81
+					}
+					if !_rules[ruleblock]() {
+						goto l81
+					}
+					if !_rules[ruleAction19]() {
+						goto l81
+					}
+					goto l80
+				l81:
+					position, tokenIndex = position81, tokenIndex81
+				}
+				{
+					position83, tokenIndex83 := position, tokenIndex
+					if !_rules[rulesp]() {
+						goto l83
+					}
+					if !_rules[rule_]() {
+						goto l83
+					}
+					{
+						position85 := position
+						if buffer[position] != rune('e') {
+							goto l83
 						}
 						position++
-						if !_rules[rule_]() {
-							goto l48
+						if buffer[position] != rune('l') {
+							goto l83
 						}
-						if !_rules[rulecompare]() {
-							goto l48
+						position++
+						if buffer[position] != rune('s') {
+							goto l83
 						}
-						if !_rules[ruleAction20]() {
-							goto l48
+						position++
+						if buffer[position] != rune('e') {
+							goto l83
 						}
+						position++
+						add(rulePegText, position85)
 					}
-				l49:
-					goto l47
-				l48:
-					position, tokenIndex = position48, tokenIndex48
+					if !_rules[ruleAction20]() {
+						goto l83
+					}
+					if !_rules[rule_]() {
+						goto l83
+					}
+					if !_rules[rulesp]() {
+						goto l83
+					}
+					if !_rules[rule_]() {
+						goto l83
+					}
+					if !_rules[ruleblock]() {
+						goto l83
+					}
+					if !_rules[ruleAction21]() {
+						goto l83
+					}
+					goto l84
+				l83:
+					position, tokenIndex = position83, tokenIndex83
+				}
+			l84:
+				if !_rules[ruleAction22]() {
+					goto l77
 				}
-				add(ruleequality, position46)
+				add(ruleif, position78)
 			}
 			return true
-		l45:
-			position, tokenIndex = position45, tokenIndex45
+		l77:
+			position, tokenIndex = position77, tokenIndex77
 			return false
 		},
-		/* 10 compare <- <(additive ((_ ('<' '=') _ additive Action21) / (_ ('>' '=') _ additive Action22) / (_ '<' _ additive Action23) / (_ '>' _ additive Action24))*)> */
+		/* 10 begin <- <(<('b' 'e' 'g' 'i' 'n')> Action23 _ sp _ block Action24 (sp _ <('r' 'e' 's' 'c' 'u' 'e')> Action25 (_ identifier)? _ sp _ block Action26)? (sp _ <('e' 'n' 's' 'u' 'r' 'e')> Action27 _ sp _ block Action28)? Action29)> */
 		func() bool {
-			position51, tokenIndex51 := position, tokenIndex
+			position86, tokenIndex86 := position, tokenIndex
 			{
-				position52 := position
-				if !_rules[ruleadditive]() {
-					goto l51
+				position87 := position
+				{
+					position88 := position
+					if buffer[position] != rune('b') {
+						goto l86
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l86
+					}
+					position++
+					if buffer[position] != rune('g') {
+						goto l86
+					}
+					position++
+					if buffer[position] != rune('i') {
+						goto l86
+					}
+					position++
+					if buffer[position] != rune('n') {
+						goto l86
+					}
+					position++
+					add(rulePegText, position88)
+				}
+				if !_rules[ruleAction23]() {
+					goto l86
+				}
+				if !_rules[rule_]() {
+					goto l86
+				}
+				if !_rules[rulesp]() {
+					goto l86
+				}
+				if !_rules[rule_]() {
+					goto l86
+				}
+				if !_rules[ruleblock]() {
+					goto l86
+				}
+				if !_rules[ruleAction24]() {
+					goto l86
 				}
-			l53:
 				{
-					position54, tokenIndex54 := position, tokenIndex
+					position89, tokenIndex89 := position, tokenIndex
+					if !_rules[rulesp]() {
+						goto l89
+					}
+					if !_rules[rule_]() {
+						goto l89
+					}
 					{
-						position55, tokenIndex55 := position, tokenIndex
-						if !_rules[rule_]() {
-							goto l56
-						}
-						if buffer[position] != rune('<') {
-							goto l56
+						position91 := position
+						if buffer[position] != rune('r') {
+							goto l89
 						}
 						position++
-						if buffer[position] != rune('=') {
-							goto l56
+						if buffer[position] != rune('e') {
+							goto l89
 						}
 						position++
-						if !_rules[rule_]() {
-							goto l56
-						}
-						if !_rules[ruleadditive]() {
-							goto l56
-						}
-						if !_rules[ruleAction21]() {
-							goto l56
-						}
-						goto l55
-					l56:
-						position, tokenIndex = position55, tokenIndex55
-						if !_rules[rule_]() {
-							goto l57
-						}
-						if buffer[position] != rune('>') {
-							goto l57
+						if buffer[position] != rune('s') {
+							goto l89
 						}
 						position++
-						if buffer[position] != rune('=') {
-							goto l57
+						if buffer[position] != rune('c') {
+							goto l89
 						}
 						position++
-						if !_rules[rule_]() {
-							goto l57
-						}
-						if !_rules[ruleadditive]() {
-							goto l57
-						}
-						if !_rules[ruleAction22]() {
-							goto l57
-						}
-						goto l55
-					l57:
-						position, tokenIndex = position55, tokenIndex55
-						if !_rules[rule_]() {
-							goto l58
-						}
-						if buffer[position] != rune('<') {
-							goto l58
+						if buffer[position] != rune('u') {
+							goto l89
 						}
 						position++
-						if !_rules[rule_]() {
-							goto l58
-						}
-						if !_rules[ruleadditive]() {
-							goto l58
-						}
-						if !_rules[ruleAction23]() {
-							goto l58
-						}
-						goto l55
-					l58:
-						position, tokenIndex = position55, tokenIndex55
-						if !_rules[rule_]() {
-							goto l54
-						}
-						if buffer[position] != rune('>') {
-							goto l54
+						if buffer[position] != rune('e') {
+							goto l89
 						}
 						position++
+						add(rulePegText, position91)
+					}
+					if !_rules[ruleAction25]() {
+						goto l89
+					}
+					{
+						position92, tokenIndex92 := position, tokenIndex
 						if !_rules[rule_]() {
-							goto l54
-						}
-						if !_rules[ruleadditive]() {
-							goto l54
+							goto l92
 						}
-						if !_rules[ruleAction24]() {
-							goto l54
+						if !_rules[ruleidentifier]() {
+							goto l92
 						}
+						goto l93
+					l92:
+						position, tokenIndex = position92, tokenIndex92
 					}
-				l55:
-					goto l53
-				l54:
-					position, tokenIndex = position54, tokenIndex54
-				}
-				add(rulecompare, position52)
-			}
-			return true
-		l51:
-			position, tokenIndex = position51, tokenIndex51
-			return false
-		},
-		/* 11 additive <- <(multitive ((_ '+' _ multitive Action25) / (_ '-' _ multitive Action26))*)> */
-		func() bool {
-			position59, tokenIndex59 := position, tokenIndex
-			{
-				position60 := position
-				if !_rules[rulemultitive]() {
-					goto l59
+				l93:
+					if !_rules[rule_]() {
+						goto l89
+					}
+					if !_rules[rulesp]() {
+						goto l89
+					}
+					if !_rules[rule_]() {
+						goto l89
+					}
+					if !_rules[ruleblock]() {
+						goto l89
+					}
+					if !_rules[ruleAction26]() {
+						goto l89
+					}
+					goto l90
+				l89:
+					position, tokenIndex = position89, tokenIndex89
 				}
-			l61:
+			l90:
 				{
-					position62, tokenIndex62 := position, tokenIndex
+					position94, tokenIndex94 := position, tokenIndex
+					if !_rules[rulesp]() {
+						goto l94
+					}
+					if !_rules[rule_]() {
+						goto l94
+					}
 					{
-						position63, tokenIndex63 := position, tokenIndex
-						if !_rules[rule_]() {
-							goto l64
-						}
-						if buffer[position] != rune('+') {
-							goto l64
+						position96 := position
+						if buffer[position] != rune('e') {
+							goto l94
 						}
 						position++
-						if !_rules[rule_]() {
-							goto l64
-						}
-						if !_rules[rulemultitive]() {
-							goto l64
-						}
-						if !_rules[ruleAction25]() {
-							goto l64
-						}
-						goto l63
-					l64:
-						position, tokenIndex = position63, tokenIndex63
-						if !_rules[rule_]() {
-							goto l62
+						if buffer[position] != rune('n') {
+							goto l94
 						}
-						if buffer[position] != rune('-') {
-							goto l62
+						position++
+						if buffer[position] != rune('s') {
+							goto l94
 						}
 						position++
-						if !_rules[rule_]() {
-							goto l62
+						if buffer[position] != rune('u') {
+							goto l94
 						}
-						if !_rules[rulemultitive]() {
-							goto l62
+						position++
+						if buffer[position] != rune('r') {
+							goto l94
 						}
-						if !_rules[ruleAction26]() {
-							goto l62
+						position++
+						if buffer[position] != rune('e') {
+							goto l94
 						}
+						position++
+						add(rulePegText, position96)
+					}
+					if !_rules[ruleAction27]() {
+						goto l94
+					}
+					if !_rules[rule_]() {
+						goto l94
+					}
+					if !_rules[rulesp]() {
+						goto l94
+					}
+					if !_rules[rule_]() {
+						goto l94
+					}
+					if !_rules[ruleblock]() {
+						goto l94
+					}
+					if !_rules[ruleAction28]() {
+						goto l94
 					}
-				l63:
-					goto l61
-				l62:
-					position, tokenIndex = position62, tokenIndex62
+					goto l95
+				l94:
+					position, tokenIndex = position94, tokenIndex94
+				}
+			l95:
+				if !_rules[ruleAction29]() {
+					goto l86
 				}
-				add(ruleadditive, position60)
+				add(rulebegin, position87)
 			}
 			return true
-		l59:
-			position, tokenIndex = position59, tokenIndex59
+		l86:
+			position, tokenIndex = position86, tokenIndex86
 			return false
 		},
-		/* 12 multitive <- <(factor ((_ '*' _ factor Action27) / (_ '/' _ factor Action28) / (_ '%' _ factor Action29))*)> */
+		/* 11 raise <- <(<('r' 'a' 'i' 's' 'e')> Action30 _ expression _ comment? nl Action31)> */
 		func() bool {
-			position65, tokenIndex65 := position, tokenIndex
+			position97, tokenIndex97 := position, tokenIndex
 			{
-				position66 := position
-				if !_rules[rulefactor]() {
-					goto l65
+				position98 := position
+				{
+					position99 := position
+					if buffer[position] != rune('r') {
+						goto l97
+					}
+					position++
+					if buffer[position] != rune('a') {
+						goto l97
+					}
+					position++
+					if buffer[position] != rune('i') {
+						goto l97
+					}
+					position++
+					if buffer[position] != rune('s') {
+						goto l97
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l97
+					}
+					position++
+					add(rulePegText, position99)
+				}
+				if !_rules[ruleAction30]() {
+					goto l97
+				}
+				if !_rules[rule_]() {
+					goto l97
+				}
+				if !_rules[ruleexpression]() {
+					goto l97
+				}
+				if !_rules[rule_]() {
+					goto l97
 				}
-			l67:
 				{
-					position68, tokenIndex68 := position, tokenIndex
-					{
-						position69, tokenIndex69 := position, tokenIndex
-						if !_rules[rule_]() {
-							goto l70
-						}
-						if buffer[position] != rune('*') {
-							goto l70
-						}
-						position++
-						if !_rules[rule_]() {
-							goto l70
-						}
-						if !_rules[rulefactor]() {
-							goto l70
-						}
-						if !_rules[ruleAction27]() {
-							goto l70
-						}
-						goto l69
-					l70:
-						position, tokenIndex = position69, tokenIndex69
-						if !_rules[rule_]() {
-							goto l71
-						}
-						if buffer[position] != rune('/') {
-							goto l71
-						}
-						position++
-						if !_rules[rule_]() {
-							goto l71
-						}
-						if !_rules[rulefactor]() {
-							goto l71
-						}
-						if !_rules[ruleAction28]() {
-							goto l71
-						}
-						goto l69
-					l71:
-						position, tokenIndex = position69, tokenIndex69
-						if !_rules[rule_]() {
-							goto l68
-						}
-						if buffer[position] != rune('%') {
-							goto l68
-						}
-						position++
-						if !_rules[rule_]() {
-							goto l68
-						}
-						if !_rules[rulefactor]() {
-							goto l68
-						}
-						if !_rules[ruleAction29]() {
-							goto l68
-						}
+					position100, tokenIndex100 := position, tokenIndex
+					if !_rules[rulecomment]() {
+						goto l100
 					}
-				l69:
-					goto l67
-				l68:
-					position, tokenIndex = position68, tokenIndex68
+					goto l101
+				l100:
+					position, tokenIndex = position100, tokenIndex100
+				}
+			l101:
+				if !_rules[rulenl]() {
+					goto l97
+				}
+				if !_rules[ruleAction31]() {
+					goto l97
 				}
-				add(rulemultitive, position66)
+				add(ruleraise, position98)
 			}
 			return true
-		l65:
-			position, tokenIndex = position65, tokenIndex65
+		l97:
+			position, tokenIndex = position97, tokenIndex97
 			return false
 		},
-		/* 13 factor <- <(unary / postfix)> */
+		/* 12 return <- <(<('r' 'e' 't' 'u' 'r' 'n')> Action32 (_ expression)? _ comment? nl Action33)> */
 		func() bool {
-			position72, tokenIndex72 := position, tokenIndex
+			position102, tokenIndex102 := position, tokenIndex
 			{
-				position73 := position
+				position103 := position
 				{
-					position74, tokenIndex74 := position, tokenIndex
-					if !_rules[ruleunary]() {
-						goto l75
+					position104 := position
+					if buffer[position] != rune('r') {
+						goto l102
 					}
-					goto l74
-				l75:
-					position, tokenIndex = position74, tokenIndex74
-					if !_rules[rulepostfix]() {
-						goto l72
+					position++
+					if buffer[position] != rune('e') {
+						goto l102
+					}
+					position++
+					if buffer[position] != rune('t') {
+						goto l102
+					}
+					position++
+					if buffer[position] != rune('u') {
+						goto l102
+					}
+					position++
+					if buffer[position] != rune('r') {
+						goto l102
+					}
+					position++
+					if buffer[position] != rune('n') {
+						goto l102
+					}
+					position++
+					add(rulePegText, position104)
+				}
+				if !_rules[ruleAction32]() {
+					goto l102
+				}
+				{
+					position105, tokenIndex105 := position, tokenIndex
+					if !_rules[rule_]() {
+						goto l105
+					}
+					if !_rules[ruleexpression]() {
+						goto l105
+					}
+					goto l106
+				l105:
+					position, tokenIndex = position105, tokenIndex105
+				}
+			l106:
+				if !_rules[rule_]() {
+					goto l102
+				}
+				{
+					position107, tokenIndex107 := position, tokenIndex
+					if !_rules[rulecomment]() {
+						goto l107
 					}
+					goto l108
+				l107:
+					position, tokenIndex = position107, tokenIndex107
+				}
+			l108:
+				if !_rules[rulenl]() {
+					goto l102
+				}
+				if !_rules[ruleAction33]() {
+					goto l102
 				}
-			l74:
-				add(rulefactor, position73)
+				add(rulereturn, position103)
 			}
 			return true
-		l72:
-			position, tokenIndex = position72, tokenIndex72
+		l102:
+			position, tokenIndex = position102, tokenIndex102
 			return false
 		},
-		/* 14 unary <- <(((<'-'> Action30) / (<'+'> Action31) / (<'!'> Action32)) _ factor Action33)> */
+		/* 13 yield <- <(<('y' 'i' 'e' 'l' 'd')> !('_' / [a-z] / [A-Z] / [0-9]) Action34 _ expression _ comment? nl Action35)> */
 		func() bool {
-			position76, tokenIndex76 := position, tokenIndex
+			position109, tokenIndex109 := position, tokenIndex
 			{
-				position77 := position
+				position110 := position
 				{
-					position78, tokenIndex78 := position, tokenIndex
-					{
-						position80 := position
-						if buffer[position] != rune('-') {
-							goto l79
-						}
-						position++
-						add(rulePegText, position80)
+					position111 := position
+					if buffer[position] != rune('y') {
+						goto l109
 					}
-					if !_rules[ruleAction30]() {
-						goto l79
+					position++
+					if buffer[position] != rune('i') {
+						goto l109
 					}
-					goto l78
-				l79:
-					position, tokenIndex = position78, tokenIndex78
-					{
-						position82 := position
-						if buffer[position] != rune('+') {
-							goto l81
-						}
-						position++
-						add(rulePegText, position82)
+					position++
+					if buffer[position] != rune('e') {
+						goto l109
 					}
-					if !_rules[ruleAction31]() {
-						goto l81
+					position++
+					if buffer[position] != rune('l') {
+						goto l109
 					}
-					goto l78
-				l81:
-					position, tokenIndex = position78, tokenIndex78
+					position++
+					if buffer[position] != rune('d') {
+						goto l109
+					}
+					position++
+					add(rulePegText, position111)
+				}
+				{
+					position112, tokenIndex112 := position, tokenIndex
 					{
-						position83 := position
-						if buffer[position] != rune('!') {
-							goto l76
+						position113, tokenIndex113 := position, tokenIndex
+						if buffer[position] != rune('_') {
+							goto l114
+						}
+						position++
+						goto l113
+					l114:
+						position, tokenIndex = position113, tokenIndex113
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l115
+						}
+						position++
+						goto l113
+					l115:
+						position, tokenIndex = position113, tokenIndex113
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l116
+						}
+						position++
+						goto l113
+					l116:
+						position, tokenIndex = position113, tokenIndex113
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l112
 						}
 						position++
-						add(rulePegText, position83)
-					}
-					if !_rules[ruleAction32]() {
-						goto l76
 					}
+				l113:
+					goto l109
+				l112:
+					position, tokenIndex = position112, tokenIndex112
 				}
-			l78:
-				if !_rules[rule_]() {
-					goto l76
+				if !_rules[ruleAction34]() {
+					goto l109
 				}
-				if !_rules[rulefactor]() {
-					goto l76
+				if !_rules[rule_]() {
+					goto l109
 				}
-				if !_rules[ruleAction33]() {
-					goto l76
+				if !_rules[ruleexpression]() {
+					goto l109
 				}
-				add(ruleunary, position77)
-			}
-			return true
-		l76:
-			position, tokenIndex = position76, tokenIndex76
-			return false
-		},
-		/* 15 postfix <- <(primary funcall*)> */
-		func() bool {
-			position84, tokenIndex84 := position, tokenIndex
-			{
-				position85 := position
-				if !_rules[ruleprimary]() {
-					goto l84
+				if !_rules[rule_]() {
+					goto l109
 				}
-			l86:
 				{
-					position87, tokenIndex87 := position, tokenIndex
-					if !_rules[rulefuncall]() {
-						goto l87
+					position117, tokenIndex117 := position, tokenIndex
+					if !_rules[rulecomment]() {
+						goto l117
 					}
-					goto l86
-				l87:
-					position, tokenIndex = position87, tokenIndex87
+					goto l118
+				l117:
+					position, tokenIndex = position117, tokenIndex117
+				}
+			l118:
+				if !_rules[rulenl]() {
+					goto l109
 				}
-				add(rulepostfix, position85)
+				if !_rules[ruleAction35]() {
+					goto l109
+				}
+				add(ruleyield, position110)
 			}
 			return true
-		l84:
-			position, tokenIndex = position84, tokenIndex84
+		l109:
+			position, tokenIndex = position109, tokenIndex109
 			return false
 		},
-		/* 16 funcall <- <((_ '(' Action34 sp _ <')'> Action35) / ('(' Action36 sp _ expression (_ ',' sp _ expression)* (_ ',')? sp _ <')'> Action37))> */
+		/* 14 function <- <(<('f' 'u' 'n' 'c')> !('_' / [a-z] / [A-Z] / [0-9]) Action36 _ identifier _ parameters _ block Action37)> */
 		func() bool {
-			position88, tokenIndex88 := position, tokenIndex
+			position119, tokenIndex119 := position, tokenIndex
 			{
-				position89 := position
+				position120 := position
 				{
-					position90, tokenIndex90 := position, tokenIndex
-					if !_rules[rule_]() {
-						goto l91
-					}
-					if buffer[position] != rune('(') {
-						goto l91
+					position121 := position
+					if buffer[position] != rune('f') {
+						goto l119
 					}
 					position++
-					if !_rules[ruleAction34]() {
-						goto l91
+					if buffer[position] != rune('u') {
+						goto l119
 					}
-					if !_rules[rulesp]() {
-						goto l91
+					position++
+					if buffer[position] != rune('n') {
+						goto l119
 					}
-					if !_rules[rule_]() {
-						goto l91
+					position++
+					if buffer[position] != rune('c') {
+						goto l119
 					}
+					position++
+					add(rulePegText, position121)
+				}
+				{
+					position122, tokenIndex122 := position, tokenIndex
 					{
-						position92 := position
-						if buffer[position] != rune(')') {
-							goto l91
+						position123, tokenIndex123 := position, tokenIndex
+						if buffer[position] != rune('_') {
+							goto l124
+						}
+						position++
+						goto l123
+					l124:
+						position, tokenIndex = position123, tokenIndex123
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l125
+						}
+						position++
+						goto l123
+					l125:
+						position, tokenIndex = position123, tokenIndex123
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l126
+						}
+						position++
+						goto l123
+					l126:
+						position, tokenIndex = position123, tokenIndex123
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l122
 						}
 						position++
-						add(rulePegText, position92)
-					}
-					if !_rules[ruleAction35]() {
-						goto l91
-					}
-					goto l90
-				l91:
-					position, tokenIndex = position90, tokenIndex90
-					if buffer[position] != rune('(') {
-						goto l88
-					}
-					position++
-					if !_rules[ruleAction36]() {
-						goto l88
-					}
-					if !_rules[rulesp]() {
-						goto l88
-					}
-					if !_rules[rule_]() {
-						goto l88
 					}
-					if !_rules[ruleexpression]() {
-						goto l88
+				l123:
+					goto l119
+				l122:
+					position, tokenIndex = position122, tokenIndex122
+				}
+				if !_rules[ruleAction36]() {
+					goto l119
+				}
+				if !_rules[rule_]() {
+					goto l119
+				}
+				if !_rules[ruleidentifier]() {
+					goto l119
+				}
+				if !_rules[rule_]() {
+					goto l119
+				}
+				if !_rules[ruleparameters]() {
+					goto l119
+				}
+				if !_rules[rule_]() {
+					goto l119
+				}
+				if !_rules[ruleblock]() {
+					goto l119
+				}
+				if !_rules[ruleAction37]() {
+					goto l119
+				}
+				add(rulefunction, position120)
+			}
+			return true
+		l119:
+			position, tokenIndex = position119, tokenIndex119
+			return false
+		},
+		/* 15 parameters <- <('(' sp _ (parameter (_ ',' sp _ parameter)* (_ ',')?)? sp _ ')' (_ ':' _ typename)?)> */
+		func() bool {
+			position127, tokenIndex127 := position, tokenIndex
+			{
+				position128 := position
+				if buffer[position] != rune('(') {
+					goto l127
+				}
+				position++
+				if !_rules[rulesp]() {
+					goto l127
+				}
+				if !_rules[rule_]() {
+					goto l127
+				}
+				{
+					position129, tokenIndex129 := position, tokenIndex
+					if !_rules[ruleparameter]() {
+						goto l129
 					}
-				l93:
+				l131:
 					{
-						position94, tokenIndex94 := position, tokenIndex
+						position132, tokenIndex132 := position, tokenIndex
 						if !_rules[rule_]() {
-							goto l94
+							goto l132
 						}
 						if buffer[position] != rune(',') {
-							goto l94
+							goto l132
 						}
 						position++
 						if !_rules[rulesp]() {
-							goto l94
+							goto l132
 						}
 						if !_rules[rule_]() {
-							goto l94
+							goto l132
 						}
-						if !_rules[ruleexpression]() {
-							goto l94
+						if !_rules[ruleparameter]() {
+							goto l132
 						}
-						goto l93
-					l94:
-						position, tokenIndex = position94, tokenIndex94
+						goto l131
+					l132:
+						position, tokenIndex = position132, tokenIndex132
+					}
+					{
+						position133, tokenIndex133 := position, tokenIndex
+						if !_rules[rule_]() {
+							goto l133
+						}
+						if buffer[position] != rune(',') {
+							goto l133
+						}
+						position++
+						goto l134
+					l133:
+						position, tokenIndex = position133, tokenIndex133
+					}
+				l134:
+					goto l130
+				l129:
+					position, tokenIndex = position129, tokenIndex129
+				}
+			l130:
+				if !_rules[rulesp]() {
+					goto l127
+				}
+				if !_rules[rule_]() {
+					goto l127
+				}
+				if buffer[position] != rune(')') {
+					goto l127
+				}
+				position++
+				{
+					position135, tokenIndex135 := position, tokenIndex
+					if !_rules[rule_]() {
+						goto l135
+					}
+					if buffer[position] != rune(':') {
+						goto l135
+					}
+					position++
+					if !_rules[rule_]() {
+						goto l135
+					}
+					if !_rules[ruletypename]() {
+						goto l135
+					}
+					goto l136
+				l135:
+					position, tokenIndex = position135, tokenIndex135
+				}
+			l136:
+				add(ruleparameters, position128)
+			}
+			return true
+		l127:
+			position, tokenIndex = position127, tokenIndex127
+			return false
+		},
+		/* 16 parameter <- <(identifier (_ ':' _ typename)? Action38)> */
+		func() bool {
+			position137, tokenIndex137 := position, tokenIndex
+			{
+				position138 := position
+				if !_rules[ruleidentifier]() {
+					goto l137
+				}
+				{
+					position139, tokenIndex139 := position, tokenIndex
+					if !_rules[rule_]() {
+						goto l139
+					}
+					if buffer[position] != rune(':') {
+						goto l139
+					}
+					position++
+					if !_rules[rule_]() {
+						goto l139
+					}
+					if !_rules[ruletypename]() {
+						goto l139
+					}
+					goto l140
+				l139:
+					position, tokenIndex = position139, tokenIndex139
+				}
+			l140:
+				if !_rules[ruleAction38]() {
+					goto l137
+				}
+				add(ruleparameter, position138)
+			}
+			return true
+		l137:
+			position, tokenIndex = position137, tokenIndex137
+			return false
+		},
+		/* 17 typename <- <(<(('_' / [a-z] / [A-Z]) ('_' / [a-z] / [A-Z] / [0-9])*)> Action39)> */
+		func() bool {
+			position141, tokenIndex141 := position, tokenIndex
+			{
+				position142 := position
+				{
+					position143 := position
+					{
+						position144, tokenIndex144 := position, tokenIndex
+						if buffer[position] != rune('_') {
+							goto l145
+						}
+						position++
+						goto l144
+					l145:
+						position, tokenIndex = position144, tokenIndex144
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l146
+						}
+						position++
+						goto l144
+					l146:
+						position, tokenIndex = position144, tokenIndex144
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l141
+						}
+						position++
+					}
+				l144:
+				l147:
+					{
+						position148, tokenIndex148 := position, tokenIndex
+						{
+							position149, tokenIndex149 := position, tokenIndex
+							if buffer[position] != rune('_') {
+								goto l150
+							}
+							position++
+							goto l149
+						l150:
+							position, tokenIndex = position149, tokenIndex149
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l151
+							}
+							position++
+							goto l149
+						l151:
+							position, tokenIndex = position149, tokenIndex149
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l152
+							}
+							position++
+							goto l149
+						l152:
+							position, tokenIndex = position149, tokenIndex149
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l148
+							}
+							position++
+						}
+					l149:
+						goto l147
+					l148:
+						position, tokenIndex = position148, tokenIndex148
+					}
+					add(rulePegText, position143)
+				}
+				if !_rules[ruleAction39]() {
+					goto l141
+				}
+				add(ruletypename, position142)
+			}
+			return true
+		l141:
+			position, tokenIndex = position141, tokenIndex141
+			return false
+		},
+		/* 18 struct <- <(<('s' 't' 'r' 'u' 'c' 't')> !('_' / [a-z] / [A-Z] / [0-9]) Action40 _ identifier _ '{' sp _ (identifier (_ ',' sp _ identifier)* (_ ',')?)? sp _ <'}'> Action41)> */
+		func() bool {
+			position153, tokenIndex153 := position, tokenIndex
+			{
+				position154 := position
+				{
+					position155 := position
+					if buffer[position] != rune('s') {
+						goto l153
+					}
+					position++
+					if buffer[position] != rune('t') {
+						goto l153
+					}
+					position++
+					if buffer[position] != rune('r') {
+						goto l153
+					}
+					position++
+					if buffer[position] != rune('u') {
+						goto l153
+					}
+					position++
+					if buffer[position] != rune('c') {
+						goto l153
+					}
+					position++
+					if buffer[position] != rune('t') {
+						goto l153
+					}
+					position++
+					add(rulePegText, position155)
+				}
+				{
+					position156, tokenIndex156 := position, tokenIndex
+					{
+						position157, tokenIndex157 := position, tokenIndex
+						if buffer[position] != rune('_') {
+							goto l158
+						}
+						position++
+						goto l157
+					l158:
+						position, tokenIndex = position157, tokenIndex157
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l159
+						}
+						position++
+						goto l157
+					l159:
+						position, tokenIndex = position157, tokenIndex157
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l160
+						}
+						position++
+						goto l157
+					l160:
+						position, tokenIndex = position157, tokenIndex157
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l156
+						}
+						position++
+					}
+				l157:
+					goto l153
+				l156:
+					position, tokenIndex = position156, tokenIndex156
+				}
+				if !_rules[ruleAction40]() {
+					goto l153
+				}
+				if !_rules[rule_]() {
+					goto l153
+				}
+				if !_rules[ruleidentifier]() {
+					goto l153
+				}
+				if !_rules[rule_]() {
+					goto l153
+				}
+				if buffer[position] != rune('{') {
+					goto l153
+				}
+				position++
+				if !_rules[rulesp]() {
+					goto l153
+				}
+				if !_rules[rule_]() {
+					goto l153
+				}
+				{
+					position161, tokenIndex161 := position, tokenIndex
+					if !_rules[ruleidentifier]() {
+						goto l161
+					}
+				l163:
+					{
+						position164, tokenIndex164 := position, tokenIndex
+						if !_rules[rule_]() {
+							goto l164
+						}
+						if buffer[position] != rune(',') {
+							goto l164
+						}
+						position++
+						if !_rules[rulesp]() {
+							goto l164
+						}
+						if !_rules[rule_]() {
+							goto l164
+						}
+						if !_rules[ruleidentifier]() {
+							goto l164
+						}
+						goto l163
+					l164:
+						position, tokenIndex = position164, tokenIndex164
+					}
+					{
+						position165, tokenIndex165 := position, tokenIndex
+						if !_rules[rule_]() {
+							goto l165
+						}
+						if buffer[position] != rune(',') {
+							goto l165
+						}
+						position++
+						goto l166
+					l165:
+						position, tokenIndex = position165, tokenIndex165
+					}
+				l166:
+					goto l162
+				l161:
+					position, tokenIndex = position161, tokenIndex161
+				}
+			l162:
+				if !_rules[rulesp]() {
+					goto l153
+				}
+				if !_rules[rule_]() {
+					goto l153
+				}
+				{
+					position167 := position
+					if buffer[position] != rune('}') {
+						goto l153
+					}
+					position++
+					add(rulePegText, position167)
+				}
+				if !_rules[ruleAction41]() {
+					goto l153
+				}
+				add(rulestruct, position154)
+			}
+			return true
+		l153:
+			position, tokenIndex = position153, tokenIndex153
+			return false
+		},
+		/* 19 class <- <(<('c' 'l' 'a' 's' 's')> !('_' / [a-z] / [A-Z] / [0-9]) Action42 _ identifier _ block Action43)> */
+		func() bool {
+			position168, tokenIndex168 := position, tokenIndex
+			{
+				position169 := position
+				{
+					position170 := position
+					if buffer[position] != rune('c') {
+						goto l168
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l168
+					}
+					position++
+					if buffer[position] != rune('a') {
+						goto l168
+					}
+					position++
+					if buffer[position] != rune('s') {
+						goto l168
+					}
+					position++
+					if buffer[position] != rune('s') {
+						goto l168
+					}
+					position++
+					add(rulePegText, position170)
+				}
+				{
+					position171, tokenIndex171 := position, tokenIndex
+					{
+						position172, tokenIndex172 := position, tokenIndex
+						if buffer[position] != rune('_') {
+							goto l173
+						}
+						position++
+						goto l172
+					l173:
+						position, tokenIndex = position172, tokenIndex172
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l174
+						}
+						position++
+						goto l172
+					l174:
+						position, tokenIndex = position172, tokenIndex172
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l175
+						}
+						position++
+						goto l172
+					l175:
+						position, tokenIndex = position172, tokenIndex172
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l171
+						}
+						position++
+					}
+				l172:
+					goto l168
+				l171:
+					position, tokenIndex = position171, tokenIndex171
+				}
+				if !_rules[ruleAction42]() {
+					goto l168
+				}
+				if !_rules[rule_]() {
+					goto l168
+				}
+				if !_rules[ruleidentifier]() {
+					goto l168
+				}
+				if !_rules[rule_]() {
+					goto l168
+				}
+				if !_rules[ruleblock]() {
+					goto l168
+				}
+				if !_rules[ruleAction43]() {
+					goto l168
+				}
+				add(ruleclass, position169)
+			}
+			return true
+		l168:
+			position, tokenIndex = position168, tokenIndex168
+			return false
+		},
+		/* 20 import <- <(<('i' 'm' 'p' 'o' 'r' 't')> Action44 _ string _ comment? nl Action45)> */
+		func() bool {
+			position176, tokenIndex176 := position, tokenIndex
+			{
+				position177 := position
+				{
+					position178 := position
+					if buffer[position] != rune('i') {
+						goto l176
+					}
+					position++
+					if buffer[position] != rune('m') {
+						goto l176
+					}
+					position++
+					if buffer[position] != rune('p') {
+						goto l176
+					}
+					position++
+					if buffer[position] != rune('o') {
+						goto l176
+					}
+					position++
+					if buffer[position] != rune('r') {
+						goto l176
+					}
+					position++
+					if buffer[position] != rune('t') {
+						goto l176
+					}
+					position++
+					add(rulePegText, position178)
+				}
+				if !_rules[ruleAction44]() {
+					goto l176
+				}
+				if !_rules[rule_]() {
+					goto l176
+				}
+				if !_rules[rulestring]() {
+					goto l176
+				}
+				if !_rules[rule_]() {
+					goto l176
+				}
+				{
+					position179, tokenIndex179 := position, tokenIndex
+					if !_rules[rulecomment]() {
+						goto l179
+					}
+					goto l180
+				l179:
+					position, tokenIndex = position179, tokenIndex179
+				}
+			l180:
+				if !_rules[rulenl]() {
+					goto l176
+				}
+				if !_rules[ruleAction45]() {
+					goto l176
+				}
+				add(ruleimport, position177)
+			}
+			return true
+		l176:
+			position, tokenIndex = position176, tokenIndex176
+			return false
+		},
+		/* 21 expression <- <assign> */
+		func() bool {
+			position181, tokenIndex181 := position, tokenIndex
+			{
+				position182 := position
+				if !_rules[ruleassign]() {
+					goto l181
+				}
+				add(ruleexpression, position182)
+			}
+			return true
+		l181:
+			position, tokenIndex = position181, tokenIndex181
+			return false
+		},
+		/* 22 assign <- <((identifier _ ':' _ typename _ '=' _ expression Action46) / (equality ((_ '=' !'=' _ expression Action47) / (_ ('+' '=') _ expression Action48) / (_ ('-' '=') _ expression Action49) / (_ ('*' '=') _ expression Action50) / (_ ('/' '=') _ expression Action51) / (_ ('%' '=') _ expression Action52))?))> */
+		func() bool {
+			position183, tokenIndex183 := position, tokenIndex
+			{
+				position184 := position
+				{
+					position185, tokenIndex185 := position, tokenIndex
+					if !_rules[ruleidentifier]() {
+						goto l186
+					}
+					if !_rules[rule_]() {
+						goto l186
+					}
+					if buffer[position] != rune(':') {
+						goto l186
+					}
+					position++
+					if !_rules[rule_]() {
+						goto l186
+					}
+					if !_rules[ruletypename]() {
+						goto l186
+					}
+					if !_rules[rule_]() {
+						goto l186
+					}
+					if buffer[position] != rune('=') {
+						goto l186
+					}
+					position++
+					if !_rules[rule_]() {
+						goto l186
+					}
+					if !_rules[ruleexpression]() {
+						goto l186
+					}
+					if !_rules[ruleAction46]() {
+						goto l186
+					}
+					goto l185
+				l186:
+					position, tokenIndex = position185, tokenIndex185
+					if !_rules[ruleequality]() {
+						goto l183
+					}
+					{
+						position187, tokenIndex187 := position, tokenIndex
+						{
+							position189, tokenIndex189 := position, tokenIndex
+							if !_rules[rule_]() {
+								goto l190
+							}
+							if buffer[position] != rune('=') {
+								goto l190
+							}
+							position++
+							{
+								position191, tokenIndex191 := position, tokenIndex
+								if buffer[position] != rune('=') {
+									goto l191
+								}
+								position++
+								goto l190
+							l191:
+								position, tokenIndex = position191, tokenIndex191
+							}
+							if !_rules[rule_]() {
+								goto l190
+							}
+							if !_rules[ruleexpression]() {
+								goto l190
+							}
+							if !_rules[ruleAction47]() {
+								goto l190
+							}
+							goto l189
+						l190:
+							position, tokenIndex = position189, tokenIndex189
+							if !_rules[rule_]() {
+								goto l192
+							}
+							if buffer[position] != rune('+') {
+								goto l192
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l192
+							}
+							position++
+							if !_rules[rule_]() {
+								goto l192
+							}
+							if !_rules[ruleexpression]() {
+								goto l192
+							}
+							if !_rules[ruleAction48]() {
+								goto l192
+							}
+							goto l189
+						l192:
+							position, tokenIndex = position189, tokenIndex189
+							if !_rules[rule_]() {
+								goto l193
+							}
+							if buffer[position] != rune('-') {
+								goto l193
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l193
+							}
+							position++
+							if !_rules[rule_]() {
+								goto l193
+							}
+							if !_rules[ruleexpression]() {
+								goto l193
+							}
+							if !_rules[ruleAction49]() {
+								goto l193
+							}
+							goto l189
+						l193:
+							position, tokenIndex = position189, tokenIndex189
+							if !_rules[rule_]() {
+								goto l194
+							}
+							if buffer[position] != rune('*') {
+								goto l194
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l194
+							}
+							position++
+							if !_rules[rule_]() {
+								goto l194
+							}
+							if !_rules[ruleexpression]() {
+								goto l194
+							}
+							if !_rules[ruleAction50]() {
+								goto l194
+							}
+							goto l189
+						l194:
+							position, tokenIndex = position189, tokenIndex189
+							if !_rules[rule_]() {
+								goto l195
+							}
+							if buffer[position] != rune('/') {
+								goto l195
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l195
+							}
+							position++
+							if !_rules[rule_]() {
+								goto l195
+							}
+							if !_rules[ruleexpression]() {
+								goto l195
+							}
+							if !_rules[ruleAction51]() {
+								goto l195
+							}
+							goto l189
+						l195:
+							position, tokenIndex = position189, tokenIndex189
+							if !_rules[rule_]() {
+								goto l187
+							}
+							if buffer[position] != rune('%') {
+								goto l187
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l187
+							}
+							position++
+							if !_rules[rule_]() {
+								goto l187
+							}
+							if !_rules[ruleexpression]() {
+								goto l187
+							}
+							if !_rules[ruleAction52]() {
+								goto l187
+							}
+						}
+					l189:
+						goto l188
+					l187:
+						position, tokenIndex = position187, tokenIndex187
+					}
+				l188:
+				}
+			l185:
+				add(ruleassign, position184)
+			}
+			return true
+		l183:
+			position, tokenIndex = position183, tokenIndex183
+			return false
+		},
+		/* 23 equality <- <(compare ((_ ('=' '=') _ compare Action53) / (_ ('!' '=') _ compare Action54))*)> */
+		func() bool {
+			position196, tokenIndex196 := position, tokenIndex
+			{
+				position197 := position
+				if !_rules[rulecompare]() {
+					goto l196
+				}
+			l198:
+				{
+					position199, tokenIndex199 := position, tokenIndex
+					{
+						position200, tokenIndex200 := position, tokenIndex
+						if !_rules[rule_]() {
+							goto l201
+						}
+						if buffer[position] != rune('=') {
+							goto l201
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l201
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l201
+						}
+						if !_rules[rulecompare]() {
+							goto l201
+						}
+						if !_rules[ruleAction53]() {
+							goto l201
+						}
+						goto l200
+					l201:
+						position, tokenIndex = position200, tokenIndex200
+						if !_rules[rule_]() {
+							goto l199
+						}
+						if buffer[position] != rune('!') {
+							goto l199
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l199
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l199
+						}
+						if !_rules[rulecompare]() {
+							goto l199
+						}
+						if !_rules[ruleAction54]() {
+							goto l199
+						}
+					}
+				l200:
+					goto l198
+				l199:
+					position, tokenIndex = position199, tokenIndex199
+				}
+				add(ruleequality, position197)
+			}
+			return true
+		l196:
+			position, tokenIndex = position196, tokenIndex196
+			return false
+		},
+		/* 24 compare <- <(range ((_ ('<' '=') _ range Action55) / (_ ('>' '=') _ range Action56) / (_ '<' _ range Action57) / (_ '>' _ range Action58) / (_ ('i' 'n') !('_' / [a-z] / [A-Z] / [0-9]) _ range Action59))*)> */
+		func() bool {
+			position202, tokenIndex202 := position, tokenIndex
+			{
+				position203 := position
+				if !_rules[rulerange]() {
+					goto l202
+				}
+			l204:
+				{
+					position205, tokenIndex205 := position, tokenIndex
+					{
+						position206, tokenIndex206 := position, tokenIndex
+						if !_rules[rule_]() {
+							goto l207
+						}
+						if buffer[position] != rune('<') {
+							goto l207
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l207
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l207
+						}
+						if !_rules[rulerange]() {
+							goto l207
+						}
+						if !_rules[ruleAction55]() {
+							goto l207
+						}
+						goto l206
+					l207:
+						position, tokenIndex = position206, tokenIndex206
+						if !_rules[rule_]() {
+							goto l208
+						}
+						if buffer[position] != rune('>') {
+							goto l208
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l208
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l208
+						}
+						if !_rules[rulerange]() {
+							goto l208
+						}
+						if !_rules[ruleAction56]() {
+							goto l208
+						}
+						goto l206
+					l208:
+						position, tokenIndex = position206, tokenIndex206
+						if !_rules[rule_]() {
+							goto l209
+						}
+						if buffer[position] != rune('<') {
+							goto l209
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l209
+						}
+						if !_rules[rulerange]() {
+							goto l209
+						}
+						if !_rules[ruleAction57]() {
+							goto l209
+						}
+						goto l206
+					l209:
+						position, tokenIndex = position206, tokenIndex206
+						if !_rules[rule_]() {
+							goto l210
+						}
+						if buffer[position] != rune('>') {
+							goto l210
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l210
+						}
+						if !_rules[rulerange]() {
+							goto l210
+						}
+						if !_rules[ruleAction58]() {
+							goto l210
+						}
+						goto l206
+					l210:
+						position, tokenIndex = position206, tokenIndex206
+						if !_rules[rule_]() {
+							goto l205
+						}
+						if buffer[position] != rune('i') {
+							goto l205
+						}
+						position++
+						if buffer[position] != rune('n') {
+							goto l205
+						}
+						position++
+						{
+							position211, tokenIndex211 := position, tokenIndex
+							{
+								position212, tokenIndex212 := position, tokenIndex
+								if buffer[position] != rune('_') {
+									goto l213
+								}
+								position++
+								goto l212
+							l213:
+								position, tokenIndex = position212, tokenIndex212
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l214
+								}
+								position++
+								goto l212
+							l214:
+								position, tokenIndex = position212, tokenIndex212
+								if c := buffer[position]; c < rune('A') || c > rune('Z') {
+									goto l215
+								}
+								position++
+								goto l212
+							l215:
+								position, tokenIndex = position212, tokenIndex212
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l211
+								}
+								position++
+							}
+						l212:
+							goto l205
+						l211:
+							position, tokenIndex = position211, tokenIndex211
+						}
+						if !_rules[rule_]() {
+							goto l205
+						}
+						if !_rules[rulerange]() {
+							goto l205
+						}
+						if !_rules[ruleAction59]() {
+							goto l205
+						}
+					}
+				l206:
+					goto l204
+				l205:
+					position, tokenIndex = position205, tokenIndex205
+				}
+				add(rulecompare, position203)
+			}
+			return true
+		l202:
+			position, tokenIndex = position202, tokenIndex202
+			return false
+		},
+		/* 25 range <- <(additive ((_ ('.' '.' '.') _ additive Action60) / (_ ('.' '.') _ additive Action61))?)> */
+		func() bool {
+			position216, tokenIndex216 := position, tokenIndex
+			{
+				position217 := position
+				if !_rules[ruleadditive]() {
+					goto l216
+				}
+				{
+					position218, tokenIndex218 := position, tokenIndex
+					{
+						position220, tokenIndex220 := position, tokenIndex
+						if !_rules[rule_]() {
+							goto l221
+						}
+						if buffer[position] != rune('.') {
+							goto l221
+						}
+						position++
+						if buffer[position] != rune('.') {
+							goto l221
+						}
+						position++
+						if buffer[position] != rune('.') {
+							goto l221
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l221
+						}
+						if !_rules[ruleadditive]() {
+							goto l221
+						}
+						if !_rules[ruleAction60]() {
+							goto l221
+						}
+						goto l220
+					l221:
+						position, tokenIndex = position220, tokenIndex220
+						if !_rules[rule_]() {
+							goto l218
+						}
+						if buffer[position] != rune('.') {
+							goto l218
+						}
+						position++
+						if buffer[position] != rune('.') {
+							goto l218
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l218
+						}
+						if !_rules[ruleadditive]() {
+							goto l218
+						}
+						if !_rules[ruleAction61]() {
+							goto l218
+						}
+					}
+				l220:
+					goto l219
+				l218:
+					position, tokenIndex = position218, tokenIndex218
+				}
+			l219:
+				add(rulerange, position217)
+			}
+			return true
+		l216:
+			position, tokenIndex = position216, tokenIndex216
+			return false
+		},
+		/* 26 additive <- <(multitive ((_ '+' _ multitive Action62) / (_ '-' _ multitive Action63))*)> */
+		func() bool {
+			position222, tokenIndex222 := position, tokenIndex
+			{
+				position223 := position
+				if !_rules[rulemultitive]() {
+					goto l222
+				}
+			l224:
+				{
+					position225, tokenIndex225 := position, tokenIndex
+					{
+						position226, tokenIndex226 := position, tokenIndex
+						if !_rules[rule_]() {
+							goto l227
+						}
+						if buffer[position] != rune('+') {
+							goto l227
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l227
+						}
+						if !_rules[rulemultitive]() {
+							goto l227
+						}
+						if !_rules[ruleAction62]() {
+							goto l227
+						}
+						goto l226
+					l227:
+						position, tokenIndex = position226, tokenIndex226
+						if !_rules[rule_]() {
+							goto l225
+						}
+						if buffer[position] != rune('-') {
+							goto l225
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l225
+						}
+						if !_rules[rulemultitive]() {
+							goto l225
+						}
+						if !_rules[ruleAction63]() {
+							goto l225
+						}
+					}
+				l226:
+					goto l224
+				l225:
+					position, tokenIndex = position225, tokenIndex225
+				}
+				add(ruleadditive, position223)
+			}
+			return true
+		l222:
+			position, tokenIndex = position222, tokenIndex222
+			return false
+		},
+		/* 27 multitive <- <(factor ((_ '*' _ factor Action64) / (_ '/' _ factor Action65) / (_ '%' _ factor Action66))*)> */
+		func() bool {
+			position228, tokenIndex228 := position, tokenIndex
+			{
+				position229 := position
+				if !_rules[rulefactor]() {
+					goto l228
+				}
+			l230:
+				{
+					position231, tokenIndex231 := position, tokenIndex
+					{
+						position232, tokenIndex232 := position, tokenIndex
+						if !_rules[rule_]() {
+							goto l233
+						}
+						if buffer[position] != rune('*') {
+							goto l233
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l233
+						}
+						if !_rules[rulefactor]() {
+							goto l233
+						}
+						if !_rules[ruleAction64]() {
+							goto l233
+						}
+						goto l232
+					l233:
+						position, tokenIndex = position232, tokenIndex232
+						if !_rules[rule_]() {
+							goto l234
+						}
+						if buffer[position] != rune('/') {
+							goto l234
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l234
+						}
+						if !_rules[rulefactor]() {
+							goto l234
+						}
+						if !_rules[ruleAction65]() {
+							goto l234
+						}
+						goto l232
+					l234:
+						position, tokenIndex = position232, tokenIndex232
+						if !_rules[rule_]() {
+							goto l231
+						}
+						if buffer[position] != rune('%') {
+							goto l231
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l231
+						}
+						if !_rules[rulefactor]() {
+							goto l231
+						}
+						if !_rules[ruleAction66]() {
+							goto l231
+						}
+					}
+				l232:
+					goto l230
+				l231:
+					position, tokenIndex = position231, tokenIndex231
+				}
+				add(rulemultitive, position229)
+			}
+			return true
+		l228:
+			position, tokenIndex = position228, tokenIndex228
+			return false
+		},
+		/* 28 factor <- <(unary / postfix)> */
+		func() bool {
+			position235, tokenIndex235 := position, tokenIndex
+			{
+				position236 := position
+				{
+					position237, tokenIndex237 := position, tokenIndex
+					if !_rules[ruleunary]() {
+						goto l238
+					}
+					goto l237
+				l238:
+					position, tokenIndex = position237, tokenIndex237
+					if !_rules[rulepostfix]() {
+						goto l235
+					}
+				}
+			l237:
+				add(rulefactor, position236)
+			}
+			return true
+		l235:
+			position, tokenIndex = position235, tokenIndex235
+			return false
+		},
+		/* 29 unary <- <(((<'-'> Action67) / (<'+'> Action68) / (<'!'> Action69)) _ factor Action70)> */
+		func() bool {
+			position239, tokenIndex239 := position, tokenIndex
+			{
+				position240 := position
+				{
+					position241, tokenIndex241 := position, tokenIndex
+					{
+						position243 := position
+						if buffer[position] != rune('-') {
+							goto l242
+						}
+						position++
+						add(rulePegText, position243)
+					}
+					if !_rules[ruleAction67]() {
+						goto l242
+					}
+					goto l241
+				l242:
+					position, tokenIndex = position241, tokenIndex241
+					{
+						position245 := position
+						if buffer[position] != rune('+') {
+							goto l244
+						}
+						position++
+						add(rulePegText, position245)
+					}
+					if !_rules[ruleAction68]() {
+						goto l244
+					}
+					goto l241
+				l244:
+					position, tokenIndex = position241, tokenIndex241
+					{
+						position246 := position
+						if buffer[position] != rune('!') {
+							goto l239
+						}
+						position++
+						add(rulePegText, position246)
+					}
+					if !_rules[ruleAction69]() {
+						goto l239
+					}
+				}
+			l241:
+				if !_rules[rule_]() {
+					goto l239
+				}
+				if !_rules[rulefactor]() {
+					goto l239
+				}
+				if !_rules[ruleAction70]() {
+					goto l239
+				}
+				add(ruleunary, position240)
+			}
+			return true
+		l239:
+			position, tokenIndex = position239, tokenIndex239
+			return false
+		},
+		/* 30 postfix <- <(primary (funcall / attribute / index)*)> */
+		func() bool {
+			position247, tokenIndex247 := position, tokenIndex
+			{
+				position248 := position
+				if !_rules[ruleprimary]() {
+					goto l247
+				}
+			l249:
+				{
+					position250, tokenIndex250 := position, tokenIndex
+					{
+						position251, tokenIndex251 := position, tokenIndex
+						if !_rules[rulefuncall]() {
+							goto l252
+						}
+						goto l251
+					l252:
+						position, tokenIndex = position251, tokenIndex251
+						if !_rules[ruleattribute]() {
+							goto l253
+						}
+						goto l251
+					l253:
+						position, tokenIndex = position251, tokenIndex251
+						if !_rules[ruleindex]() {
+							goto l250
+						}
+					}
+				l251:
+					goto l249
+				l250:
+					position, tokenIndex = position250, tokenIndex250
+				}
+				add(rulepostfix, position248)
+			}
+			return true
+		l247:
+			position, tokenIndex = position247, tokenIndex247
+			return false
+		},
+		/* 31 attribute <- <('.' identifier Action71)> */
+		func() bool {
+			position254, tokenIndex254 := position, tokenIndex
+			{
+				position255 := position
+				if buffer[position] != rune('.') {
+					goto l254
+				}
+				position++
+				if !_rules[ruleidentifier]() {
+					goto l254
+				}
+				if !_rules[ruleAction71]() {
+					goto l254
+				}
+				add(ruleattribute, position255)
+			}
+			return true
+		l254:
+			position, tokenIndex = position254, tokenIndex254
+			return false
+		},
+		/* 32 index <- <('[' sp _ expression sp _ <']'> Action72)> */
+		func() bool {
+			position256, tokenIndex256 := position, tokenIndex
+			{
+				position257 := position
+				if buffer[position] != rune('[') {
+					goto l256
+				}
+				position++
+				if !_rules[rulesp]() {
+					goto l256
+				}
+				if !_rules[rule_]() {
+					goto l256
+				}
+				if !_rules[ruleexpression]() {
+					goto l256
+				}
+				if !_rules[rulesp]() {
+					goto l256
+				}
+				if !_rules[rule_]() {
+					goto l256
+				}
+				{
+					position258 := position
+					if buffer[position] != rune(']') {
+						goto l256
+					}
+					position++
+					add(rulePegText, position258)
+				}
+				if !_rules[ruleAction72]() {
+					goto l256
+				}
+				add(ruleindex, position257)
+			}
+			return true
+		l256:
+			position, tokenIndex = position256, tokenIndex256
+			return false
+		},
+		/* 33 funcall <- <((_ '(' Action73 sp _ <')'> Action74) / ('(' Action75 sp _ expression (_ ',' sp _ expression)* (_ ',')? sp _ <')'> Action76))> */
+		func() bool {
+			position259, tokenIndex259 := position, tokenIndex
+			{
+				position260 := position
+				{
+					position261, tokenIndex261 := position, tokenIndex
+					if !_rules[rule_]() {
+						goto l262
+					}
+					if buffer[position] != rune('(') {
+						goto l262
+					}
+					position++
+					if !_rules[ruleAction73]() {
+						goto l262
+					}
+					if !_rules[rulesp]() {
+						goto l262
+					}
+					if !_rules[rule_]() {
+						goto l262
+					}
+					{
+						position263 := position
+						if buffer[position] != rune(')') {
+							goto l262
+						}
+						position++
+						add(rulePegText, position263)
+					}
+					if !_rules[ruleAction74]() {
+						goto l262
+					}
+					goto l261
+				l262:
+					position, tokenIndex = position261, tokenIndex261
+					if buffer[position] != rune('(') {
+						goto l259
+					}
+					position++
+					if !_rules[ruleAction75]() {
+						goto l259
+					}
+					if !_rules[rulesp]() {
+						goto l259
+					}
+					if !_rules[rule_]() {
+						goto l259
+					}
+					if !_rules[ruleexpression]() {
+						goto l259
+					}
+				l264:
+					{
+						position265, tokenIndex265 := position, tokenIndex
+						if !_rules[rule_]() {
+							goto l265
+						}
+						if buffer[position] != rune(',') {
+							goto l265
+						}
+						position++
+						if !_rules[rulesp]() {
+							goto l265
+						}
+						if !_rules[rule_]() {
+							goto l265
+						}
+						if !_rules[ruleexpression]() {
+							goto l265
+						}
+						goto l264
+					l265:
+						position, tokenIndex = position265, tokenIndex265
+					}
+					{
+						position266, tokenIndex266 := position, tokenIndex
+						if !_rules[rule_]() {
+							goto l266
+						}
+						if buffer[position] != rune(',') {
+							goto l266
+						}
+						position++
+						goto l267
+					l266:
+						position, tokenIndex = position266, tokenIndex266
+					}
+				l267:
+					if !_rules[rulesp]() {
+						goto l259
+					}
+					if !_rules[rule_]() {
+						goto l259
+					}
+					{
+						position268 := position
+						if buffer[position] != rune(')') {
+							goto l259
+						}
+						position++
+						add(rulePegText, position268)
+					}
+					if !_rules[ruleAction76]() {
+						goto l259
+					}
+				}
+			l261:
+				add(rulefuncall, position260)
+			}
+			return true
+		l259:
+			position, tokenIndex = position259, tokenIndex259
+			return false
+		},
+		/* 34 primary <- <(('(' _ sp _ expression _ sp _ ')') / ('t' 'r' 'u' 'e' Action77) / ('f' 'a' 'l' 's' 'e' Action78) / float / integer / string / list / (<('s' 'p' 'a' 'w' 'n')> !('_' / [a-z] / [A-Z] / [0-9]) Action79 _ postfix Action80) / (<('f' 'u' 'n' 'c')> Action81 _ parameters _ block Action82) / identifier)> */
+		func() bool {
+			position269, tokenIndex269 := position, tokenIndex
+			{
+				position270 := position
+				{
+					position271, tokenIndex271 := position, tokenIndex
+					if buffer[position] != rune('(') {
+						goto l272
+					}
+					position++
+					if !_rules[rule_]() {
+						goto l272
+					}
+					if !_rules[rulesp]() {
+						goto l272
+					}
+					if !_rules[rule_]() {
+						goto l272
+					}
+					if !_rules[ruleexpression]() {
+						goto l272
+					}
+					if !_rules[rule_]() {
+						goto l272
+					}
+					if !_rules[rulesp]() {
+						goto l272
+					}
+					if !_rules[rule_]() {
+						goto l272
+					}
+					if buffer[position] != rune(')') {
+						goto l272
+					}
+					position++
+					goto l271
+				l272:
+					position, tokenIndex = position271, tokenIndex271
+					if buffer[position] != rune('t') {
+						goto l273
+					}
+					position++
+					if buffer[position] != rune('r') {
+						goto l273
+					}
+					position++
+					if buffer[position] != rune('u') {
+						goto l273
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l273
+					}
+					position++
+					if !_rules[ruleAction77]() {
+						goto l273
+					}
+					goto l271
+				l273:
+					position, tokenIndex = position271, tokenIndex271
+					if buffer[position] != rune('f') {
+						goto l274
+					}
+					position++
+					if buffer[position] != rune('a') {
+						goto l274
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l274
+					}
+					position++
+					if buffer[position] != rune('s') {
+						goto l274
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l274
+					}
+					position++
+					if !_rules[ruleAction78]() {
+						goto l274
+					}
+					goto l271
+				l274:
+					position, tokenIndex = position271, tokenIndex271
+					if !_rules[rulefloat]() {
+						goto l275
+					}
+					goto l271
+				l275:
+					position, tokenIndex = position271, tokenIndex271
+					if !_rules[ruleinteger]() {
+						goto l276
+					}
+					goto l271
+				l276:
+					position, tokenIndex = position271, tokenIndex271
+					if !_rules[rulestring]() {
+						goto l277
+					}
+					goto l271
+				l277:
+					position, tokenIndex = position271, tokenIndex271
+					if !_rules[rulelist]() {
+						goto l278
+					}
+					goto l271
+				l278:
+					position, tokenIndex = position271, tokenIndex271
+					{
+						position280 := position
+						if buffer[position] != rune('s') {
+							goto l279
+						}
+						position++
+						if buffer[position] != rune('p') {
+							goto l279
+						}
+						position++
+						if buffer[position] != rune('a') {
+							goto l279
+						}
+						position++
+						if buffer[position] != rune('w') {
+							goto l279
+						}
+						position++
+						if buffer[position] != rune('n') {
+							goto l279
+						}
+						position++
+						add(rulePegText, position280)
+					}
+					{
+						position281, tokenIndex281 := position, tokenIndex
+						{
+							position282, tokenIndex282 := position, tokenIndex
+							if buffer[position] != rune('_') {
+								goto l283
+							}
+							position++
+							goto l282
+						l283:
+							position, tokenIndex = position282, tokenIndex282
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l284
+							}
+							position++
+							goto l282
+						l284:
+							position, tokenIndex = position282, tokenIndex282
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l285
+							}
+							position++
+							goto l282
+						l285:
+							position, tokenIndex = position282, tokenIndex282
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l281
+							}
+							position++
+						}
+					l282:
+						goto l279
+					l281:
+						position, tokenIndex = position281, tokenIndex281
+					}
+					if !_rules[ruleAction79]() {
+						goto l279
+					}
+					if !_rules[rule_]() {
+						goto l279
+					}
+					if !_rules[rulepostfix]() {
+						goto l279
+					}
+					if !_rules[ruleAction80]() {
+						goto l279
+					}
+					goto l271
+				l279:
+					position, tokenIndex = position271, tokenIndex271
+					{
+						position287 := position
+						if buffer[position] != rune('f') {
+							goto l286
+						}
+						position++
+						if buffer[position] != rune('u') {
+							goto l286
+						}
+						position++
+						if buffer[position] != rune('n') {
+							goto l286
+						}
+						position++
+						if buffer[position] != rune('c') {
+							goto l286
+						}
+						position++
+						add(rulePegText, position287)
+					}
+					if !_rules[ruleAction81]() {
+						goto l286
+					}
+					if !_rules[rule_]() {
+						goto l286
+					}
+					if !_rules[ruleparameters]() {
+						goto l286
+					}
+					if !_rules[rule_]() {
+						goto l286
+					}
+					if !_rules[ruleblock]() {
+						goto l286
+					}
+					if !_rules[ruleAction82]() {
+						goto l286
+					}
+					goto l271
+				l286:
+					position, tokenIndex = position271, tokenIndex271
+					if !_rules[ruleidentifier]() {
+						goto l269
+					}
+				}
+			l271:
+				add(ruleprimary, position270)
+			}
+			return true
+		l269:
+			position, tokenIndex = position269, tokenIndex269
+			return false
+		},
+		/* 35 list <- <(<'['> Action83 sp _ (expression (_ ',' sp _ expression)* (_ ',')?)? sp _ <']'> Action84)> */
+		func() bool {
+			position288, tokenIndex288 := position, tokenIndex
+			{
+				position289 := position
+				{
+					position290 := position
+					if buffer[position] != rune('[') {
+						goto l288
+					}
+					position++
+					add(rulePegText, position290)
+				}
+				if !_rules[ruleAction83]() {
+					goto l288
+				}
+				if !_rules[rulesp]() {
+					goto l288
+				}
+				if !_rules[rule_]() {
+					goto l288
+				}
+				{
+					position291, tokenIndex291 := position, tokenIndex
+					if !_rules[ruleexpression]() {
+						goto l291
+					}
+				l293:
+					{
+						position294, tokenIndex294 := position, tokenIndex
+						if !_rules[rule_]() {
+							goto l294
+						}
+						if buffer[position] != rune(',') {
+							goto l294
+						}
+						position++
+						if !_rules[rulesp]() {
+							goto l294
+						}
+						if !_rules[rule_]() {
+							goto l294
+						}
+						if !_rules[ruleexpression]() {
+							goto l294
+						}
+						goto l293
+					l294:
+						position, tokenIndex = position294, tokenIndex294
+					}
+					{
+						position295, tokenIndex295 := position, tokenIndex
+						if !_rules[rule_]() {
+							goto l295
+						}
+						if buffer[position] != rune(',') {
+							goto l295
+						}
+						position++
+						goto l296
+					l295:
+						position, tokenIndex = position295, tokenIndex295
+					}
+				l296:
+					goto l292
+				l291:
+					position, tokenIndex = position291, tokenIndex291
+				}
+			l292:
+				if !_rules[rulesp]() {
+					goto l288
+				}
+				if !_rules[rule_]() {
+					goto l288
+				}
+				{
+					position297 := position
+					if buffer[position] != rune(']') {
+						goto l288
+					}
+					position++
+					add(rulePegText, position297)
+				}
+				if !_rules[ruleAction84]() {
+					goto l288
+				}
+				add(rulelist, position289)
+			}
+			return true
+		l288:
+			position, tokenIndex = position288, tokenIndex288
+			return false
+		},
+		/* 36 float <- <(<(('0' / ([1-9] [0-9]*)) '.' [0-9]+ (('e' / 'E') ('+' / '-')? [0-9]+)?)> Action85)> */
+		func() bool {
+			position298, tokenIndex298 := position, tokenIndex
+			{
+				position299 := position
+				{
+					position300 := position
+					{
+						position301, tokenIndex301 := position, tokenIndex
+						if buffer[position] != rune('0') {
+							goto l302
+						}
+						position++
+						goto l301
+					l302:
+						position, tokenIndex = position301, tokenIndex301
+						if c := buffer[position]; c < rune('1') || c > rune('9') {
+							goto l298
+						}
+						position++
+					l303:
+						{
+							position304, tokenIndex304 := position, tokenIndex
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l304
+							}
+							position++
+							goto l303
+						l304:
+							position, tokenIndex = position304, tokenIndex304
+						}
+					}
+				l301:
+					if buffer[position] != rune('.') {
+						goto l298
+					}
+					position++
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l298
+					}
+					position++
+				l305:
+					{
+						position306, tokenIndex306 := position, tokenIndex
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l306
+						}
+						position++
+						goto l305
+					l306:
+						position, tokenIndex = position306, tokenIndex306
+					}
+					{
+						position307, tokenIndex307 := position, tokenIndex
+						{
+							position309, tokenIndex309 := position, tokenIndex
+							if buffer[position] != rune('e') {
+								goto l310
+							}
+							position++
+							goto l309
+						l310:
+							position, tokenIndex = position309, tokenIndex309
+							if buffer[position] != rune('E') {
+								goto l307
+							}
+							position++
+						}
+					l309:
+						{
+							position311, tokenIndex311 := position, tokenIndex
+							{
+								position313, tokenIndex313 := position, tokenIndex
+								if buffer[position] != rune('+') {
+									goto l314
+								}
+								position++
+								goto l313
+							l314:
+								position, tokenIndex = position313, tokenIndex313
+								if buffer[position] != rune('-') {
+									goto l311
+								}
+								position++
+							}
+						l313:
+							goto l312
+						l311:
+							position, tokenIndex = position311, tokenIndex311
+						}
+					l312:
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l307
+						}
+						position++
+					l315:
+						{
+							position316, tokenIndex316 := position, tokenIndex
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l316
+							}
+							position++
+							goto l315
+						l316:
+							position, tokenIndex = position316, tokenIndex316
+						}
+						goto l308
+					l307:
+						position, tokenIndex = position307, tokenIndex307
+					}
+				l308:
+					add(rulePegText, position300)
+				}
+				if !_rules[ruleAction85]() {
+					goto l298
+				}
+				add(rulefloat, position299)
+			}
+			return true
+		l298:
+			position, tokenIndex = position298, tokenIndex298
+			return false
+		},
+		/* 37 integer <- <(<('0' / ([1-9] [0-9]*))> Action86)> */
+		func() bool {
+			position317, tokenIndex317 := position, tokenIndex
+			{
+				position318 := position
+				{
+					position319 := position
+					{
+						position320, tokenIndex320 := position, tokenIndex
+						if buffer[position] != rune('0') {
+							goto l321
+						}
+						position++
+						goto l320
+					l321:
+						position, tokenIndex = position320, tokenIndex320
+						if c := buffer[position]; c < rune('1') || c > rune('9') {
+							goto l317
+						}
+						position++
+					l322:
+						{
+							position323, tokenIndex323 := position, tokenIndex
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l323
+							}
+							position++
+							goto l322
+						l323:
+							position, tokenIndex = position323, tokenIndex323
+						}
+					}
+				l320:
+					add(rulePegText, position319)
+				}
+				if !_rules[ruleAction86]() {
+					goto l317
+				}
+				add(ruleinteger, position318)
+			}
+			return true
+		l317:
+			position, tokenIndex = position317, tokenIndex317
+			return false
+		},
+		/* 38 string <- <('"' <(!'"' .)*> '"' Action87)> */
+		func() bool {
+			position324, tokenIndex324 := position, tokenIndex
+			{
+				position325 := position
+				if buffer[position] != rune('"') {
+					goto l324
+				}
+				position++
+				{
+					position326 := position
+				l327:
+					{
+						position328, tokenIndex328 := position, tokenIndex
+						{
+							position329, tokenIndex329 := position, tokenIndex
+							if buffer[position] != rune('"') {
+								goto l329
+							}
+							position++
+							goto l328
+						l329:
+							position, tokenIndex = position329, tokenIndex329
+						}
+						if !matchDot() {
+							goto l328
+						}
+						goto l327
+					l328:
+						position, tokenIndex = position328, tokenIndex328
+					}
+					add(rulePegText, position326)
+				}
+				if buffer[position] != rune('"') {
+					goto l324
+				}
+				position++
+				if !_rules[ruleAction87]() {
+					goto l324
+				}
+				add(rulestring, position325)
+			}
+			return true
+		l324:
+			position, tokenIndex = position324, tokenIndex324
+			return false
+		},
+		/* 39 identifier <- <(!keyword <(('_' / [a-z] / [A-Z]) ('_' / [a-z] / [A-Z] / [0-9])*)> Action88)> */
+		func() bool {
+			position330, tokenIndex330 := position, tokenIndex
+			{
+				position331 := position
+				{
+					position332, tokenIndex332 := position, tokenIndex
+					if !_rules[rulekeyword]() {
+						goto l332
+					}
+					goto l330
+				l332:
+					position, tokenIndex = position332, tokenIndex332
+				}
+				{
+					position333 := position
+					{
+						position334, tokenIndex334 := position, tokenIndex
+						if buffer[position] != rune('_') {
+							goto l335
+						}
+						position++
+						goto l334
+					l335:
+						position, tokenIndex = position334, tokenIndex334
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l336
+						}
+						position++
+						goto l334
+					l336:
+						position, tokenIndex = position334, tokenIndex334
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l330
+						}
+						position++
+					}
+				l334:
+				l337:
+					{
+						position338, tokenIndex338 := position, tokenIndex
+						{
+							position339, tokenIndex339 := position, tokenIndex
+							if buffer[position] != rune('_') {
+								goto l340
+							}
+							position++
+							goto l339
+						l340:
+							position, tokenIndex = position339, tokenIndex339
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l341
+							}
+							position++
+							goto l339
+						l341:
+							position, tokenIndex = position339, tokenIndex339
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l342
+							}
+							position++
+							goto l339
+						l342:
+							position, tokenIndex = position339, tokenIndex339
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l338
+							}
+							position++
+						}
+					l339:
+						goto l337
+					l338:
+						position, tokenIndex = position338, tokenIndex338
+					}
+					add(rulePegText, position333)
+				}
+				if !_rules[ruleAction88]() {
+					goto l330
+				}
+				add(ruleidentifier, position331)
+			}
+			return true
+		l330:
+			position, tokenIndex = position330, tokenIndex330
+			return false
+		},
+		/* 40 keyword <- <((('w' 'h' 'i' 'l' 'e') / ('i' 'f') / ('e' 'l' 's' 'i' 'f') / ('e' 'l' 's' 'e') / ('b' 'e' 'g' 'i' 'n') / ('r' 'e' 's' 'c' 'u' 'e') / ('e' 'n' 's' 'u' 'r' 'e') / ('r' 'a' 'i' 's' 'e') / ('i' 'm' 'p' 'o' 'r' 't') / ('f' 'u' 'n' 'c') / ('r' 'e' 't' 'u' 'r' 'n') / ('y' 'i' 'e' 'l' 'd') / ('f' 'o' 'r') / ('i' 'n') / ('s' 'p' 'a' 'w' 'n') / ('s' 'e' 'l' 'e' 'c' 't') / ('c' 'a' 's' 'e') / ('d' 'e' 'f' 'a' 'u' 'l' 't') / ('s' 't' 'r' 'u' 'c' 't') / ('c' 'l' 'a' 's' 's')) !('_' / [a-z] / [A-Z] / [0-9]))> */
+		func() bool {
+			position343, tokenIndex343 := position, tokenIndex
+			{
+				position344 := position
+				{
+					position345, tokenIndex345 := position, tokenIndex
+					if buffer[position] != rune('w') {
+						goto l346
+					}
+					position++
+					if buffer[position] != rune('h') {
+						goto l346
+					}
+					position++
+					if buffer[position] != rune('i') {
+						goto l346
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l346
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l346
+					}
+					position++
+					goto l345
+				l346:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('i') {
+						goto l347
+					}
+					position++
+					if buffer[position] != rune('f') {
+						goto l347
+					}
+					position++
+					goto l345
+				l347:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('e') {
+						goto l348
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l348
+					}
+					position++
+					if buffer[position] != rune('s') {
+						goto l348
+					}
+					position++
+					if buffer[position] != rune('i') {
+						goto l348
+					}
+					position++
+					if buffer[position] != rune('f') {
+						goto l348
+					}
+					position++
+					goto l345
+				l348:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('e') {
+						goto l349
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l349
+					}
+					position++
+					if buffer[position] != rune('s') {
+						goto l349
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l349
+					}
+					position++
+					goto l345
+				l349:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('b') {
+						goto l350
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l350
+					}
+					position++
+					if buffer[position] != rune('g') {
+						goto l350
+					}
+					position++
+					if buffer[position] != rune('i') {
+						goto l350
+					}
+					position++
+					if buffer[position] != rune('n') {
+						goto l350
+					}
+					position++
+					goto l345
+				l350:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('r') {
+						goto l351
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l351
+					}
+					position++
+					if buffer[position] != rune('s') {
+						goto l351
+					}
+					position++
+					if buffer[position] != rune('c') {
+						goto l351
+					}
+					position++
+					if buffer[position] != rune('u') {
+						goto l351
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l351
+					}
+					position++
+					goto l345
+				l351:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('e') {
+						goto l352
+					}
+					position++
+					if buffer[position] != rune('n') {
+						goto l352
+					}
+					position++
+					if buffer[position] != rune('s') {
+						goto l352
+					}
+					position++
+					if buffer[position] != rune('u') {
+						goto l352
+					}
+					position++
+					if buffer[position] != rune('r') {
+						goto l352
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l352
+					}
+					position++
+					goto l345
+				l352:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('r') {
+						goto l353
+					}
+					position++
+					if buffer[position] != rune('a') {
+						goto l353
+					}
+					position++
+					if buffer[position] != rune('i') {
+						goto l353
+					}
+					position++
+					if buffer[position] != rune('s') {
+						goto l353
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l353
+					}
+					position++
+					goto l345
+				l353:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('i') {
+						goto l354
+					}
+					position++
+					if buffer[position] != rune('m') {
+						goto l354
+					}
+					position++
+					if buffer[position] != rune('p') {
+						goto l354
+					}
+					position++
+					if buffer[position] != rune('o') {
+						goto l354
+					}
+					position++
+					if buffer[position] != rune('r') {
+						goto l354
+					}
+					position++
+					if buffer[position] != rune('t') {
+						goto l354
+					}
+					position++
+					goto l345
+				l354:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('f') {
+						goto l355
+					}
+					position++
+					if buffer[position] != rune('u') {
+						goto l355
+					}
+					position++
+					if buffer[position] != rune('n') {
+						goto l355
+					}
+					position++
+					if buffer[position] != rune('c') {
+						goto l355
+					}
+					position++
+					goto l345
+				l355:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('r') {
+						goto l356
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l356
+					}
+					position++
+					if buffer[position] != rune('t') {
+						goto l356
+					}
+					position++
+					if buffer[position] != rune('u') {
+						goto l356
+					}
+					position++
+					if buffer[position] != rune('r') {
+						goto l356
+					}
+					position++
+					if buffer[position] != rune('n') {
+						goto l356
+					}
+					position++
+					goto l345
+				l356:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('y') {
+						goto l357
+					}
+					position++
+					if buffer[position] != rune('i') {
+						goto l357
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l357
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l357
+					}
+					position++
+					if buffer[position] != rune('d') {
+						goto l357
+					}
+					position++
+					goto l345
+				l357:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('f') {
+						goto l358
 					}
-					{
-						position95, tokenIndex95 := position, tokenIndex
-						if !_rules[rule_]() {
-							goto l95
-						}
-						if buffer[position] != rune(',') {
-							goto l95
-						}
-						position++
-						goto l96
-					l95:
-						position, tokenIndex = position95, tokenIndex95
+					position++
+					if buffer[position] != rune('o') {
+						goto l358
 					}
-				l96:
-					if !_rules[rulesp]() {
-						goto l88
+					position++
+					if buffer[position] != rune('r') {
+						goto l358
 					}
-					if !_rules[rule_]() {
-						goto l88
+					position++
+					goto l345
+				l358:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('i') {
+						goto l359
 					}
-					{
-						position97 := position
-						if buffer[position] != rune(')') {
-							goto l88
-						}
-						position++
-						add(rulePegText, position97)
+					position++
+					if buffer[position] != rune('n') {
+						goto l359
 					}
-					if !_rules[ruleAction37]() {
-						goto l88
+					position++
+					goto l345
+				l359:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('s') {
+						goto l360
 					}
-				}
-			l90:
-				add(rulefuncall, position89)
-			}
-			return true
-		l88:
-			position, tokenIndex = position88, tokenIndex88
-			return false
-		},
-		/* 17 primary <- <(('(' _ sp _ expression _ sp _ ')') / ('t' 'r' 'u' 'e' Action38) / ('f' 'a' 'l' 's' 'e' Action39) / float / integer / string / identifier)> */
-		func() bool {
-			position98, tokenIndex98 := position, tokenIndex
-			{
-				position99 := position
-				{
-					position100, tokenIndex100 := position, tokenIndex
-					if buffer[position] != rune('(') {
-						goto l101
+					position++
+					if buffer[position] != rune('p') {
+						goto l360
 					}
 					position++
-					if !_rules[rule_]() {
-						goto l101
+					if buffer[position] != rune('a') {
+						goto l360
 					}
-					if !_rules[rulesp]() {
-						goto l101
+					position++
+					if buffer[position] != rune('w') {
+						goto l360
 					}
-					if !_rules[rule_]() {
-						goto l101
+					position++
+					if buffer[position] != rune('n') {
+						goto l360
 					}
-					if !_rules[ruleexpression]() {
-						goto l101
+					position++
+					goto l345
+				l360:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('s') {
+						goto l361
 					}
-					if !_rules[rule_]() {
-						goto l101
+					position++
+					if buffer[position] != rune('e') {
+						goto l361
 					}
-					if !_rules[rulesp]() {
-						goto l101
+					position++
+					if buffer[position] != rune('l') {
+						goto l361
 					}
-					if !_rules[rule_]() {
-						goto l101
+					position++
+					if buffer[position] != rune('e') {
+						goto l361
 					}
-					if buffer[position] != rune(')') {
-						goto l101
+					position++
+					if buffer[position] != rune('c') {
+						goto l361
 					}
 					position++
-					goto l100
-				l101:
-					position, tokenIndex = position100, tokenIndex100
 					if buffer[position] != rune('t') {
-						goto l102
+						goto l361
 					}
 					position++
-					if buffer[position] != rune('r') {
-						goto l102
+					goto l345
+				l361:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('c') {
+						goto l362
 					}
 					position++
-					if buffer[position] != rune('u') {
-						goto l102
+					if buffer[position] != rune('a') {
+						goto l362
+					}
+					position++
+					if buffer[position] != rune('s') {
+						goto l362
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l102
+						goto l362
 					}
 					position++
-					if !_rules[ruleAction38]() {
-						goto l102
+					goto l345
+				l362:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('d') {
+						goto l363
 					}
-					goto l100
-				l102:
-					position, tokenIndex = position100, tokenIndex100
+					position++
+					if buffer[position] != rune('e') {
+						goto l363
+					}
+					position++
 					if buffer[position] != rune('f') {
-						goto l103
+						goto l363
 					}
 					position++
 					if buffer[position] != rune('a') {
-						goto l103
+						goto l363
 					}
 					position++
-					if buffer[position] != rune('l') {
-						goto l103
+					if buffer[position] != rune('u') {
+						goto l363
 					}
 					position++
-					if buffer[position] != rune('s') {
-						goto l103
+					if buffer[position] != rune('l') {
+						goto l363
 					}
 					position++
-					if buffer[position] != rune('e') {
-						goto l103
+					if buffer[position] != rune('t') {
+						goto l363
 					}
 					position++
-					if !_rules[ruleAction39]() {
-						goto l103
-					}
-					goto l100
-				l103:
-					position, tokenIndex = position100, tokenIndex100
-					if !_rules[rulefloat]() {
-						goto l104
+					goto l345
+				l363:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('s') {
+						goto l364
 					}
-					goto l100
-				l104:
-					position, tokenIndex = position100, tokenIndex100
-					if !_rules[ruleinteger]() {
-						goto l105
+					position++
+					if buffer[position] != rune('t') {
+						goto l364
 					}
-					goto l100
-				l105:
-					position, tokenIndex = position100, tokenIndex100
-					if !_rules[rulestring]() {
-						goto l106
+					position++
+					if buffer[position] != rune('r') {
+						goto l364
 					}
-					goto l100
-				l106:
-					position, tokenIndex = position100, tokenIndex100
-					if !_rules[ruleidentifier]() {
-						goto l98
+					position++
+					if buffer[position] != rune('u') {
+						goto l364
 					}
-				}
-			l100:
-				add(ruleprimary, position99)
-			}
-			return true
-		l98:
-			position, tokenIndex = position98, tokenIndex98
-			return false
-		},
-		/* 18 float <- <(<(('0' / ([1-9] [0-9]*)) '.' [0-9]+ (('e' / 'E') ('+' / '-')? [0-9]+)?)> Action40)> */
-		func() bool {
-			position107, tokenIndex107 := position, tokenIndex
-			{
-				position108 := position
-				{
-					position109 := position
-					{
-						position110, tokenIndex110 := position, tokenIndex
-						if buffer[position] != rune('0') {
-							goto l111
-						}
-						position++
-						goto l110
-					l111:
-						position, tokenIndex = position110, tokenIndex110
-						if c := buffer[position]; c < rune('1') || c > rune('9') {
-							goto l107
-						}
-						position++
-					l112:
-						{
-							position113, tokenIndex113 := position, tokenIndex
-							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l113
-							}
-							position++
-							goto l112
-						l113:
-							position, tokenIndex = position113, tokenIndex113
-						}
+					position++
+					if buffer[position] != rune('c') {
+						goto l364
 					}
-				l110:
-					if buffer[position] != rune('.') {
-						goto l107
+					position++
+					if buffer[position] != rune('t') {
+						goto l364
 					}
 					position++
-					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l107
+					goto l345
+				l364:
+					position, tokenIndex = position345, tokenIndex345
+					if buffer[position] != rune('c') {
+						goto l343
 					}
 					position++
-				l114:
-					{
-						position115, tokenIndex115 := position, tokenIndex
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l115
-						}
-						position++
-						goto l114
-					l115:
-						position, tokenIndex = position115, tokenIndex115
+					if buffer[position] != rune('l') {
+						goto l343
 					}
-					{
-						position116, tokenIndex116 := position, tokenIndex
-						{
-							position118, tokenIndex118 := position, tokenIndex
-							if buffer[position] != rune('e') {
-								goto l119
-							}
-							position++
-							goto l118
-						l119:
-							position, tokenIndex = position118, tokenIndex118
-							if buffer[position] != rune('E') {
-								goto l116
-							}
-							position++
-						}
-					l118:
-						{
-							position120, tokenIndex120 := position, tokenIndex
-							{
-								position122, tokenIndex122 := position, tokenIndex
-								if buffer[position] != rune('+') {
-									goto l123
-								}
-								position++
-								goto l122
-							l123:
-								position, tokenIndex = position122, tokenIndex122
-								if buffer[position] != rune('-') {
-									goto l120
-								}
-								position++
-							}
-						l122:
-							goto l121
-						l120:
-							position, tokenIndex = position120, tokenIndex120
-						}
-					l121:
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l116
-						}
-						position++
-					l124:
-						{
-							position125, tokenIndex125 := position, tokenIndex
-							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l125
-							}
-							position++
-							goto l124
-						l125:
-							position, tokenIndex = position125, tokenIndex125
-						}
-						goto l117
-					l116:
-						position, tokenIndex = position116, tokenIndex116
+					position++
+					if buffer[position] != rune('a') {
+						goto l343
 					}
-				l117:
-					add(rulePegText, position109)
-				}
-				if !_rules[ruleAction40]() {
-					goto l107
-				}
-				add(rulefloat, position108)
-			}
-			return true
-		l107:
-			position, tokenIndex = position107, tokenIndex107
-			return false
-		},
-		/* 19 integer <- <(<('0' / ([1-9] [0-9]*))> Action41)> */
-		func() bool {
-			position126, tokenIndex126 := position, tokenIndex
-			{
-				position127 := position
-				{
-					position128 := position
-					{
-						position129, tokenIndex129 := position, tokenIndex
-						if buffer[position] != rune('0') {
-							goto l130
-						}
-						position++
-						goto l129
-					l130:
-						position, tokenIndex = position129, tokenIndex129
-						if c := buffer[position]; c < rune('1') || c > rune('9') {
-							goto l126
-						}
-						position++
-					l131:
-						{
-							position132, tokenIndex132 := position, tokenIndex
-							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l132
-							}
-							position++
-							goto l131
-						l132:
-							position, tokenIndex = position132, tokenIndex132
-						}
+					position++
+					if buffer[position] != rune('s') {
+						goto l343
 					}
-				l129:
-					add(rulePegText, position128)
-				}
-				if !_rules[ruleAction41]() {
-					goto l126
-				}
-				add(ruleinteger, position127)
-			}
-			return true
-		l126:
-			position, tokenIndex = position126, tokenIndex126
-			return false
-		},
-		/* 20 string <- <('"' <(!'"' .)*> '"' Action42)> */
-		func() bool {
-			position133, tokenIndex133 := position, tokenIndex
-			{
-				position134 := position
-				if buffer[position] != rune('"') {
-					goto l133
-				}
-				position++
-				{
-					position135 := position
-				l136:
-					{
-						position137, tokenIndex137 := position, tokenIndex
-						{
-							position138, tokenIndex138 := position, tokenIndex
-							if buffer[position] != rune('"') {
-								goto l138
-							}
-							position++
-							goto l137
-						l138:
-							position, tokenIndex = position138, tokenIndex138
-						}
-						if !matchDot() {
-							goto l137
-						}
-						goto l136
-					l137:
-						position, tokenIndex = position137, tokenIndex137
+					position++
+					if buffer[position] != rune('s') {
+						goto l343
 					}
-					add(rulePegText, position135)
-				}
-				if buffer[position] != rune('"') {
-					goto l133
-				}
-				position++
-				if !_rules[ruleAction42]() {
-					goto l133
+					position++
 				}
-				add(rulestring, position134)
-			}
-			return true
-		l133:
-			position, tokenIndex = position133, tokenIndex133
-			return false
-		},
-		/* 21 identifier <- <(<(('_' / [a-z] / [A-Z]) ('_' / [a-z] / [A-Z] / [0-9])*)> Action43)> */
-		func() bool {
-			position139, tokenIndex139 := position, tokenIndex
-			{
-				position140 := position
+			l345:
 				{
-					position141 := position
+					position365, tokenIndex365 := position, tokenIndex
 					{
-						position142, tokenIndex142 := position, tokenIndex
+						position366, tokenIndex366 := position, tokenIndex
 						if buffer[position] != rune('_') {
-							goto l143
+							goto l367
 						}
 						position++
-						goto l142
-					l143:
-						position, tokenIndex = position142, tokenIndex142
+						goto l366
+					l367:
+						position, tokenIndex = position366, tokenIndex366
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l144
+							goto l368
 						}
 						position++
-						goto l142
-					l144:
-						position, tokenIndex = position142, tokenIndex142
+						goto l366
+					l368:
+						position, tokenIndex = position366, tokenIndex366
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l139
+							goto l369
 						}
 						position++
-					}
-				l142:
-				l145:
-					{
-						position146, tokenIndex146 := position, tokenIndex
-						{
-							position147, tokenIndex147 := position, tokenIndex
-							if buffer[position] != rune('_') {
-								goto l148
-							}
-							position++
-							goto l147
-						l148:
-							position, tokenIndex = position147, tokenIndex147
-							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l149
-							}
-							position++
-							goto l147
-						l149:
-							position, tokenIndex = position147, tokenIndex147
-							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l150
-							}
-							position++
-							goto l147
-						l150:
-							position, tokenIndex = position147, tokenIndex147
-							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l146
-							}
-							position++
+						goto l366
+					l369:
+						position, tokenIndex = position366, tokenIndex366
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l365
 						}
-					l147:
-						goto l145
-					l146:
-						position, tokenIndex = position146, tokenIndex146
+						position++
 					}
-					add(rulePegText, position141)
-				}
-				if !_rules[ruleAction43]() {
-					goto l139
+				l366:
+					goto l343
+				l365:
+					position, tokenIndex = position365, tokenIndex365
 				}
-				add(ruleidentifier, position140)
+				add(rulekeyword, position344)
 			}
 			return true
-		l139:
-			position, tokenIndex = position139, tokenIndex139
+		l343:
+			position, tokenIndex = position343, tokenIndex343
 			return false
 		},
-		/* 22 _ <- <(' ' / '\t')*> */
+		/* 41 _ <- <(' ' / '\t')*> */
 		func() bool {
 			{
-				position152 := position
-			l153:
+				position371 := position
+			l372:
 				{
-					position154, tokenIndex154 := position, tokenIndex
+					position373, tokenIndex373 := position, tokenIndex
 					{
-						position155, tokenIndex155 := position, tokenIndex
+						position374, tokenIndex374 := position, tokenIndex
 						if buffer[position] != rune(' ') {
-							goto l156
+							goto l375
 						}
 						position++
-						goto l155
-					l156:
-						position, tokenIndex = position155, tokenIndex155
+						goto l374
+					l375:
+						position, tokenIndex = position374, tokenIndex374
 						if buffer[position] != rune('\t') {
-							goto l154
+							goto l373
 						}
 						position++
 					}
-				l155:
-					goto l153
-				l154:
-					position, tokenIndex = position154, tokenIndex154
+				l374:
+					goto l372
+				l373:
+					position, tokenIndex = position373, tokenIndex373
 				}
-				add(rule_, position152)
+				add(rule_, position371)
 			}
 			return true
 		},
-		/* 23 nl <- <('\r' / '\n')+> */
+		/* 42 nl <- <('\r' / '\n')+> */
 		func() bool {
-			position157, tokenIndex157 := position, tokenIndex
+			position376, tokenIndex376 := position, tokenIndex
 			{
-				position158 := position
+				position377 := position
 				{
-					position161, tokenIndex161 := position, tokenIndex
+					position380, tokenIndex380 := position, tokenIndex
 					if buffer[position] != rune('\r') {
-						goto l162
+						goto l381
 					}
 					position++
-					goto l161
-				l162:
-					position, tokenIndex = position161, tokenIndex161
+					goto l380
+				l381:
+					position, tokenIndex = position380, tokenIndex380
 					if buffer[position] != rune('\n') {
-						goto l157
+						goto l376
 					}
 					position++
 				}
-			l161:
-			l159:
+			l380:
+			l378:
 				{
-					position160, tokenIndex160 := position, tokenIndex
+					position379, tokenIndex379 := position, tokenIndex
 					{
-						position163, tokenIndex163 := position, tokenIndex
+						position382, tokenIndex382 := position, tokenIndex
 						if buffer[position] != rune('\r') {
-							goto l164
+							goto l383
 						}
 						position++
-						goto l163
-					l164:
-						position, tokenIndex = position163, tokenIndex163
+						goto l382
+					l383:
+						position, tokenIndex = position382, tokenIndex382
 						if buffer[position] != rune('\n') {
-							goto l160
+							goto l379
 						}
 						position++
 					}
-				l163:
-					goto l159
-				l160:
-					position, tokenIndex = position160, tokenIndex160
+				l382:
+					goto l378
+				l379:
+					position, tokenIndex = position379, tokenIndex379
 				}
-				add(rulenl, position158)
+				add(rulenl, position377)
 			}
 			return true
-		l157:
-			position, tokenIndex = position157, tokenIndex157
+		l376:
+			position, tokenIndex = position376, tokenIndex376
 			return false
 		},
-		/* 24 comment <- <('#' (!('\r' / '\n') .)*)> */
+		/* 43 comment <- <(<('#' (!('\r' / '\n') .)*)> Action89)> */
 		func() bool {
-			position165, tokenIndex165 := position, tokenIndex
+			position384, tokenIndex384 := position, tokenIndex
 			{
-				position166 := position
-				if buffer[position] != rune('#') {
-					goto l165
-				}
-				position++
-			l167:
+				position385 := position
 				{
-					position168, tokenIndex168 := position, tokenIndex
+					position386 := position
+					if buffer[position] != rune('#') {
+						goto l384
+					}
+					position++
+				l387:
 					{
-						position169, tokenIndex169 := position, tokenIndex
+						position388, tokenIndex388 := position, tokenIndex
 						{
-							position170, tokenIndex170 := position, tokenIndex
-							if buffer[position] != rune('\r') {
-								goto l171
-							}
-							position++
-							goto l170
-						l171:
-							position, tokenIndex = position170, tokenIndex170
-							if buffer[position] != rune('\n') {
-								goto l169
+							position389, tokenIndex389 := position, tokenIndex
+							{
+								position390, tokenIndex390 := position, tokenIndex
+								if buffer[position] != rune('\r') {
+									goto l391
+								}
+								position++
+								goto l390
+							l391:
+								position, tokenIndex = position390, tokenIndex390
+								if buffer[position] != rune('\n') {
+									goto l389
+								}
+								position++
 							}
-							position++
+						l390:
+							goto l388
+						l389:
+							position, tokenIndex = position389, tokenIndex389
 						}
-					l170:
-						goto l168
-					l169:
-						position, tokenIndex = position169, tokenIndex169
-					}
-					if !matchDot() {
-						goto l168
+						if !matchDot() {
+							goto l388
+						}
+						goto l387
+					l388:
+						position, tokenIndex = position388, tokenIndex388
 					}
-					goto l167
-				l168:
-					position, tokenIndex = position168, tokenIndex168
+					add(rulePegText, position386)
+				}
+				if !_rules[ruleAction89]() {
+					goto l384
 				}
-				add(rulecomment, position166)
+				add(rulecomment, position385)
 			}
 			return true
-		l165:
-			position, tokenIndex = position165, tokenIndex165
+		l384:
+			position, tokenIndex = position384, tokenIndex384
 			return false
 		},
-		/* 25 sp <- <(_ comment? nl _)*> */
+		/* 44 sp <- <(_ comment? nl _)*> */
 		func() bool {
 			{
-				position173 := position
-			l174:
+				position393 := position
+			l394:
 				{
-					position175, tokenIndex175 := position, tokenIndex
+					position395, tokenIndex395 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l175
+						goto l395
 					}
 					{
-						position176, tokenIndex176 := position, tokenIndex
+						position396, tokenIndex396 := position, tokenIndex
 						if !_rules[rulecomment]() {
-							goto l176
+							goto l396
 						}
-						goto l177
-					l176:
-						position, tokenIndex = position176, tokenIndex176
+						goto l397
+					l396:
+						position, tokenIndex = position396, tokenIndex396
 					}
-				l177:
+				l397:
 					if !_rules[rulenl]() {
-						goto l175
+						goto l395
 					}
 					if !_rules[rule_]() {
-						goto l175
+						goto l395
 					}
-					goto l174
-				l175:
-					position, tokenIndex = position175, tokenIndex175
+					goto l394
+				l395:
+					position, tokenIndex = position395, tokenIndex395
 				}
-				add(rulesp, position173)
+				add(rulesp, position393)
 			}
 			return true
 		},
-		/* 27 Action0 <- <{ p.PushExpressionStatement() }> */
+		/* 46 Action0 <- <{ p.PushExpressionStatement() }> */
 		func() bool {
 			{
 				add(ruleAction0, position)
 			}
 			return true
 		},
-		/* 28 Action1 <- <{ p.PopBlock() }> */
+		/* 47 Action1 <- <{ p.PushExpressionStatement() }> */
 		func() bool {
 			{
 				add(ruleAction1, position)
 			}
 			return true
 		},
-		nil,
-		/* 30 Action2 <- <{ p.PushBlock(begin) }> */
+		/* 48 Action2 <- <{ p.PopBlock() }> */
 		func() bool {
 			{
 				add(ruleAction2, position)
 			}
 			return true
 		},
-		/* 31 Action3 <- <{ p.CompleteBlock(end) }> */
+		nil,
+		/* 50 Action3 <- <{ p.PushBlock(begin) }> */
 		func() bool {
 			{
 				add(ruleAction3, position)
 			}
 			return true
 		},
-		/* 32 Action4 <- <{ p.PushWhile(begin) }> */
+		/* 51 Action4 <- <{ p.CompleteBlock(end) }> */
 		func() bool {
 			{
 				add(ruleAction4, position)
 			}
 			return true
 		},
-		/* 33 Action5 <- <{ p.CompleteWhile() }> */
+		/* 52 Action5 <- <{ p.PushWhile(begin) }> */
 		func() bool {
 			{
 				add(ruleAction5, position)
 			}
 			return true
 		},
-		/* 34 Action6 <- <{ p.PushIfPart(begin) }> */
+		/* 53 Action6 <- <{ p.CompleteWhile() }> */
 		func() bool {
 			{
 				add(ruleAction6, position)
 			}
 			return true
 		},
-		/* 35 Action7 <- <{ p.CompleteIfPart() }> */
+		/* 54 Action7 <- <{ p.PushFor(begin) }> */
 		func() bool {
 			{
 				add(ruleAction7, position)
 			}
 			return true
 		},
-		/* 36 Action8 <- <{ p.PushElsifPart(begin) }> */
+		/* 55 Action8 <- <{ p.CompleteFor() }> */
 		func() bool {
 			{
 				add(ruleAction8, position)
 			}
 			return true
 		},
-		/* 37 Action9 <- <{ p.CompleteElsifPart() }> */
+		/* 56 Action9 <- <{ p.PushSelect(begin) }> */
 		func() bool {
 			{
 				add(ruleAction9, position)
 			}
 			return true
 		},
-		/* 38 Action10 <- <{ p.PushElsePart(begin) }> */
+		/* 57 Action10 <- <{ p.PushSelectDefault() }> */
 		func() bool {
 			{
 				add(ruleAction10, position)
 			}
 			return true
 		},
-		/* 39 Action11 <- <{ p.CompleteElsePart() }> */
+		/* 58 Action11 <- <{ p.CompleteSelect(end) }> */
 		func() bool {
 			{
 				add(ruleAction11, position)
 			}
 			return true
 		},
-		/* 40 Action12 <- <{ p.CompleteIf() }> */
+		/* 59 Action12 <- <{ p.PushSelectCase(begin) }> */
 		func() bool {
 			{
 				add(ruleAction12, position)
 			}
 			return true
 		},
-		/* 41 Action13 <- <{ p.PushAssign("") }> */
+		/* 60 Action13 <- <{ p.CompleteSelectSend() }> */
 		func() bool {
 			{
 				add(ruleAction13, position)
 			}
 			return true
 		},
-		/* 42 Action14 <- <{ p.PushAssign("+") }> */
+		/* 61 Action14 <- <{ p.CompleteSelectRecv() }> */
 		func() bool {
 			{
 				add(ruleAction14, position)
 			}
 			return true
 		},
-		/* 43 Action15 <- <{ p.PushAssign("-") }> */
+		/* 62 Action15 <- <{ p.CompleteSelectCase() }> */
 		func() bool {
 			{
 				add(ruleAction15, position)
 			}
 			return true
 		},
-		/* 44 Action16 <- <{ p.PushAssign("*") }> */
+		/* 63 Action16 <- <{ p.PushIfPart(begin) }> */
 		func() bool {
 			{
 				add(ruleAction16, position)
 			}
 			return true
 		},
-		/* 45 Action17 <- <{ p.PushAssign("/") }> */
+		/* 64 Action17 <- <{ p.CompleteIfPart() }> */
 		func() bool {
 			{
 				add(ruleAction17, position)
 			}
 			return true
 		},
-		/* 46 Action18 <- <{ p.PushAssign("%") }> */
+		/* 65 Action18 <- <{ p.PushElsifPart(begin) }> */
 		func() bool {
 			{
 				add(ruleAction18, position)
 			}
 			return true
 		},
-		/* 47 Action19 <- <{ p.PushBinOp("==") }> */
+		/* 66 Action19 <- <{ p.CompleteElsifPart() }> */
 		func() bool {
 			{
 				add(ruleAction19, position)
 			}
 			return true
 		},
-		/* 48 Action20 <- <{ p.PushBinOp("!=") }> */
+		/* 67 Action20 <- <{ p.PushElsePart(begin) }> */
 		func() bool {
 			{
 				add(ruleAction20, position)
 			}
 			return true
 		},
-		/* 49 Action21 <- <{ p.PushBinOp("<=") }> */
+		/* 68 Action21 <- <{ p.CompleteElsePart() }> */
 		func() bool {
 			{
 				add(ruleAction21, position)
 			}
 			return true
 		},
-		/* 50 Action22 <- <{ p.PushBinOp(">=") }> */
+		/* 69 Action22 <- <{ p.CompleteIf() }> */
 		func() bool {
 			{
 				add(ruleAction22, position)
 			}
 			return true
 		},
-		/* 51 Action23 <- <{ p.PushBinOp("<") }> */
+		/* 70 Action23 <- <{ p.PushBegin(begin) }> */
 		func() bool {
 			{
 				add(ruleAction23, position)
 			}
 			return true
 		},
-		/* 52 Action24 <- <{ p.PushBinOp(">") }> */
+		/* 71 Action24 <- <{ p.CompleteBeginBody() }> */
 		func() bool {
 			{
 				add(ruleAction24, position)
 			}
 			return true
 		},
-		/* 53 Action25 <- <{ p.PushBinOp("+") }> */
+		/* 72 Action25 <- <{ p.PushRescuePart(begin) }> */
 		func() bool {
 			{
 				add(ruleAction25, position)
 			}
 			return true
 		},
-		/* 54 Action26 <- <{ p.PushBinOp("-") }> */
+		/* 73 Action26 <- <{ p.CompleteRescuePart() }> */
 		func() bool {
 			{
 				add(ruleAction26, position)
 			}
 			return true
 		},
-		/* 55 Action27 <- <{ p.PushBinOp("*") }> */
+		/* 74 Action27 <- <{ p.PushEnsurePart(begin) }> */
 		func() bool {
 			{
 				add(ruleAction27, position)
 			}
 			return true
 		},
-		/* 56 Action28 <- <{ p.PushBinOp("/") }> */
+		/* 75 Action28 <- <{ p.CompleteEnsurePart() }> */
 		func() bool {
 			{
 				add(ruleAction28, position)
 			}
 			return true
 		},
-		/* 57 Action29 <- <{ p.PushBinOp("%") }> */
+		/* 76 Action29 <- <{ p.CompleteBegin() }> */
 		func() bool {
 			{
 				add(ruleAction29, position)
 			}
 			return true
 		},
-		/* 58 Action30 <- <{ p.PushUnaryOp(begin, end, "-") }> */
+		/* 77 Action30 <- <{ p.PushRaise(begin) }> */
 		func() bool {
 			{
 				add(ruleAction30, position)
 			}
 			return true
 		},
-		/* 59 Action31 <- <{ p.PushUnaryOp(begin, end, "+") }> */
+		/* 78 Action31 <- <{ p.CompleteRaise() }> */
 		func() bool {
 			{
 				add(ruleAction31, position)
 			}
 			return true
 		},
-		/* 60 Action32 <- <{ p.PushUnaryOp(begin, end, "!") }> */
+		/* 79 Action32 <- <{ p.PushReturn(begin, end) }> */
 		func() bool {
 			{
 				add(ruleAction32, position)
 			}
 			return true
 		},
-		/* 61 Action33 <- <{ p.CompleteUnary() }> */
+		/* 80 Action33 <- <{ p.CompleteReturn() }> */
 		func() bool {
 			{
 				add(ruleAction33, position)
 			}
 			return true
 		},
-		/* 62 Action34 <- <{ p.PushApply() }> */
+		/* 81 Action34 <- <{ p.PushYield(begin) }> */
 		func() bool {
 			{
 				add(ruleAction34, position)
 			}
 			return true
 		},
-		/* 63 Action35 <- <{ p.CompleteApply(end) }> */
+		/* 82 Action35 <- <{ p.CompleteYield() }> */
 		func() bool {
 			{
 				add(ruleAction35, position)
 			}
 			return true
 		},
-		/* 64 Action36 <- <{ p.PushApply() }> */
+		/* 83 Action36 <- <{ p.PushFunction(begin) }> */
 		func() bool {
 			{
 				add(ruleAction36, position)
 			}
 			return true
 		},
-		/* 65 Action37 <- <{ p.CompleteApply(end) }> */
+		/* 84 Action37 <- <{ p.CompleteFunction() }> */
 		func() bool {
 			{
 				add(ruleAction37, position)
 			}
 			return true
 		},
-		/* 66 Action38 <- <{ p.PushBooleanLiteral(begin, end, true) }> */
+		/* 85 Action38 <- <{ p.PushParameter() }> */
 		func() bool {
 			{
 				add(ruleAction38, position)
 			}
 			return true
 		},
-		/* 67 Action39 <- <{ p.PushBooleanLiteral(begin, end, false) }> */
+		/* 86 Action39 <- <{ p.PushTypeName(begin, end, text) }> */
 		func() bool {
 			{
 				add(ruleAction39, position)
 			}
 			return true
 		},
-		/* 68 Action40 <- <{ p.PushFloatLiteral(begin, end, text) }> */
+		/* 87 Action40 <- <{ p.PushStruct(begin) }> */
 		func() bool {
 			{
 				add(ruleAction40, position)
 			}
 			return true
 		},
-		/* 69 Action41 <- <{ p.PushIntLiteral(begin, end, text) }> */
+		/* 88 Action41 <- <{ p.CompleteStruct(end) }> */
 		func() bool {
 			{
 				add(ruleAction41, position)
 			}
 			return true
 		},
-		/* 70 Action42 <- <{ p.PushStringLiteral(begin, end, text) }> */
+		/* 89 Action42 <- <{ p.PushClass(begin) }> */
 		func() bool {
 			{
 				add(ruleAction42, position)
 			}
 			return true
 		},
-		/* 71 Action43 <- <{ p.PushIdentifier(begin, end, text) }> */
+		/* 90 Action43 <- <{ p.CompleteClass() }> */
 		func() bool {
 			{
 				add(ruleAction43, position)
 			}
 			return true
 		},
+		/* 91 Action44 <- <{ p.PushImport(begin) }> */
+		func() bool {
+			{
+				add(ruleAction44, position)
+			}
+			return true
+		},
+		/* 92 Action45 <- <{ p.CompleteImport() }> */
+		func() bool {
+			{
+				add(ruleAction45, position)
+			}
+			return true
+		},
+		/* 93 Action46 <- <{ p.PushTypedAssign() }> */
+		func() bool {
+			{
+				add(ruleAction46, position)
+			}
+			return true
+		},
+		/* 94 Action47 <- <{ p.PushAssign("") }> */
+		func() bool {
+			{
+				add(ruleAction47, position)
+			}
+			return true
+		},
+		/* 95 Action48 <- <{ p.PushAssign("+") }> */
+		func() bool {
+			{
+				add(ruleAction48, position)
+			}
+			return true
+		},
+		/* 96 Action49 <- <{ p.PushAssign("-") }> */
+		func() bool {
+			{
+				add(ruleAction49, position)
+			}
+			return true
+		},
+		/* 97 Action50 <- <{ p.PushAssign("*") }> */
+		func() bool {
+			{
+				add(ruleAction50, position)
+			}
+			return true
+		},
+		/* 98 Action51 <- <{ p.PushAssign("/") }> */
+		func() bool {
+			{
+				add(ruleAction51, position)
+			}
+			return true
+		},
+		/* 99 Action52 <- <{ p.PushAssign("%") }> */
+		func() bool {
+			{
+				add(ruleAction52, position)
+			}
+			return true
+		},
+		/* 100 Action53 <- <{ p.PushBinOp("==") }> */
+		func() bool {
+			{
+				add(ruleAction53, position)
+			}
+			return true
+		},
+		/* 101 Action54 <- <{ p.PushBinOp("!=") }> */
+		func() bool {
+			{
+				add(ruleAction54, position)
+			}
+			return true
+		},
+		/* 102 Action55 <- <{ p.PushBinOp("<=") }> */
+		func() bool {
+			{
+				add(ruleAction55, position)
+			}
+			return true
+		},
+		/* 103 Action56 <- <{ p.PushBinOp(">=") }> */
+		func() bool {
+			{
+				add(ruleAction56, position)
+			}
+			return true
+		},
+		/* 104 Action57 <- <{ p.PushBinOp("<") }> */
+		func() bool {
+			{
+				add(ruleAction57, position)
+			}
+			return true
+		},
+		/* 105 Action58 <- <{ p.PushBinOp(">") }> */
+		func() bool {
+			{
+				add(ruleAction58, position)
+			}
+			return true
+		},
+		/* 106 Action59 <- <{ p.PushBinOp("in") }> */
+		func() bool {
+			{
+				add(ruleAction59, position)
+			}
+			return true
+		},
+		/* 107 Action60 <- <{ p.PushBinOp("...") }> */
+		func() bool {
+			{
+				add(ruleAction60, position)
+			}
+			return true
+		},
+		/* 108 Action61 <- <{ p.PushBinOp("..") }> */
+		func() bool {
+			{
+				add(ruleAction61, position)
+			}
+			return true
+		},
+		/* 109 Action62 <- <{ p.PushBinOp("+") }> */
+		func() bool {
+			{
+				add(ruleAction62, position)
+			}
+			return true
+		},
+		/* 110 Action63 <- <{ p.PushBinOp("-") }> */
+		func() bool {
+			{
+				add(ruleAction63, position)
+			}
+			return true
+		},
+		/* 111 Action64 <- <{ p.PushBinOp("*") }> */
+		func() bool {
+			{
+				add(ruleAction64, position)
+			}
+			return true
+		},
+		/* 112 Action65 <- <{ p.PushBinOp("/") }> */
+		func() bool {
+			{
+				add(ruleAction65, position)
+			}
+			return true
+		},
+		/* 113 Action66 <- <{ p.PushBinOp("%") }> */
+		func() bool {
+			{
+				add(ruleAction66, position)
+			}
+			return true
+		},
+		/* 114 Action67 <- <{ p.PushUnaryOp(begin, end, "-") }> */
+		func() bool {
+			{
+				add(ruleAction67, position)
+			}
+			return true
+		},
+		/* 115 Action68 <- <{ p.PushUnaryOp(begin, end, "+") }> */
+		func() bool {
+			{
+				add(ruleAction68, position)
+			}
+			return true
+		},
+		/* 116 Action69 <- <{ p.PushUnaryOp(begin, end, "!") }> */
+		func() bool {
+			{
+				add(ruleAction69, position)
+			}
+			return true
+		},
+		/* 117 Action70 <- <{ p.CompleteUnary() }> */
+		func() bool {
+			{
+				add(ruleAction70, position)
+			}
+			return true
+		},
+		/* 118 Action71 <- <{ p.PushAttribute() }> */
+		func() bool {
+			{
+				add(ruleAction71, position)
+			}
+			return true
+		},
+		/* 119 Action72 <- <{ p.PushIndex(end) }> */
+		func() bool {
+			{
+				add(ruleAction72, position)
+			}
+			return true
+		},
+		/* 120 Action73 <- <{ p.PushApply() }> */
+		func() bool {
+			{
+				add(ruleAction73, position)
+			}
+			return true
+		},
+		/* 121 Action74 <- <{ p.CompleteApply(end) }> */
+		func() bool {
+			{
+				add(ruleAction74, position)
+			}
+			return true
+		},
+		/* 122 Action75 <- <{ p.PushApply() }> */
+		func() bool {
+			{
+				add(ruleAction75, position)
+			}
+			return true
+		},
+		/* 123 Action76 <- <{ p.CompleteApply(end) }> */
+		func() bool {
+			{
+				add(ruleAction76, position)
+			}
+			return true
+		},
+		/* 124 Action77 <- <{ p.PushBooleanLiteral(begin, end, true) }> */
+		func() bool {
+			{
+				add(ruleAction77, position)
+			}
+			return true
+		},
+		/* 125 Action78 <- <{ p.PushBooleanLiteral(begin, end, false) }> */
+		func() bool {
+			{
+				add(ruleAction78, position)
+			}
+			return true
+		},
+		/* 126 Action79 <- <{ p.PushSpawn(begin) }> */
+		func() bool {
+			{
+				add(ruleAction79, position)
+			}
+			return true
+		},
+		/* 127 Action80 <- <{ p.CompleteSpawn() }> */
+		func() bool {
+			{
+				add(ruleAction80, position)
+			}
+			return true
+		},
+		/* 128 Action81 <- <{ p.PushFunction(begin) }> */
+		func() bool {
+			{
+				add(ruleAction81, position)
+			}
+			return true
+		},
+		/* 129 Action82 <- <{ p.CompleteFunction() }> */
+		func() bool {
+			{
+				add(ruleAction82, position)
+			}
+			return true
+		},
+		/* 130 Action83 <- <{ p.PushList(begin) }> */
+		func() bool {
+			{
+				add(ruleAction83, position)
+			}
+			return true
+		},
+		/* 131 Action84 <- <{ p.CompleteList(end) }> */
+		func() bool {
+			{
+				add(ruleAction84, position)
+			}
+			return true
+		},
+		/* 132 Action85 <- <{ p.PushFloatLiteral(begin, end, text) }> */
+		func() bool {
+			{
+				add(ruleAction85, position)
+			}
+			return true
+		},
+		/* 133 Action86 <- <{ p.PushIntLiteral(begin, end, text) }> */
+		func() bool {
+			{
+				add(ruleAction86, position)
+			}
+			return true
+		},
+		/* 134 Action87 <- <{ p.PushStringLiteral(begin, end, text) }> */
+		func() bool {
+			{
+				add(ruleAction87, position)
+			}
+			return true
+		},
+		/* 135 Action88 <- <{ p.PushIdentifier(begin, end, text) }> */
+		func() bool {
+			{
+				add(ruleAction88, position)
+			}
+			return true
+		},
+		/* 136 Action89 <- <{ p.PushComment(begin, end, text) }> */
+		func() bool {
+			{
+				add(ruleAction89, position)
+			}
+			return true
+		},
 	}
 	p.rules = _rules
 	return nil
@@ -0,0 +1,51 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	bodies := []string{`{"a":1}`, ``, `{"text":"é\r\n"}`}
+	for _, b := range bodies {
+		if err := Write(&buf, []byte(b)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := bufio.NewReader(&buf)
+	for _, want := range bodies {
+		got, err := Read(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("Read = %q, want %q", got, want)
+		}
+	}
+	if _, err := Read(r); err != io.EOF {
+		t.Errorf("Read at end = %v, want EOF", err)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"no length", "Content-Type: x\r\n\r\n{}", `invalid Content-Length - ""`},
+		{"bad length", "Content-Length: ten\r\n\r\n{}", `invalid Content-Length - "ten"`},
+		{"negative length", "Content-Length: -1\r\n\r\n{}", `invalid Content-Length - "-1"`},
+		{"short body", "Content-Length: 10\r\n\r\n{}", "unexpected EOF"},
+	}
+	for _, tt := range tests {
+		_, err := Read(bufio.NewReader(strings.NewReader(tt.input)))
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("%s: error = %v, want %s", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Package jsonrpctest drives servers speaking the jsonrpc base protocol
+// in tests.
+package jsonrpctest
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/arikui1911/golan/internal/jsonrpc"
+)
+
+// Client is connected by pipes to a server as an editor would be.
+type Client struct {
+	T   *testing.T
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// Start runs serve on pipes to a new client. When the test ends, hangUp
+// is called to tell the server to stop, anything it still writes is
+// discarded, and an error from serve fails the test.
+func Start(t *testing.T, serve func(r io.Reader, w io.Writer) error, hangUp func()) *Client {
+	cr, sw := io.Pipe()
+	sr, cw := io.Pipe()
+	done := make(chan error)
+	go func() { done <- serve(sr, sw) }()
+	c := &Client{T: t, in: bufio.NewReader(cr), out: cw}
+	t.Cleanup(func() {
+		hangUp()
+		go io.Copy(io.Discard, c.in)
+		if err := <-done; err != nil {
+			t.Errorf("Serve: %v", err)
+		}
+		cw.Close()
+	})
+	return c
+}
+
+// Send writes msg as JSON.
+func (c *Client) Send(msg any) {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		c.T.Fatal(err)
+	}
+	if err := jsonrpc.Write(c.out, b); err != nil {
+		c.T.Fatal(err)
+	}
+}
+
+// Receive reads the next message into msg.
+func (c *Client) Receive(msg any) {
+	b, err := jsonrpc.Read(c.in)
+	if err != nil {
+		c.T.Fatal(err)
+	}
+	if err := json.Unmarshal(b, msg); err != nil {
+		c.T.Fatalf("%v in %s", err, b)
+	}
+}
@@ -0,0 +1,403 @@
+// Package lint reports suspicious constructs in golan syntax trees.
+//
+// Each warning carries the rule that produced it. A comment of the form
+//
+//	# lint:ignore RULE[,RULE...]
+//
+// on the line before a statement, or at the end of it, suppresses those
+// rules for the statement; the rule name all suppresses every rule.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arikui1911/golan"
+)
+
+// Rule identifiers.
+const (
+	AssignInCondition = "assign-in-condition"
+	ShadowBuiltin     = "shadow-builtin"
+	UnreachableElse   = "unreachable-else"
+	UnusedVariable    = "unused-variable"
+)
+
+// Rules lists every rule identifier with a short description.
+var Rules = map[string]string{
+	AssignInCondition: "assignment used as a while or if condition",
+	ShadowBuiltin:     "variable hides a builtin of the same name",
+	UnreachableElse:   "branch of an if whose condition is constant",
+	UnusedVariable:    "local variable assigned but never read",
+}
+
+// Warning is a problem found in the tree.
+type Warning struct {
+	Position *golan.Position
+	Rule     string
+	Message  string
+}
+
+func (w *Warning) String() string {
+	return fmt.Sprintf("%s: %s (%s)", w.Position, w.Message, w.Rule)
+}
+
+// Check returns the warnings for tree ordered by position.
+func Check(tree golan.Node) []*Warning {
+	l := &linter{
+		builtins: map[string]bool{},
+		ignores:  []ignore{},
+		bindings: map[*golan.Identifier]bool{},
+		methods:  map[*golan.Function]bool{},
+	}
+	for _, name := range golan.BuiltinNames() {
+		l.builtins[name] = true
+	}
+	l.run(tree)
+
+	r := []*Warning{}
+	for _, w := range l.warnings {
+		if !l.ignored(w) {
+			r = append(r, w)
+		}
+	}
+	sort.SliceStable(r, func(i, j int) bool {
+		a, b := r[i].Position, r[j].Position
+		if a.FirstLineno != b.FirstLineno {
+			return a.FirstLineno < b.FirstLineno
+		}
+		return a.FirstColumn < b.FirstColumn
+	})
+	return r
+}
+
+// ignore suppresses rules on the lines of one statement.
+type ignore struct {
+	first, last int
+	rules       map[string]bool
+}
+
+type linter struct {
+	builtins map[string]bool
+	warnings []*Warning
+	ignores  []ignore
+	// bindings are the identifiers assigned to rather than read.
+	bindings map[*golan.Identifier]bool
+	// methods are not variables, so they cannot shadow builtins.
+	methods map[*golan.Function]bool
+}
+
+func (l *linter) warn(p *golan.Position, rule string, format string, args ...any) {
+	l.warnings = append(l.warnings, &Warning{p, rule, fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) ignored(w *Warning) bool {
+	for _, ig := range l.ignores {
+		if w.Position.FirstLineno < ig.first || w.Position.FirstLineno > ig.last {
+			continue
+		}
+		if ig.rules[w.Rule] || ig.rules["all"] {
+			return true
+		}
+	}
+	return false
+}
+
+// scope holds the variables of the top level or of one function.
+type scope struct {
+	parent   *scope
+	children []*scope
+	params   map[string]bool
+	// assigns are the identifiers assigned in the scope, in order.
+	assigns []*golan.Identifier
+	reads   []string
+	// vars maps the variables resolved to the scope to their first
+	// assignment, and used tells which of them are read.
+	vars map[string]*golan.Identifier
+	used map[string]bool
+}
+
+func newScope(parent *scope) *scope {
+	s := &scope{parent: parent, params: map[string]bool{}, vars: map[string]*golan.Identifier{}, used: map[string]bool{}}
+	if parent != nil {
+		parent.children = append(parent.children, s)
+	}
+	return s
+}
+
+func (s *scope) binds(name string) bool {
+	_, ok := s.vars[name]
+	return ok || s.params[name]
+}
+
+// owner returns the scope an assignment in s binds name in, as the
+// engine does: an enclosing function already binding it, else the top
+// level when it assigns name, else s.
+func (s *scope) owner(name string, top *scope) *scope {
+	for t := s; t != top; t = t.parent {
+		if t.binds(name) {
+			return t
+		}
+	}
+	if top.binds(name) {
+		return top
+	}
+	return s
+}
+
+// resolve binds the assignments of s and of the functions in it, outer
+// scopes first so that inner ones see what they bind.
+func (s *scope) resolve(top *scope) {
+	for _, id := range s.assigns {
+		t := s.owner(id.Name, top)
+		if !t.binds(id.Name) {
+			t.vars[id.Name] = id
+		}
+	}
+	for _, c := range s.children {
+		c.resolve(top)
+	}
+}
+
+// markReads marks the variables the reads of s and of the functions in
+// it refer to.
+func (s *scope) markReads() {
+	for _, name := range s.reads {
+		for t := s; t != nil; t = t.parent {
+			if t.binds(name) {
+				t.used[name] = true
+				break
+			}
+		}
+	}
+	for _, c := range s.children {
+		c.markReads()
+	}
+}
+
+func (l *linter) reportUnused(s *scope) {
+	for name, id := range s.vars {
+		if !s.used[name] && !strings.HasPrefix(name, "_") {
+			l.warn(id.Position(), UnusedVariable, "%s is assigned but never used", name)
+		}
+	}
+	for _, c := range s.children {
+		l.reportUnused(c)
+	}
+}
+
+func (l *linter) run(tree golan.Node) {
+	top := newScope(nil)
+	l.scan(tree, top)
+	top.resolve(top)
+	top.markReads()
+	// Top-level variables are exported by modules, so only the locals
+	// of functions can be known to be unused.
+	for _, f := range top.children {
+		l.reportUnused(f)
+	}
+}
+
+// scan checks the statements of a function body or of the top level,
+// collecting the variables of s; functions in it get scopes of their
+// own.
+func (l *linter) scan(n golan.Node, s *scope) {
+	bind := func(n golan.Node) {
+		id, ok := n.(*golan.Identifier)
+		if !ok {
+			return
+		}
+		l.bindings[id] = true
+		if l.builtins[id.Name] {
+			l.warn(id.Position(), ShadowBuiltin, "%s shadows the builtin of the same name", id.Name)
+		}
+		s.assigns = append(s.assigns, id)
+	}
+
+	golan.Inspect(n, func(n golan.Node) bool {
+		switch n := n.(type) {
+		case *golan.Block:
+			l.directives(n)
+		case *golan.While:
+			l.condition(n.Condition)
+		case *golan.If:
+			l.condition(n.Test)
+			if v, ok := constant(n.Test); ok {
+				switch {
+				case golan.ValueTest(v) && n.Alt != nil:
+					l.warn(n.Alt.Position(), UnreachableElse, "else branch is unreachable; condition is always true")
+				case !golan.ValueTest(v):
+					l.warn(n.Then.Position(), UnreachableElse, "then branch is unreachable; condition is always false")
+				}
+			}
+		case *golan.Begin:
+			bind(n.Variable)
+		case *golan.For:
+			bind(n.Variable)
+		case *golan.SelectCase:
+			bind(n.Variable)
+		case *golan.Assign:
+			bind(n.Destination)
+		case *golan.Class:
+			if l.builtins[n.Name] {
+				l.warn(n.Position(), ShadowBuiltin, "%s shadows the builtin of the same name", n.Name)
+			}
+			for _, m := range n.Methods() {
+				l.methods[m] = true
+			}
+		case *golan.Function:
+			if n.Name != "" && !l.methods[n] && l.builtins[n.Name] {
+				l.warn(n.Position(), ShadowBuiltin, "%s shadows the builtin of the same name", n.Name)
+			}
+			f := newScope(s)
+			for _, p := range n.Parameters {
+				if l.builtins[p.Name] {
+					l.warn(p.Position(), ShadowBuiltin, "%s shadows the builtin of the same name", p.Name)
+				}
+				f.params[p.Name] = true
+			}
+			l.scan(n.Body, f)
+			return false
+		case *golan.Struct:
+			if l.builtins[n.Name] {
+				l.warn(n.Position(), ShadowBuiltin, "%s shadows the builtin of the same name", n.Name)
+			}
+		case *golan.Identifier:
+			if !l.bindings[n] {
+				s.reads = append(s.reads, n.Name)
+			}
+		}
+		return true
+	})
+}
+
+func (l *linter) condition(n golan.Node) {
+	golan.Inspect(n, func(n golan.Node) bool {
+		if a, ok := n.(*golan.Assign); ok {
+			l.warn(a.Position(), AssignInCondition, "assignment in condition; did you mean ==?")
+		}
+		return true
+	})
+}
+
+// directives records the lint:ignore comments attached to statements of b.
+func (l *linter) directives(b *golan.Block) {
+	for _, s := range b.Statements() {
+		comments := b.LeadingComments(s)
+		if c := b.TrailingComment(s); c != nil {
+			comments = append(comments[:len(comments):len(comments)], c)
+		}
+		for _, c := range comments {
+			rules := parseDirective(c.Text)
+			if rules == nil {
+				continue
+			}
+			p := s.Position()
+			l.ignores = append(l.ignores, ignore{p.FirstLineno, p.LastLineno, rules})
+		}
+	}
+}
+
+func parseDirective(text string) map[string]bool {
+	text = strings.TrimSpace(strings.TrimPrefix(text, "#"))
+	rest, ok := strings.CutPrefix(text, "lint:ignore")
+	if !ok {
+		return nil
+	}
+	rules := map[string]bool{}
+	for _, r := range strings.FieldsFunc(rest, func(c rune) bool { return c == ',' || c == ' ' || c == '\t' }) {
+		rules[r] = true
+	}
+	if len(rules) == 0 {
+		rules["all"] = true
+	}
+	return rules
+}
+
+// constant returns the value of a condition built only from literals and
+// operators.
+func constant(n golan.Node) (golan.Value, bool) {
+	switch n := n.(type) {
+	case *golan.BooleanLiteral:
+		return golan.Boolean(n.Value), true
+	case *golan.IntLiteral:
+		return golan.Integer(n.Value), true
+	case *golan.FloatLiteral:
+		return golan.Float(n.Value), true
+	case *golan.StringLiteral:
+		return golan.String(n.Value), true
+	case *golan.Not:
+		v, ok := constant(n.Expression)
+		if !ok {
+			return nil, false
+		}
+		return golan.Boolean(!golan.ValueTest(v)), true
+	case *golan.Plus:
+		v, ok := constant(n.Expression)
+		if s, isSignable := v.(golan.SignableValue); ok && isSignable {
+			r, err := s.OpPlus()
+			return r, err == nil
+		}
+	case *golan.Minus:
+		v, ok := constant(n.Expression)
+		if s, isSignable := v.(golan.SignableValue); ok && isSignable {
+			r, err := s.OpMinus()
+			return r, err == nil
+		}
+	case *golan.Addition:
+		return fold(n.Left, n.Right, golan.AddValues)
+	case *golan.Subtraction:
+		return fold(n.Left, n.Right, golan.SubtractValues)
+	case *golan.Multiplication:
+		return fold(n.Left, n.Right, golan.MultiplyValues)
+	case *golan.Division:
+		return fold(n.Left, n.Right, golan.DivideValues)
+	case *golan.Modulo:
+		return fold(n.Left, n.Right, golan.ModuloValues)
+	case *golan.Equal:
+		return fold(n.Left, n.Right, compares(golan.CMP_EQ))
+	case *golan.NotEqual:
+		return fold(n.Left, n.Right, compares(golan.CMP_LESS, golan.CMP_GREATER, golan.CMP_NE))
+	case *golan.LessThan:
+		return fold(n.Left, n.Right, compares(golan.CMP_LESS))
+	case *golan.LessThanEqual:
+		return fold(n.Left, n.Right, compares(golan.CMP_LESS, golan.CMP_EQ))
+	case *golan.GreaterThan:
+		return fold(n.Left, n.Right, compares(golan.CMP_GREATER))
+	case *golan.GreaterThanEqual:
+		return fold(n.Left, n.Right, compares(golan.CMP_GREATER, golan.CMP_EQ))
+	}
+	return nil, false
+}
+
+// fold applies op to the constant values of left and right.
+func fold(left golan.Node, right golan.Node, op func(golan.Value, golan.Value) (golan.Value, error)) (golan.Value, bool) {
+	x, ok := constant(left)
+	if !ok {
+		return nil, false
+	}
+	y, ok := constant(right)
+	if !ok {
+		return nil, false
+	}
+	v, err := op(x, y)
+	return v, err == nil
+}
+
+// compares returns an operator telling whether values compare as one of
+// wants.
+func compares(wants ...golan.CompareResult) func(golan.Value, golan.Value) (golan.Value, error) {
+	return func(x golan.Value, y golan.Value) (golan.Value, error) {
+		r, err := golan.CompareValues(x, y)
+		if err != nil {
+			return nil, err
+		}
+		for _, want := range wants {
+			if r == want {
+				return golan.Boolean(true), nil
+			}
+		}
+		return golan.Boolean(false), nil
+	}
+}
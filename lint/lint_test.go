@@ -0,0 +1,67 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"assign in while", "x = 0\nwhile x = 1 {\n  print(x)\n}\n",
+			[]string{"<string>:2:7: assignment in condition; did you mean ==? (assign-in-condition)"}},
+		{"assign in if", "x = 0\nif x = 1 {\n  print(x)\n}\n",
+			[]string{"<string>:2:4: assignment in condition; did you mean ==? (assign-in-condition)"}},
+		{"comparison in if", "x = 0\nif x == 1 {\n  print(x)\n}\n", []string{}},
+		{"shadowed builtin", "len = 1\nprint(len)\n",
+			[]string{"<string>:1:1: len shadows the builtin of the same name (shadow-builtin)"}},
+		{"builtin parameter", "func f(print) {\n  return print\n}\nf(1)\n",
+			[]string{"<string>:1:8: print shadows the builtin of the same name (shadow-builtin)"}},
+		{"builtin function", "func len(x) {\n  return 0\n}\n",
+			[]string{"<string>:1:1: len shadows the builtin of the same name (shadow-builtin)"}},
+		{"builtin method", "class C {\n  func len(self) {\n    return 0\n  }\n}\n", []string{}},
+		{"always true", "if true {\n  print(1)\n} else {\n  print(2)\n}\n",
+			[]string{"<string>:3:8: else branch is unreachable; condition is always true (unreachable-else)"}},
+		{"always false", "if false {\n  print(1)\n}\n",
+			[]string{"<string>:1:10: then branch is unreachable; condition is always false (unreachable-else)"}},
+		{"folded", "if 1 + 1 == 3 {\n  print(1)\n}\n",
+			[]string{"<string>:1:15: then branch is unreachable; condition is always false (unreachable-else)"}},
+		{"variable condition", "x = true\nif x {\n  print(1)\n} else {\n  print(2)\n}\n", []string{}},
+		{"unused local", "func f() {\n  y = 1\n  return 2\n}\nf()\n",
+			[]string{"<string>:2:3: y is assigned but never used (unused-variable)"}},
+		{"used local", "func f() {\n  y = 1\n  return y\n}\nf()\n", []string{}},
+		{"local read by closure", "func f() {\n  y = 1\n  func g() {\n    return y\n  }\n  return g\n}\nf()\n", []string{}},
+		{"top-level export", "answer = 42\n", []string{}},
+		{"ignored on line before", "# lint:ignore shadow-builtin\nlen = 1\nprint(len)\n", []string{}},
+		{"ignored at end", "len = 1 # lint:ignore all\nprint(len)\n", []string{}},
+		{"other rule ignored", "len = 1 # lint:ignore unused-variable\nprint(len)\n",
+			[]string{"<string>:1:1: len shadows the builtin of the same name (shadow-builtin)"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := golan.Parse(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, w := range Check(tree) {
+				got = append(got, w.String())
+				if _, ok := Rules[w.Rule]; !ok {
+					t.Errorf("undocumented rule %s", w.Rule)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("warnings = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("warnings[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package lsp
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/arikui1911/golan"
+	"github.com/arikui1911/golan/lint"
+	"github.com/arikui1911/golan/typecheck"
+)
+
+// document is an open text document and the result of analyzing it.
+type document struct {
+	uri         string
+	text        string
+	lines       []string
+	tree        golan.Node
+	diagnostics []Diagnostic
+	// module is the outermost scope; functions nest scopes in it.
+	module *scope
+	// occurrences are the names bound or referred to in the document, in
+	// source order.
+	occurrences []*occurrence
+}
+
+// scope holds the names bound by the module or by a function: its
+// parameters and the variables first assigned in it.
+type scope struct {
+	parent   *scope
+	node     golan.Node
+	bindings map[string]*binding
+	children []*scope
+}
+
+// binding is a variable, function, struct, class or module bound in a
+// scope. def is the node binding it first.
+type binding struct {
+	name        string
+	def         golan.Node
+	span        *golan.Position
+	occurrences []*occurrence
+}
+
+// occurrence is the span of a name in the source, bound to binding or,
+// when it is nil, to a builtin or nothing.
+type occurrence struct {
+	name    string
+	span    *golan.Position
+	binding *binding
+}
+
+func newDocument(uri string, text string, builtins map[string]bool) *document {
+	d := &document{
+		uri:         uri,
+		text:        text,
+		lines:       strings.Split(text, "\n"),
+		diagnostics: []Diagnostic{},
+	}
+	tree, err := golan.ParseSource(uriToPath(uri), text)
+	if err != nil {
+		var se *golan.SyntaxError
+		r := Range{}
+		if errors.As(err, &se) {
+			r = toRange(se.Position)
+		}
+		d.diagnostics = append(d.diagnostics, Diagnostic{
+			Range:    r,
+			Severity: SeverityError,
+			Source:   "golan",
+			Message:  err.Error(),
+		})
+		return d
+	}
+	d.tree = tree
+	d.collect()
+	d.check(builtins)
+	for _, e := range typecheck.Check(tree) {
+		d.diagnostics = append(d.diagnostics, Diagnostic{
+			Range:    toRange(e.Position),
+			Severity: SeverityError,
+			Source:   "golan-check",
+			Message:  e.Message,
+		})
+	}
+	for _, w := range lint.Check(tree) {
+		d.diagnostics = append(d.diagnostics, Diagnostic{
+			Range:    toRange(w.Position),
+			Severity: SeverityWarning,
+			Code:     w.Rule,
+			Source:   "golan-lint",
+			Message:  w.Message,
+		})
+	}
+	return d
+}
+
+// assignment is a name assigned in a scope, which binds it there unless
+// an enclosing scope binds it already, as the engine assigns it.
+type assignment struct {
+	scope *scope
+	def   golan.Node
+	name  string
+	span  *golan.Position
+}
+
+func (d *document) collect() {
+	d.module = &scope{bindings: map[string]*binding{}}
+	current := d.module
+	assignments := []assignment{}
+	reads := []assignment{}
+	bindings := map[*golan.Identifier]bool{}
+	methods := map[*golan.Function]bool{}
+	assign := func(def golan.Node, name string, span *golan.Position) {
+		assignments = append(assignments, assignment{current, def, name, span})
+	}
+	bindVariable := func(n golan.Node) {
+		if id, ok := n.(*golan.Identifier); ok {
+			bindings[id] = true
+			assign(id, id.Name, id.Position())
+		}
+	}
+	stack := []golan.Node{}
+	golan.Inspect(d.tree, func(n golan.Node) bool {
+		if n == nil {
+			if _, ok := stack[len(stack)-1].(*golan.Function); ok {
+				current = current.parent
+			}
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+		switch n := n.(type) {
+		case *golan.Assign:
+			bindVariable(n.Destination)
+		case *golan.Begin:
+			bindVariable(n.Variable)
+		case *golan.For:
+			bindVariable(n.Variable)
+		case *golan.SelectCase:
+			bindVariable(n.Variable)
+		case *golan.Import:
+			assign(n, n.Name, n.Position())
+		case *golan.Struct:
+			assign(n, n.Name, d.nameSpan(n.Position(), n.Name))
+		case *golan.Class:
+			assign(n, n.Name, d.nameSpan(n.Position(), n.Name))
+			for _, m := range n.Methods() {
+				methods[m] = true
+			}
+		case *golan.Function:
+			if n.Name != "" && !methods[n] {
+				assign(n, n.Name, d.nameSpan(n.Position(), n.Name))
+			}
+			s := &scope{parent: current, node: n, bindings: map[string]*binding{}}
+			current.children = append(current.children, s)
+			current = s
+		case *golan.Parameter:
+			p := *n.Position()
+			p.LastLineno, p.LastColumn = p.FirstLineno, p.FirstColumn+len([]rune(n.Name))-1
+			current.bind(n, n.Name, &p)
+		case *golan.Identifier:
+			if !bindings[n] {
+				reads = append(reads, assignment{current, n, n.Name, n.Position()})
+			}
+		}
+		return true
+	})
+
+	// Assignments in enclosing scopes are resolved first, as a function
+	// usually runs after the module assigns its globals.
+	sort.SliceStable(assignments, func(i, j int) bool {
+		return assignments[i].scope.depth() < assignments[j].scope.depth()
+	})
+	for _, a := range assignments {
+		if b := a.scope.parent.lookup(a.name); b != nil {
+			d.occur(a.name, a.span, b)
+			continue
+		}
+		b := a.scope.bindings[a.name]
+		if b == nil {
+			b = a.scope.bind(a.def, a.name, a.span)
+		}
+		d.occur(a.name, a.span, b)
+	}
+	for _, r := range reads {
+		d.occur(r.name, r.span, r.scope.lookup(r.name))
+	}
+	for _, s := range d.module.all() {
+		for _, b := range s.bindings {
+			d.occurrences = append(d.occurrences, b.occurrences[0])
+		}
+	}
+	sort.SliceStable(d.occurrences, func(i, j int) bool {
+		return before(d.occurrences[i].span, d.occurrences[j].span)
+	})
+}
+
+func (s *scope) bind(def golan.Node, name string, span *golan.Position) *binding {
+	b := &binding{name: name, def: def, span: span}
+	b.occurrences = []*occurrence{{name, span, b}}
+	s.bindings[name] = b
+	return b
+}
+
+// lookup returns the binding of name in s or the scopes enclosing it.
+func (s *scope) lookup(name string) *binding {
+	for ; s != nil; s = s.parent {
+		if b, ok := s.bindings[name]; ok {
+			return b
+		}
+	}
+	return nil
+}
+
+func (s *scope) depth() int {
+	n := 0
+	for ; s.parent != nil; s = s.parent {
+		n++
+	}
+	return n
+}
+
+// all returns s and the scopes nested in it.
+func (s *scope) all() []*scope {
+	r := []*scope{s}
+	for _, c := range s.children {
+		r = append(r, c.all()...)
+	}
+	return r
+}
+
+// occur records a name at span, other than the one of its binding.
+func (d *document) occur(name string, span *golan.Position, b *binding) {
+	if b != nil && b.span == span {
+		return
+	}
+	o := &occurrence{name, span, b}
+	if b != nil {
+		b.occurrences = append(b.occurrences, o)
+	}
+	d.occurrences = append(d.occurrences, o)
+}
+
+// nameSpan locates name in the source of the declaration at p, past its
+// keyword; the tree records the position of the whole declaration only.
+func (d *document) nameSpan(p *golan.Position, name string) *golan.Position {
+	n := []rune(name)
+	for l := p.FirstLineno; l <= p.LastLineno && l <= len(d.lines); l++ {
+		line := []rune(d.lines[l-1])
+		i := 0
+		if l == p.FirstLineno {
+			i = p.FirstColumn - 1
+		}
+		for ; i >= 0 && i+len(n) <= len(line); i++ {
+			if string(line[i:i+len(n)]) != name || (i > 0 && isNameRune(line[i-1])) || (i+len(n) < len(line) && isNameRune(line[i+len(n)])) {
+				continue
+			}
+			return &golan.Position{Source: p.Source, FirstLineno: l, FirstColumn: i + 1, LastLineno: l, LastColumn: i + len(n)}
+		}
+	}
+	return p
+}
+
+func isNameRune(r rune) bool {
+	return r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9')
+}
+
+func before(p *golan.Position, q *golan.Position) bool {
+	if p.FirstLineno != q.FirstLineno {
+		return p.FirstLineno < q.FirstLineno
+	}
+	return p.FirstColumn < q.FirstColumn
+}
+
+// check reports references to names bound nowhere in the document.
+func (d *document) check(builtins map[string]bool) {
+	for _, o := range d.occurrences {
+		if o.binding != nil || builtins[o.name] {
+			continue
+		}
+		d.diagnostics = append(d.diagnostics, Diagnostic{
+			Range:    toRange(o.span),
+			Severity: SeverityWarning,
+			Source:   "golan",
+			Message:  "undefined variable - " + o.name,
+		})
+	}
+}
+
+// occurrenceAt returns the occurrence of a name at p, the innermost if
+// spans nest.
+func (d *document) occurrenceAt(p Position) *occurrence {
+	var found *occurrence
+	for _, o := range d.occurrences {
+		if contains(toRange(o.span), p) && (found == nil || contains(toRange(found.span), toRange(o.span).Start)) {
+			found = o
+		}
+	}
+	return found
+}
+
+// scopeAt returns the innermost scope whose function contains p.
+func (d *document) scopeAt(p Position) *scope {
+	s := d.module
+	for {
+		inner := (*scope)(nil)
+		for _, c := range s.children {
+			if contains(toRange(c.node.Position()), p) {
+				inner = c
+			}
+		}
+		if inner == nil {
+			return s
+		}
+		s = inner
+	}
+}
+
+func (d *document) location(span *golan.Position) Location {
+	return Location{URI: d.uri, Range: toRange(span)}
+}
+
+func contains(r Range, p Position) bool {
+	if p.Line < r.Start.Line || p.Line > r.End.Line {
+		return false
+	}
+	if p.Line == r.Start.Line && p.Character < r.Start.Character {
+		return false
+	}
+	if p.Line == r.End.Line && p.Character > r.End.Character {
+		return false
+	}
+	return true
+}
+
+// toRange converts an inclusive 1-origin golan position to an exclusive
+// 0-origin protocol range.
+func toRange(p *golan.Position) Range {
+	r := Range{
+		Start: Position{p.FirstLineno - 1, p.FirstColumn - 1},
+		End:   Position{p.LastLineno - 1, p.LastColumn},
+	}
+	if r.Start.Character < 0 {
+		r.Start.Character = 0
+	}
+	return r
+}
+
+func uriToPath(uri string) string {
+	return strings.TrimPrefix(uri, "file://")
+}
+
+// wholeRange spans all of text.
+func wholeRange(text string) Range {
+	lines := strings.Split(text, "\n")
+	return Range{
+		Start: Position{0, 0},
+		End:   Position{len(lines) - 1, len(lines[len(lines)-1])},
+	}
+}
@@ -0,0 +1,326 @@
+// Package lsp implements a Language Server Protocol server for golan
+// scripts over a JSON-RPC stream.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/arikui1911/golan"
+	"github.com/arikui1911/golan/internal/jsonrpc"
+)
+
+// Server serves one client connection.
+type Server struct {
+	in        *bufio.Reader
+	out       io.Writer
+	mu        sync.Mutex
+	documents map[string]*document
+	builtins  map[string]bool
+	shutdown  bool
+}
+
+// NewServer returns a server reading requests from r and writing
+// responses and notifications to w.
+func NewServer(r io.Reader, w io.Writer) *Server {
+	s := &Server{
+		in:        bufio.NewReader(r),
+		out:       w,
+		documents: map[string]*document{},
+		builtins:  map[string]bool{},
+	}
+	for _, name := range golan.BuiltinNames() {
+		s.builtins[name] = true
+	}
+	return s
+}
+
+// Serve handles messages until the client sends exit or closes the
+// stream.
+func (s *Server) Serve() error {
+	for {
+		msg, err := s.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+		s.handle(msg)
+	}
+}
+
+func (s *Server) read() (*message, error) {
+	body, err := jsonrpc.Read(s.in)
+	if err != nil {
+		return nil, err
+	}
+	msg := &message{}
+	if err := json.Unmarshal(body, msg); err != nil {
+		s.reply(nil, nil, &responseError{codeParseError, err.Error()})
+		return msg, nil
+	}
+	return msg, nil
+}
+
+func (s *Server) write(msg *message) {
+	msg.JSONRPC = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jsonrpc.Write(s.out, body)
+}
+
+func (s *Server) reply(id json.RawMessage, result any, e *responseError) {
+	if e == nil && result == nil {
+		result = json.RawMessage("null")
+	}
+	s.write(&message{ID: id, Result: result, Error: e})
+}
+
+func (s *Server) notify(method string, params any) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return
+	}
+	s.write(&message{Method: method, Params: b})
+}
+
+type handler func(s *Server, params json.RawMessage) (any, error)
+
+var requests = map[string]handler{
+	"initialize":              (*Server).initialize,
+	"shutdown":                (*Server).shutdownRequest,
+	"textDocument/definition": (*Server).definition,
+	"textDocument/references": (*Server).references,
+	"textDocument/hover":      (*Server).hover,
+	"textDocument/completion": (*Server).completion,
+	"textDocument/formatting": (*Server).formatting,
+}
+
+var notifications = map[string]func(s *Server, params json.RawMessage) error{
+	"textDocument/didOpen":   (*Server).didOpen,
+	"textDocument/didChange": (*Server).didChange,
+	"textDocument/didClose":  (*Server).didClose,
+}
+
+func (s *Server) handle(msg *message) {
+	if msg.ID == nil {
+		if f, ok := notifications[msg.Method]; ok {
+			f(s, msg.Params)
+		}
+		return
+	}
+	f, ok := requests[msg.Method]
+	if !ok {
+		s.reply(msg.ID, nil, &responseError{codeMethodNotFound, "method not found - " + msg.Method})
+		return
+	}
+	result, err := f(s, msg.Params)
+	if err != nil {
+		s.reply(msg.ID, nil, &responseError{codeInvalidParams, err.Error()})
+		return
+	}
+	s.reply(msg.ID, result, nil)
+}
+
+func (s *Server) initialize(json.RawMessage) (any, error) {
+	return map[string]any{
+		"capabilities": map[string]any{
+			"textDocumentSync":           1,
+			"definitionProvider":         true,
+			"referencesProvider":         true,
+			"hoverProvider":              true,
+			"completionProvider":         map[string]any{},
+			"documentFormattingProvider": true,
+		},
+		"serverInfo": map[string]any{"name": "golan"},
+	}, nil
+}
+
+func (s *Server) shutdownRequest(json.RawMessage) (any, error) {
+	s.shutdown = true
+	return nil, nil
+}
+
+func (s *Server) open(uri string, text string) {
+	d := newDocument(uri, text, s.builtins)
+	s.documents[uri] = d
+	s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{uri, d.diagnostics})
+}
+
+func (s *Server) didOpen(raw json.RawMessage) error {
+	var params DidOpenTextDocumentParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return err
+	}
+	s.open(params.TextDocument.URI, params.TextDocument.Text)
+	return nil
+}
+
+func (s *Server) didChange(raw json.RawMessage) error {
+	var params DidChangeTextDocumentParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return err
+	}
+	if n := len(params.ContentChanges); n > 0 {
+		s.open(params.TextDocument.URI, params.ContentChanges[n-1].Text)
+	}
+	return nil
+}
+
+func (s *Server) didClose(raw json.RawMessage) error {
+	var params DidCloseTextDocumentParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return err
+	}
+	delete(s.documents, params.TextDocument.URI)
+	s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{params.TextDocument.URI, []Diagnostic{}})
+	return nil
+}
+
+func (s *Server) document(raw json.RawMessage, params any) (*document, error) {
+	if err := json.Unmarshal(raw, params); err != nil {
+		return nil, err
+	}
+	var p struct {
+		TextDocument TextDocumentIdentifier `json:"textDocument"`
+	}
+	json.Unmarshal(raw, &p)
+	d, ok := s.documents[p.TextDocument.URI]
+	if !ok {
+		return nil, fmt.Errorf("document not open - %s", p.TextDocument.URI)
+	}
+	return d, nil
+}
+
+func (s *Server) definition(raw json.RawMessage) (any, error) {
+	var params TextDocumentPositionParams
+	d, err := s.document(raw, &params)
+	if err != nil {
+		return nil, err
+	}
+	o := d.occurrenceAt(params.Position)
+	if o == nil || o.binding == nil {
+		return nil, nil
+	}
+	return d.location(o.binding.span), nil
+}
+
+func (s *Server) references(raw json.RawMessage) (any, error) {
+	var params ReferenceParams
+	d, err := s.document(raw, &params)
+	if err != nil {
+		return nil, err
+	}
+	locations := []Location{}
+	o := d.occurrenceAt(params.Position)
+	if o == nil || o.binding == nil {
+		return locations, nil
+	}
+	for _, r := range o.binding.occurrences {
+		if r.span == o.binding.span && !params.Context.IncludeDeclaration {
+			continue
+		}
+		locations = append(locations, d.location(r.span))
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		a, b := locations[i].Range.Start, locations[j].Range.Start
+		return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+	})
+	return locations, nil
+}
+
+func (s *Server) hover(raw json.RawMessage) (any, error) {
+	var params TextDocumentPositionParams
+	d, err := s.document(raw, &params)
+	if err != nil {
+		return nil, err
+	}
+	o := d.occurrenceAt(params.Position)
+	if o == nil {
+		return nil, nil
+	}
+	r := toRange(o.span)
+	var text string
+	if o.binding != nil {
+		text = fmt.Sprintf("%s (defined at %s)", o.name, o.binding.span)
+	} else if sig, ok := golan.BuiltinSignature(o.name); ok {
+		text = sig
+	} else {
+		return nil, nil
+	}
+	return Hover{MarkupContent{"plaintext", text}, &r}, nil
+}
+
+// completion offers the names visible in the scope at the position, the
+// innermost binding of each, and the builtins none of them shadows.
+func (s *Server) completion(raw json.RawMessage) (any, error) {
+	var params TextDocumentPositionParams
+	d, err := s.document(raw, &params)
+	if err != nil {
+		return nil, err
+	}
+	items := []CompletionItem{}
+	seen := map[string]bool{}
+	for sc := d.scopeAt(params.Position); sc != nil; sc = sc.parent {
+		for name, b := range sc.bindings {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			kind := CompletionKindVariable
+			switch b.def.(type) {
+			case *golan.Import:
+				kind = CompletionKindModule
+			case *golan.Function:
+				kind = CompletionKindFunction
+			case *golan.Struct:
+				kind = CompletionKindStruct
+			case *golan.Class:
+				kind = CompletionKindClass
+			}
+			items = append(items, CompletionItem{Label: name, Kind: kind})
+		}
+	}
+	for name := range s.builtins {
+		if seen[name] {
+			continue
+		}
+		sig, _ := golan.BuiltinSignature(name)
+		kind := CompletionKindVariable
+		if strings.Contains(sig, "(") {
+			kind = CompletionKindFunction
+		}
+		items = append(items, CompletionItem{Label: name, Kind: kind, Detail: sig})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Label < items[j].Label })
+	return items, nil
+}
+
+func (s *Server) formatting(raw json.RawMessage) (any, error) {
+	var params DocumentFormattingParams
+	d, err := s.document(raw, &params)
+	if err != nil {
+		return nil, err
+	}
+	out, err := golan.Format([]byte(d.text))
+	if err != nil {
+		return nil, err
+	}
+	if string(out) == d.text {
+		return []TextEdit{}, nil
+	}
+	return []TextEdit{{Range: wholeRange(d.text), NewText: string(out)}}, nil
+}
@@ -0,0 +1,227 @@
+package lsp
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/arikui1911/golan/internal/jsonrpc/jsonrpctest"
+)
+
+const testURI = "file:///test.gl"
+
+const testSource = `x = 1
+func f(a) {
+  y = a + x
+  return y
+}
+func g(a, b) {
+  return f(a) + b
+}
+print(g(x, 2))
+`
+
+// client sends requests and notifications with ids of its own.
+type client struct {
+	*jsonrpctest.Client
+	id int
+}
+
+func newClient(t *testing.T) *client {
+	c := &client{}
+	c.Client = jsonrpctest.Start(t, func(r io.Reader, w io.Writer) error {
+		return NewServer(r, w).Serve()
+	}, func() { c.notify("exit", nil) })
+	return c
+}
+
+func (c *client) send(msg map[string]any) {
+	msg["jsonrpc"] = "2.0"
+	c.Send(msg)
+}
+
+func (c *client) receive() *message {
+	msg := &message{}
+	c.Receive(msg)
+	return msg
+}
+
+func (c *client) notify(method string, params any) {
+	c.send(map[string]any{"method": method, "params": params})
+}
+
+// request sends a request and decodes the result of its response into
+// result.
+func (c *client) request(method string, params any, result any) {
+	c.T.Helper()
+	c.id++
+	c.send(map[string]any{"id": c.id, "method": method, "params": params})
+	msg := c.receive()
+	if string(msg.ID) != strconv.Itoa(c.id) {
+		c.T.Fatalf("%s: response id = %s, want %d", method, msg.ID, c.id)
+	}
+	if msg.Error != nil {
+		c.T.Fatalf("%s: %s", method, msg.Error.Message)
+	}
+	b, err := json.Marshal(msg.Result)
+	if err != nil {
+		c.T.Fatal(err)
+	}
+	if err := json.Unmarshal(b, result); err != nil {
+		c.T.Fatalf("%s: %v in %s", method, err, b)
+	}
+}
+
+// open opens a document and returns the diagnostics published for it.
+func (c *client) open(text string) []Diagnostic {
+	c.notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": testURI, "text": text},
+	})
+	msg := c.receive()
+	if msg.Method != "textDocument/publishDiagnostics" {
+		c.T.Fatalf("got %q, want publishDiagnostics", msg.Method)
+	}
+	var params PublishDiagnosticsParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		c.T.Fatal(err)
+	}
+	return params.Diagnostics
+}
+
+func at(line, character int) map[string]any {
+	return map[string]any{
+		"textDocument": map[string]any{"uri": testURI},
+		"position":     Position{line, character},
+	}
+}
+
+func startedClient(t *testing.T) *client {
+	c := newClient(t)
+	var init struct {
+		Capabilities map[string]any `json:"capabilities"`
+	}
+	c.request("initialize", map[string]any{}, &init)
+	for _, name := range []string{"definitionProvider", "hoverProvider", "completionProvider"} {
+		if _, ok := init.Capabilities[name]; !ok {
+			t.Errorf("initialize: no %s", name)
+		}
+	}
+	if d := c.open(testSource); len(d) != 0 {
+		t.Errorf("diagnostics = %v, want none", d)
+	}
+	return c
+}
+
+func TestDefinition(t *testing.T) {
+	c := startedClient(t)
+	tests := []struct {
+		name     string
+		position Position
+		want     *Range
+	}{
+		{"parameter of f", Position{2, 6}, &Range{Position{1, 7}, Position{1, 8}}},
+		{"parameter of g", Position{6, 11}, &Range{Position{5, 7}, Position{5, 8}}},
+		{"global", Position{2, 10}, &Range{Position{0, 0}, Position{0, 1}}},
+		{"local", Position{3, 9}, &Range{Position{2, 2}, Position{2, 3}}},
+		{"function name", Position{6, 9}, &Range{Position{1, 5}, Position{1, 6}}},
+		{"builtin", Position{8, 2}, nil},
+	}
+	for _, tt := range tests {
+		var loc *Location
+		c.request("textDocument/definition", at(tt.position.Line, tt.position.Character), &loc)
+		switch {
+		case tt.want == nil && loc != nil:
+			t.Errorf("%s: definition = %v, want none", tt.name, loc.Range)
+		case tt.want != nil && loc == nil:
+			t.Errorf("%s: no definition, want %v", tt.name, *tt.want)
+		case tt.want != nil && (loc.URI != testURI || loc.Range != *tt.want):
+			t.Errorf("%s: definition = %s %v, want %v", tt.name, loc.URI, loc.Range, *tt.want)
+		}
+	}
+}
+
+func TestReferences(t *testing.T) {
+	c := startedClient(t)
+	params := at(5, 7)
+	params["context"] = map[string]any{"includeDeclaration": true}
+	var locs []Location
+	c.request("textDocument/references", params, &locs)
+	want := []Range{
+		{Position{5, 7}, Position{5, 8}},
+		{Position{6, 11}, Position{6, 12}},
+	}
+	if len(locs) != len(want) {
+		t.Fatalf("references = %v, want %v", locs, want)
+	}
+	for i, l := range locs {
+		if l.Range != want[i] {
+			t.Errorf("references[%d] = %v, want %v", i, l.Range, want[i])
+		}
+	}
+}
+
+func TestHover(t *testing.T) {
+	c := startedClient(t)
+	var h *Hover
+	c.request("textDocument/hover", at(6, 9), &h)
+	if h == nil || h.Contents.Value != "f (defined at /test.gl:2:6)" {
+		t.Errorf("hover on f = %+v", h)
+	}
+	h = nil
+	c.request("textDocument/hover", at(8, 0), &h)
+	if h == nil || !strings.HasPrefix(h.Contents.Value, "print(") {
+		t.Errorf("hover on print = %+v", h)
+	}
+	h = nil
+	c.request("textDocument/hover", at(4, 0), &h)
+	if h != nil {
+		t.Errorf("hover on } = %+v, want none", h)
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	c := startedClient(t)
+	tests := []struct {
+		name     string
+		position Position
+		want     []string
+		unwanted []string
+	}{
+		{"in f", Position{3, 2}, []string{"a", "f", "g", "print", "x", "y"}, []string{"b"}},
+		{"in g", Position{6, 2}, []string{"a", "b", "f", "g", "x"}, []string{"y"}},
+		{"in module", Position{8, 0}, []string{"f", "g", "print", "x"}, []string{"a", "b", "y"}},
+	}
+	for _, tt := range tests {
+		var items []CompletionItem
+		c.request("textDocument/completion", at(tt.position.Line, tt.position.Character), &items)
+		labels := map[string]CompletionItem{}
+		for _, item := range items {
+			labels[item.Label] = item
+		}
+		for _, name := range tt.want {
+			if _, ok := labels[name]; !ok {
+				t.Errorf("%s: no completion %q", tt.name, name)
+			}
+		}
+		for _, name := range tt.unwanted {
+			if _, ok := labels[name]; ok {
+				t.Errorf("%s: unexpected completion %q", tt.name, name)
+			}
+		}
+		if item := labels["f"]; item.Kind != CompletionKindFunction {
+			t.Errorf("%s: kind of f = %d, want %d", tt.name, item.Kind, CompletionKindFunction)
+		}
+	}
+}
+
+func TestUndefinedVariable(t *testing.T) {
+	c := newClient(t)
+	var init map[string]any
+	c.request("initialize", map[string]any{}, &init)
+	d := c.open("func f(a) {\n  return a\n}\nprint(a)\n")
+	if len(d) != 1 || d[0].Message != "undefined variable - a" || d[0].Range.Start != (Position{3, 6}) {
+		t.Errorf("diagnostics = %+v, want undefined a at 3:6", d)
+	}
+}
@@ -0,0 +1,211 @@
+package golan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Module is a namespace made of the top-level bindings of an imported
+// script or of a host-registered native module.
+type Module struct {
+	Name string
+	Path string
+	env  map[string]Value
+	// vars guards env for script modules, whose functions may assign
+	// their globals.
+	vars *sync.RWMutex
+}
+
+func NewModule(name string, bindings map[string]Value) *Module {
+	env := map[string]Value{}
+	for k, v := range bindings {
+		env[k] = v
+	}
+	return &Module{Name: name, env: env}
+}
+
+func (m *Module) String() string { return fmt.Sprintf("#<module %s>", m.Name) }
+
+func (m *Module) OpGetAttr(name string) (Value, error) {
+	if m.vars != nil {
+		m.vars.RLock()
+		defer m.vars.RUnlock()
+	}
+	v, ok := m.env[name]
+	if !ok {
+		return nil, fmt.Errorf("undefined attribute of module %s - %s", m.Name, name)
+	}
+	return v, nil
+}
+
+// moduleLoader is shared by an engine and its tasks. A module imported by
+// two tasks at once may run twice; the first to finish is kept.
+type moduleLoader struct {
+	mu     sync.Mutex
+	paths  []string
+	native map[string]*Module
+	loaded map[string]*Module
+	cache  *programCache
+}
+
+func newModuleLoader() *moduleLoader {
+	return &moduleLoader{
+		native: map[string]*Module{},
+		loaded: map[string]*Module{},
+		cache:  &programCache{programs: map[string]*Program{}},
+	}
+}
+
+// fork returns a loader with the paths and registered modules of l and the
+// same cache, but nothing loaded.
+func (l *moduleLoader) fork() *moduleLoader {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	native := map[string]*Module{}
+	for k, m := range l.native {
+		native[k] = m
+	}
+	return &moduleLoader{
+		paths:  append([]string{}, l.paths...),
+		native: native,
+		loaded: map[string]*Module{},
+		cache:  l.cache,
+	}
+}
+
+// RegisterModule makes bindings importable under path. Registered modules
+// take precedence over script files.
+func (e *Engine) RegisterModule(path string, bindings map[string]Value) {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	m := NewModule(name, bindings)
+	m.Path = path
+	e.modules.mu.Lock()
+	defer e.modules.mu.Unlock()
+	e.modules.native[path] = m
+}
+
+// AddModulePath appends dir to the directories searched by import after
+// the directory of the importing script.
+func (e *Engine) AddModulePath(dir string) {
+	e.modules.mu.Lock()
+	defer e.modules.mu.Unlock()
+	e.modules.paths = append(e.modules.paths, dir)
+}
+
+// get looks key up in modules, one of the maps of l.
+func (l *moduleLoader) get(modules map[string]*Module, key string) (*Module, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	m, ok := modules[key]
+	return m, ok
+}
+
+// store records m as loaded from file unless another task got there first,
+// and returns the module to use.
+func (l *moduleLoader) store(file string, m *Module) *Module {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if x, ok := l.loaded[file]; ok {
+		return x
+	}
+	l.loaded[file] = m
+	return m
+}
+
+func (l *moduleLoader) resolve(name string, from string) (string, error) {
+	if filepath.Ext(name) == "" {
+		name += ".gl"
+	}
+	dirs := []string{""}
+	if !filepath.IsAbs(name) {
+		l.mu.Lock()
+		dirs = append([]string{filepath.Dir(from)}, l.paths...)
+		l.mu.Unlock()
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return filepath.Abs(path)
+		}
+	}
+	return "", fmt.Errorf("cannot find module - %s", name)
+}
+
+// entryFiles returns the absolute paths of the script files the
+// statements of tree come from.
+func entryFiles(tree Node) []string {
+	statements := []Node{tree}
+	if b, ok := tree.(*Block); ok {
+		statements = b.statements
+	}
+	files := []string{}
+	for _, s := range statements {
+		p := s.Position()
+		if p == nil {
+			continue
+		}
+		path, err := filepath.Abs(p.Source)
+		if err != nil {
+			continue
+		}
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			continue
+		}
+		if len(files) == 0 || files[len(files)-1] != path {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+func (e *Engine) importModule(i *Import) (*Module, error) {
+	l := e.modules
+	if m, ok := l.get(l.native, i.Path); ok {
+		return m, nil
+	}
+	file, err := l.resolve(i.Path, i.position.Source)
+	if err != nil {
+		return nil, errorAt(err, "ImportError", i.position)
+	}
+	if m, ok := l.get(l.loaded, file); ok {
+		return m, nil
+	}
+	for k, f := range e.importing {
+		if f == file {
+			cycle := append(append([]string{}, e.importing[k:]...), file)
+			return nil, newError(i.position, "ImportError", "circular import - %s", strings.Join(cycle, " -> "))
+		}
+	}
+	prog, err := l.cache.compile(file)
+	if err != nil {
+		return nil, errorAt(err, "ImportError", i.position)
+	}
+
+	if e.coverage != nil {
+		e.coverage.Add(prog.tree)
+	}
+	sub := &Engine{
+		builtins:  e.builtins,
+		env:       map[string]Value{},
+		vars:      e.vars,
+		importing: append(append([]string{}, e.importing...), file),
+		modules:   l,
+		hook:      e.hook,
+		stack:     e.stack,
+		profiler:  e.profiler,
+		coverage:  e.coverage,
+		stdout:    e.stdout,
+		stderr:    e.stderr,
+		maxDepth:  e.maxDepth,
+	}
+	e.pushFrame("<module " + i.Name + ">")
+	_, err = sub.Run(prog)
+	e.stack.pop()
+	if err != nil {
+		return nil, err
+	}
+	return l.store(file, &Module{Name: i.Name, Path: file, env: sub.env, vars: e.vars}), nil
+}
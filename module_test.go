@@ -0,0 +1,87 @@
+package golan_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+// writeFiles writes files, named relative to dir, and returns dir.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// runFile runs the script at path on e and returns what it printed and
+// the error it failed with.
+func runFile(t *testing.T, e *golan.Engine, path string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	e.SetOutput(&out)
+	tree, err := golan.ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = e.Execute(tree)
+	return out.String(), err
+}
+
+func TestImport(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"main.gl":       "import \"lib/shapes\"\nimport \"util\"\nimport \"host\"\nprint(shapes.area(2, 3))\nprint(util.name)\nprint(host.answer)\n",
+		"lib/shapes.gl": "import \"helper\"\nfunc area(w, h) {\n  return helper.mul(w, h)\n}\n",
+		"lib/helper.gl": "func mul(x, y) {\n  return x * y\n}\n",
+		"path/util.gl":  "name = \"util\"\n",
+	})
+	e := golan.NewEngine()
+	e.AddModulePath(filepath.Join(dir, "path"))
+	e.RegisterModule("host", map[string]golan.Value{"answer": golan.Integer(42)})
+	out, err := runFile(t, e, filepath.Join(dir, "main.gl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "6\nutil\n42\n" {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"self.gl":    "print(\"body\")\nimport \"self\"\n",
+		"a.gl":       "import \"b\"\n",
+		"b.gl":       "import \"a\"\n",
+		"missing.gl": "import \"nowhere\"\n",
+	})
+	tests := []struct {
+		file    string
+		message string
+	}{
+		{"self.gl", "circular import - " + filepath.Join(dir, "self.gl") + " -> " + filepath.Join(dir, "self.gl")},
+		{"a.gl", "circular import - " + filepath.Join(dir, "a.gl") + " -> " + filepath.Join(dir, "b.gl") + " -> " + filepath.Join(dir, "a.gl")},
+		{"missing.gl", "cannot find module - nowhere.gl"},
+	}
+	for _, tt := range tests {
+		out, err := runFile(t, golan.NewEngine(), filepath.Join(dir, tt.file))
+		x, ok := err.(*golan.Error)
+		if !ok || x.Kind != "ImportError" || x.Message != tt.message {
+			t.Errorf("%s: error = %v, want ImportError: %s", tt.file, err, tt.message)
+		}
+		if strings.Count(out, "body") > 1 {
+			t.Errorf("%s: the script ran again before the cycle was found", tt.file)
+		}
+	}
+}
@@ -1,8 +1,18 @@
 package golan
 
-import "strings"
+import (
+	"fmt"
+	"os"
+	"strings"
+)
 
 func Parse(src string) (tree Node, err error) {
+	return ParseSource("<string>", src)
+}
+
+// ParseSource parses src; name is recorded as the Source of every position
+// in the tree.
+func ParseSource(name string, src string) (tree Node, err error) {
 	if !strings.HasSuffix(src, "\n") {
 		src += "\n"
 	}
@@ -14,11 +24,86 @@ func Parse(src string) (tree Node, err error) {
 		}
 	}()
 	p.Init()
-	p.ASTBuilderInit(p.Buffer)
+	p.ASTBuilderInit(name, p.Buffer)
 	if err := p.Parse(); err != nil {
-		p.Raise(err)
+		p.Raise(newSyntaxError(name, err))
 	}
 	p.Execute()
 	tree = p.Finish()
 	return
 }
+
+func ParseFile(path string) (Node, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSource(path, string(src))
+}
+
+// ParseFiles parses each file and joins them into one program. Statements
+// run in the given order and keep the positions of their own files.
+func ParseFiles(paths ...string) (Node, error) {
+	program := &Block{statements: []Node{}}
+	for _, path := range paths {
+		tree, err := ParseFile(path)
+		if err != nil {
+			return nil, err
+		}
+		root := tree.(*Block)
+		program.statements = append(program.statements, root.statements...)
+		program.comments = append(program.comments, root.comments...)
+		for s, cs := range root.leading {
+			if program.leading == nil {
+				program.leading = map[Node][]*Comment{}
+			}
+			program.leading[s] = cs
+		}
+		for s, c := range root.trailing {
+			if program.trailing == nil {
+				program.trailing = map[Node]*Comment{}
+			}
+			program.trailing[s] = c
+		}
+		program.dangling = append(program.dangling, root.dangling...)
+	}
+	return program, nil
+}
+
+// SyntaxError reports the farthest point the parser could reach, or a
+// construct the grammar accepts but the language does not, which Message
+// then describes.
+type SyntaxError struct {
+	Position *Position
+	Near     string
+	Message  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Position, e.Reason())
+}
+
+// Reason is the message of the error, without its position.
+func (e *SyntaxError) Reason() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return fmt.Sprintf("syntax error near %q", e.Near)
+}
+
+func newSyntaxError(name string, err error) error {
+	e, ok := err.(*parseError)
+	if !ok {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	begin, end := int(e.max.begin), int(e.max.end)
+	t := translatePositions(e.p.buffer, []int{begin, end})
+	return &SyntaxError{
+		Position: &Position{
+			name,
+			t[begin].line, t[begin].symbol,
+			t[end].line, t[end].symbol,
+		},
+		Near: string(e.p.buffer[begin:end]),
+	}
+}
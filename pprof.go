@@ -0,0 +1,187 @@
+package golan
+
+import (
+	"compress/gzip"
+	"io"
+	"sort"
+	"time"
+)
+
+// WritePprof writes the profile in the gzipped protocol buffer format read
+// by `go tool pprof`. Each sample is a call stack of golan functions with
+// one location per executing line, valued by the number of intervals and
+// the time charged to it.
+func (p *Profiler) WritePprof(w io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	b := &pprofBuilder{
+		strings:   map[string]int64{"": 0},
+		table:     []string{""},
+		functions: map[[2]string]uint64{},
+		locations: map[locationKey]uint64{},
+	}
+	var prof protobuf
+	// sample_type and period_type
+	prof.message(1, b.valueType("samples", "count"))
+	prof.message(1, b.valueType("time", "nanoseconds"))
+
+	keys := []string{}
+	for k := range p.samples {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		s := p.samples[k]
+		var sample protobuf
+		ids := []uint64{}
+		for _, f := range s.frames {
+			ids = append(ids, b.location(f))
+		}
+		sample.packedUints(1, ids)
+		sample.packedInts(2, []int64{s.count, int64(s.duration)})
+		prof.message(2, sample)
+	}
+	for _, l := range b.locs {
+		prof.message(4, l)
+	}
+	for _, f := range b.funcs {
+		prof.message(5, f)
+	}
+	for _, s := range b.table {
+		prof.bytes(6, []byte(s))
+	}
+	prof.int(9, p.start.UnixNano())
+	prof.int(10, int64(time.Since(p.start)))
+	prof.message(11, b.valueType("time", "nanoseconds"))
+	prof.int(12, 1)
+	prof.int(14, b.str("time"))
+
+	z := gzip.NewWriter(w)
+	if _, err := z.Write(prof); err != nil {
+		return err
+	}
+	return z.Close()
+}
+
+type locationKey struct {
+	function string
+	source   string
+	line     int
+}
+
+type pprofBuilder struct {
+	strings   map[string]int64
+	table     []string
+	functions map[[2]string]uint64
+	funcs     []protobuf
+	locations map[locationKey]uint64
+	locs      []protobuf
+}
+
+func (b *pprofBuilder) str(s string) int64 {
+	if i, ok := b.strings[s]; ok {
+		return i
+	}
+	i := int64(len(b.table))
+	b.strings[s] = i
+	b.table = append(b.table, s)
+	return i
+}
+
+func (b *pprofBuilder) valueType(typ string, unit string) protobuf {
+	var m protobuf
+	m.int(1, b.str(typ))
+	m.int(2, b.str(unit))
+	return m
+}
+
+func (b *pprofBuilder) function(name string, source string, line int) uint64 {
+	k := [2]string{name, source}
+	if id, ok := b.functions[k]; ok {
+		return id
+	}
+	id := uint64(len(b.funcs) + 1)
+	b.functions[k] = id
+	var m protobuf
+	m.uint(1, id)
+	m.int(2, b.str(name))
+	m.int(3, b.str(name))
+	m.int(4, b.str(source))
+	m.int(5, int64(line))
+	b.funcs = append(b.funcs, m)
+	return id
+}
+
+func (b *pprofBuilder) location(f Frame) uint64 {
+	k := locationKey{function: f.Function}
+	if f.Position != nil {
+		k.source, k.line = f.Position.Source, f.Position.FirstLineno
+	}
+	if id, ok := b.locations[k]; ok {
+		return id
+	}
+	id := uint64(len(b.locs) + 1)
+	b.locations[k] = id
+	var line protobuf
+	line.uint(1, b.function(k.function, k.source, k.line))
+	line.int(2, int64(k.line))
+	var m protobuf
+	m.uint(1, id)
+	m.message(4, line)
+	b.locs = append(b.locs, m)
+	return id
+}
+
+// protobuf is an encoded protocol buffer message.
+type protobuf []byte
+
+func (m *protobuf) varint(x uint64) {
+	for x >= 0x80 {
+		*m = append(*m, byte(x)|0x80)
+		x >>= 7
+	}
+	*m = append(*m, byte(x))
+}
+
+func (m *protobuf) key(field int, wire int) {
+	m.varint(uint64(field)<<3 | uint64(wire))
+}
+
+func (m *protobuf) uint(field int, x uint64) {
+	if x == 0 {
+		return
+	}
+	m.key(field, 0)
+	m.varint(x)
+}
+
+func (m *protobuf) int(field int, x int64) {
+	m.uint(field, uint64(x))
+}
+
+func (m *protobuf) bytes(field int, b []byte) {
+	m.key(field, 2)
+	m.varint(uint64(len(b)))
+	*m = append(*m, b...)
+}
+
+func (m *protobuf) message(field int, x protobuf) {
+	m.bytes(field, x)
+}
+
+func (m *protobuf) packedUints(field int, xs []uint64) {
+	var p protobuf
+	for _, x := range xs {
+		p.varint(x)
+	}
+	m.bytes(field, p)
+}
+
+func (m *protobuf) packedInts(field int, xs []int64) {
+	var p protobuf
+	for _, x := range xs {
+		p.varint(uint64(x))
+	}
+	m.bytes(field, p)
+}
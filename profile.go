@@ -0,0 +1,219 @@
+package golan
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Profiler measures where an engine spends its time. Time between two
+// consecutive events (a statement starting, a function being entered or
+// left) is charged to the call stack as it was at the first of them, so
+// every line gets its self time and every stack its exclusive time.
+//
+// Every task keeps a call stack, and so a timeline, of its own: the time
+// of tasks running side by side adds up, and a task blocked in a native
+// function such as recv is charged for the wait. Generators are charged
+// only while they run.
+type Profiler struct {
+	mu        sync.Mutex
+	start     time.Time
+	lines     map[lineKey]*LineProfile
+	functions map[string]*FunctionProfile
+	samples   map[string]*stackSample
+}
+
+type lineKey struct {
+	source string
+	line   int
+}
+
+// LineProfile is the time spent executing statements starting on a line.
+type LineProfile struct {
+	Source   string
+	Line     int
+	Count    int
+	Duration time.Duration
+}
+
+// FunctionProfile is the time spent in calls of a function, including
+// the functions it calls.
+type FunctionProfile struct {
+	Name     string
+	Native   bool
+	Calls    int
+	Duration time.Duration
+}
+
+// stackSample is the time charged to one call stack.
+type stackSample struct {
+	frames   []Frame
+	count    int64
+	duration time.Duration
+}
+
+func NewProfiler() *Profiler {
+	now := time.Now()
+	return &Profiler{
+		start:     now,
+		lines:     map[lineKey]*LineProfile{},
+		functions: map[string]*FunctionProfile{},
+		samples:   map[string]*stackSample{},
+	}
+}
+
+// SetProfiler makes e report to p, or stops profiling when p is nil.
+func (e *Engine) SetProfiler(p *Profiler) {
+	e.profiler = p
+}
+
+// mark charges the time since the previous event on the stack s to it.
+func (p *Profiler) mark(s *callStack) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	var d time.Duration
+	if !s.last.IsZero() {
+		d = now.Sub(s.last)
+	}
+	s.last = now
+
+	frames := []Frame{}
+	keys := []string{}
+	for i := len(s.frames) - 1; i >= 0; i-- {
+		f := *s.frames[i]
+		frames = append(frames, f)
+		keys = append(keys, f.Function+"@"+positionKey(f.Position))
+	}
+	key := strings.Join(keys, "\n")
+	x, ok := p.samples[key]
+	if !ok {
+		x = &stackSample{frames: frames}
+		p.samples[key] = x
+	}
+	x.count++
+	x.duration += d
+
+	if pos := s.top().Position; pos != nil {
+		if l, ok := p.lines[lineKey{pos.Source, pos.FirstLineno}]; ok {
+			l.Duration += d
+		}
+	}
+}
+
+// resume starts the timeline of s anew, dropping the time since its last
+// event, for a generator resumed after being suspended.
+func (p *Profiler) resume(s *callStack) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s.last = time.Now()
+}
+
+func positionKey(p *Position) string {
+	if p == nil {
+		return ""
+	}
+	return p.Source + ":" + strconv.Itoa(p.FirstLineno)
+}
+
+// statement records that a statement at p is about to run on s.
+func (p *Profiler) statement(s *callStack, pos *Position) {
+	p.mark(s)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	k := lineKey{pos.Source, pos.FirstLineno}
+	l, ok := p.lines[k]
+	if !ok {
+		l = &LineProfile{Source: pos.Source, Line: pos.FirstLineno}
+		p.lines[k] = l
+	}
+	l.Count++
+}
+
+// function records a finished call of the function name.
+func (p *Profiler) function(name string, native bool, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	f, ok := p.functions[name]
+	if !ok {
+		f = &FunctionProfile{Name: name, Native: native}
+		p.functions[name] = f
+	}
+	f.Calls++
+	f.Duration += d
+}
+
+// Lines returns the profile of every executed line, slowest first.
+func (p *Profiler) Lines() []LineProfile {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r := []LineProfile{}
+	for _, l := range p.lines {
+		r = append(r, *l)
+	}
+	sort.Slice(r, func(i, j int) bool {
+		if r[i].Duration != r[j].Duration {
+			return r[i].Duration > r[j].Duration
+		}
+		if r[i].Source != r[j].Source {
+			return r[i].Source < r[j].Source
+		}
+		return r[i].Line < r[j].Line
+	})
+	return r
+}
+
+// Functions returns the profile of every called function, slowest first.
+func (p *Profiler) Functions() []FunctionProfile {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r := []FunctionProfile{}
+	for _, f := range p.functions {
+		r = append(r, *f)
+	}
+	sort.Slice(r, func(i, j int) bool {
+		if r[i].Duration != r[j].Duration {
+			return r[i].Duration > r[j].Duration
+		}
+		return r[i].Name < r[j].Name
+	})
+	return r
+}
+
+// call applies f to args on behalf of the function expression named name
+// at p, recording the call when profiling. Native functions get a frame
+// of their own so their time is not charged to the caller.
+func (e *Engine) call(name string, p *Position, f Value, args []Value) (Value, error) {
+	if e.profiler == nil {
+		return e.Call(f, args)
+	}
+	c, ok := f.(*Closure)
+	native := !ok
+	if native {
+		e.profiler.mark(e.stack)
+		e.pushFrame(name).Position = p
+	} else {
+		name = c.name()
+	}
+	start := time.Now()
+	r, err := e.Call(f, args)
+	if native {
+		e.profiler.mark(e.stack)
+		e.stack.pop()
+	}
+	e.profiler.function(name, native, time.Since(start))
+	return r, err
+}
+
+// calleeName describes the function expression of an application.
+func calleeName(n Node) string {
+	switch n := n.(type) {
+	case *Identifier:
+		return n.Name
+	case *Attribute:
+		return calleeName(n.Receiver) + "." + n.Name
+	}
+	return "<native>"
+}
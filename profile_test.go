@@ -0,0 +1,126 @@
+package golan_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+const profiled = `func f(n) {
+  return n * 2
+}
+i = 0
+while i < 3 {
+  f(i)
+  i = i + 1
+}
+print(len("abc"))
+`
+
+func profile(t *testing.T) *golan.Profiler {
+	t.Helper()
+	p := golan.NewProfiler()
+	e := golan.NewEngine()
+	e.SetProfiler(p)
+	if got := run(t, e, profiled); got != "3\n" {
+		t.Fatalf("output = %q", got)
+	}
+	return p
+}
+
+func TestProfileLines(t *testing.T) {
+	want := map[int]int{1: 1, 2: 3, 4: 1, 5: 1, 6: 3, 7: 3, 9: 1}
+	got := map[int]int{}
+	for _, l := range profile(t).Lines() {
+		if l.Source != "<string>" || l.Duration < 0 {
+			t.Errorf("line profile %+v", l)
+		}
+		got[l.Line] = l.Count
+	}
+	for line, n := range want {
+		if got[line] != n {
+			t.Errorf("line %d ran %d times, want %d", line, got[line], n)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("lines = %v, want %v", got, want)
+	}
+}
+
+func TestProfileFunctions(t *testing.T) {
+	want := map[string]golan.FunctionProfile{
+		"f":     {Name: "f", Calls: 3},
+		"len":   {Name: "len", Native: true, Calls: 1},
+		"print": {Name: "print", Native: true, Calls: 1},
+	}
+	fs := profile(t).Functions()
+	for _, f := range fs {
+		w, ok := want[f.Name]
+		if !ok || f.Native != w.Native || f.Calls != w.Calls || f.Duration < 0 {
+			t.Errorf("function profile %+v, want %+v", f, w)
+		}
+	}
+	if len(fs) != len(want) {
+		t.Errorf("functions = %+v", fs)
+	}
+	for i := 1; i < len(fs); i++ {
+		if fs[i].Duration > fs[i-1].Duration {
+			t.Errorf("functions not ordered slowest first: %+v", fs)
+		}
+	}
+}
+
+// fields splits an encoded protocol buffer message into its fields,
+// keeping only the length-delimited ones.
+func fields(t *testing.T, b []byte) map[uint64][][]byte {
+	r := map[uint64][][]byte{}
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		b = b[n:]
+		switch key & 7 {
+		case 0:
+			_, n = binary.Uvarint(b)
+			b = b[n:]
+		case 2:
+			l, n := binary.Uvarint(b)
+			b = b[n:]
+			r[key>>3] = append(r[key>>3], b[:l])
+			b = b[l:]
+		default:
+			t.Fatalf("unexpected wire type %d", key&7)
+		}
+	}
+	return r
+}
+
+func TestWritePprof(t *testing.T) {
+	var buf bytes.Buffer
+	if err := profile(t).WritePprof(&buf); err != nil {
+		t.Fatal(err)
+	}
+	z, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prof := fields(t, b)
+	if len(prof[2]) == 0 {
+		t.Error("no samples")
+	}
+	table := map[string]bool{}
+	for _, s := range prof[6] {
+		table[string(s)] = true
+	}
+	for _, s := range []string{"", "samples", "count", "time", "nanoseconds", "f", "<string>"} {
+		if !table[s] {
+			t.Errorf("string table lacks %q", s)
+		}
+	}
+}
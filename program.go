@@ -0,0 +1,116 @@
+package golan
+
+import "sync"
+
+// Program is a parsed script prepared to run on any number of engines,
+// concurrently if need be. Running a program never modifies it, and its
+// tree must not be modified once compiled.
+type Program struct {
+	tree Node
+	// generators tells the functions of tree that contain yield.
+	generators map[*Function]bool
+}
+
+// Compile prepares tree to be run by Engine.Run.
+func Compile(tree Node) *Program {
+	p := &Program{tree: tree, generators: map[*Function]bool{}}
+	Inspect(tree, func(n Node) bool {
+		if f, ok := n.(*Function); ok {
+			p.generators[f] = IsGenerator(f)
+		}
+		return true
+	})
+	return p
+}
+
+// CompileFile parses and compiles the script at path.
+func CompileFile(path string) (*Program, error) {
+	tree, err := ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Compile(tree), nil
+}
+
+func (p *Program) Tree() Node { return p.tree }
+
+// Run executes p on e.
+func (e *Engine) Run(p *Program) (Value, error) {
+	outer := e.program
+	e.program = p
+	defer func() { e.program = outer }()
+	return e.Execute(p.tree)
+}
+
+func (e *Engine) isGenerator(f *Function) bool {
+	if e.program != nil {
+		if g, ok := e.program.generators[f]; ok {
+			return g
+		}
+	}
+	return IsGenerator(f)
+}
+
+// Fork returns an engine with globals and imported modules of its own,
+// to run programs concurrently with e and its other forks. It shares the
+// builtins, streams, hooks, registered modules and module paths of e, and
+// the modules parsed by any of them, but stdout and stderr are files of
+// its own, so closing them leaves the writers open for e. Forks are cheap
+// enough to make one for each request a server handles.
+func (e *Engine) Fork() *Engine {
+	f := &Engine{
+		builtins: e.builtins,
+		env:      map[string]Value{},
+		vars:     &sync.RWMutex{},
+		modules:  e.modules.fork(),
+		hook:     e.hook,
+		stack:    newCallStack(),
+		profiler: e.profiler,
+		coverage: e.coverage,
+		stdout:   e.stdout,
+		stderr:   e.stderr,
+		maxDepth: e.maxDepth,
+	}
+	f.bindBuiltin("stdout", NewFile("<stdout>", nil, f.stdout, nil))
+	f.bindBuiltin("stderr", NewFile("<stderr>", nil, f.stderr, nil))
+	return f
+}
+
+// bindBuiltin rebinds the builtin name. The map is replaced rather than
+// modified, since forks share it.
+func (e *Engine) bindBuiltin(name string, v Value) {
+	b := make(map[string]Value, len(e.builtins)+1)
+	for k, x := range e.builtins {
+		b[k] = x
+	}
+	b[name] = v
+	e.builtins = b
+}
+
+// programCache holds the modules parsed by an engine and its forks.
+type programCache struct {
+	mu       sync.Mutex
+	programs map[string]*Program
+}
+
+// compile returns the program of the script at path, parsing it only the
+// first time.
+func (c *programCache) compile(path string) (*Program, error) {
+	c.mu.Lock()
+	p, ok := c.programs[path]
+	c.mu.Unlock()
+	if ok {
+		return p, nil
+	}
+	p, err := CompileFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if x, ok := c.programs[path]; ok {
+		return x, nil
+	}
+	c.programs[path] = p
+	return p, nil
+}
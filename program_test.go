@@ -0,0 +1,114 @@
+package golan_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+const forked = `
+import "util"
+total = 0
+func handle(n) {
+  for i in 0...n {
+    total = total + util.twice(i)
+  }
+  return [total, util.count()]
+}
+`
+
+const util = `
+calls = 0
+func twice(x) {
+  calls = calls + 1
+  return x * 2
+}
+func count() {
+  return calls
+}
+`
+
+func TestForkConcurrently(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "util.gl"), []byte(util), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tree, err := golan.Parse(forked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog := golan.Compile(tree)
+	base := golan.NewEngine()
+	base.AddModulePath(dir)
+
+	const n = 8
+	results := make([]string, n)
+	totals := make([]golan.Value, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			e := base.Fork()
+			if _, err := e.Run(prog); err != nil {
+				errs[i] = err
+				return
+			}
+			r, err := e.Call(e.Globals()["handle"], []golan.Value{golan.Integer(i)})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i] = fmt.Sprint(r)
+			totals[i] = e.Globals()["total"]
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		if errs[i] != nil {
+			t.Errorf("fork %d: %v", i, errs[i])
+			continue
+		}
+		// Each fork imports util afresh, so its calls count only the
+		// calls of that fork.
+		total := i * (i - 1)
+		if want := fmt.Sprintf("[%d, %d]", total, i); results[i] != want {
+			t.Errorf("fork %d: handle = %s, want %s", i, results[i], want)
+		}
+		if totals[i] != golan.Integer(total) {
+			t.Errorf("fork %d: total = %v, want %d", i, totals[i], total)
+		}
+	}
+	if g := base.Globals(); len(g) != 0 {
+		t.Errorf("globals of the base engine = %v, want none", g)
+	}
+}
+
+func TestForkStreams(t *testing.T) {
+	var out bytes.Buffer
+	base := golan.NewEngine(golan.WithOutput(&out))
+	fork := base.Fork()
+	execute := func(e *golan.Engine, src string) {
+		t.Helper()
+		tree, err := golan.Parse(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := e.Execute(tree); err != nil {
+			t.Fatal(err)
+		}
+	}
+	execute(fork, "file_write(stdout, \"fork\")\nfile_close(stdout)\n")
+	execute(base, "file_write(stdout, \" base\")\n")
+	execute(base.Fork(), "file_write(stdout, \" next\")\n")
+	if out.String() != "fork base next" {
+		t.Errorf("output = %q, want %q", out.String(), "fork base next")
+	}
+}
@@ -0,0 +1,206 @@
+package golan
+
+import (
+	"fmt"
+	"math"
+)
+
+// Range is the arithmetic progression start, start+step, ... up to stop,
+// which is included only when Inclusive. Elements are computed on demand.
+// The bounds and step are all Integer, or all Float when any of them is a
+// Float; float elements are start+i*step, so rounding may drop an element
+// close to stop.
+type Range struct {
+	Start     Value
+	Stop      Value
+	Step      Value
+	Inclusive bool
+	length    int
+}
+
+// NewRange returns the range from start to stop by step. The arguments
+// must be Integer or Float and step must not be zero.
+func NewRange(start Value, stop Value, step Value, inclusive bool) (*Range, error) {
+	isFloat := false
+	for _, v := range []Value{start, stop, step} {
+		switch v.(type) {
+		case Integer:
+		case Float:
+			isFloat = true
+		default:
+			return nil, fmt.Errorf("not a number - %v(%T)", v, v)
+		}
+	}
+	if isFloat {
+		start, stop, step = toFloat(start), toFloat(stop), toFloat(step)
+	}
+	r := &Range{Start: start, Stop: stop, Step: step, Inclusive: inclusive}
+	switch s := step.(type) {
+	case Integer:
+		if s == 0 {
+			return nil, &Error{Kind: "ValueError", Message: "range step must not be zero"}
+		}
+		r.length = intRangeLength(int64(start.(Integer)), int64(stop.(Integer)), int64(s), inclusive)
+	case Float:
+		if s == 0 || math.IsNaN(float64(s)) {
+			return nil, &Error{Kind: "ValueError", Message: "range step must not be zero"}
+		}
+		n, ok := floatRangeLength(float64(start.(Float)), float64(stop.(Float)), float64(s), inclusive)
+		if !ok {
+			return nil, &Error{Kind: "ValueError", Message: "range too long"}
+		}
+		r.length = n
+	}
+	return r, nil
+}
+
+func toFloat(v Value) Value {
+	if i, ok := v.(Integer); ok {
+		return Float(i)
+	}
+	return v
+}
+
+func intRangeLength(start, stop, step int64, inclusive bool) int {
+	d := stop - start
+	if step < 0 {
+		d, step = -d, -step
+	}
+	if d < 0 || (d == 0 && !inclusive) {
+		return 0
+	}
+	n := d / step
+	if inclusive || d%step != 0 {
+		n++
+	}
+	return int(n)
+}
+
+func floatRangeLength(start, stop, step float64, inclusive bool) (int, bool) {
+	n := math.Ceil((stop - start) / step)
+	if inclusive {
+		n = math.Floor((stop-start)/step) + 1
+	}
+	if n <= 0 || math.IsNaN(n) {
+		return 0, true
+	}
+	if n > math.MaxInt32 {
+		return 0, false
+	}
+	// the division may round either way; check against the elements
+	past := func(x float64) bool {
+		if step < 0 {
+			return x < stop || (!inclusive && x == stop)
+		}
+		return x > stop || (!inclusive && x == stop)
+	}
+	for n > 0 && past(start+(n-1)*step) {
+		n--
+	}
+	return int(n), true
+}
+
+func (r *Range) at(i int) Value {
+	switch s := r.Step.(type) {
+	case Integer:
+		return r.Start.(Integer) + Integer(i)*s
+	case Float:
+		return r.Start.(Float) + Float(i)*s
+	}
+	panic("must not happen")
+}
+
+func (r *Range) String() string {
+	if step, ok := r.Step.(Integer); ok && step == 1 {
+		if r.Inclusive {
+			return fmt.Sprintf("%v..%v", r.Start, r.Stop)
+		}
+		return fmt.Sprintf("%v...%v", r.Start, r.Stop)
+	}
+	if r.Inclusive {
+		return fmt.Sprintf("range(%v, %v, %v, inclusive)", r.Start, r.Stop, r.Step)
+	}
+	return fmt.Sprintf("range(%v, %v, %v)", r.Start, r.Stop, r.Step)
+}
+
+func (r *Range) OpLen() int { return r.length }
+
+func (r *Range) OpIter() (Iterator, error) {
+	i := 0
+	return IteratorFunc(func() (Value, bool, error) {
+		if i >= r.length {
+			return nil, false, nil
+		}
+		i++
+		return r.at(i - 1), true, nil
+	}), nil
+}
+
+func (r *Range) OpIndex(key Value) (Value, error) {
+	i, err := sequenceIndex(key, r.length)
+	if err != nil {
+		return nil, err
+	}
+	return r.at(i), nil
+}
+
+// OpContains reports whether x is an element of the range.
+func (r *Range) OpContains(x Value) (bool, error) {
+	if r.length == 0 {
+		return false, nil
+	}
+	switch s := r.Step.(type) {
+	case Integer:
+		var v Integer
+		switch x := x.(type) {
+		case Integer:
+			v = x
+		case Float:
+			if x != Float(math.Trunc(float64(x))) {
+				return false, nil
+			}
+			v = Integer(x)
+		default:
+			return false, nil
+		}
+		d := v - r.Start.(Integer)
+		if d%s != 0 {
+			return false, nil
+		}
+		i := d / s
+		return i >= 0 && i < Integer(r.length), nil
+	case Float:
+		var v Float
+		switch x := x.(type) {
+		case Integer:
+			v = Float(x)
+		case Float:
+			v = x
+		default:
+			return false, nil
+		}
+		i := math.Round(float64((v - r.Start.(Float)) / s))
+		if i < 0 || i >= float64(r.length) {
+			return false, nil
+		}
+		return r.at(int(i)) == v, nil
+	}
+	panic("must not happen")
+}
+
+// builtinRange is range(stop), range(start, stop) or range(start, stop,
+// step), excluding stop like a...b.
+func builtinRange(e *Engine, args []Value) (Value, error) {
+	var start, stop, step Value = Integer(0), nil, Integer(1)
+	switch len(args) {
+	case 1:
+		stop = args[0]
+	case 2:
+		start, stop = args[0], args[1]
+	case 3:
+		start, stop, step = args[0], args[1], args[2]
+	default:
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1..3)", len(args))
+	}
+	return NewRange(start, stop, step, false)
+}
@@ -0,0 +1,82 @@
+package golan_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+func TestRangeElements(t *testing.T) {
+	tests := []struct {
+		expr     string
+		elements string
+		length   int
+	}{
+		{"1..4", "1 2 3 4 ", 4},
+		{"1...4", "1 2 3 ", 3},
+		{"4..1", "", 0},
+		{"range(5)", "0 1 2 3 4 ", 5},
+		{"range(0)", "", 0},
+		{"range(2, 10, 3)", "2 5 8 ", 3},
+		{"range(5, 0, -2)", "5 3 1 ", 3},
+		{"range(0, -3, -1)", "0 -1 -2 ", 3},
+		{"range(0, 1, 0.25)", "0 0.25 0.5 0.75 ", 4},
+		{"range(1.5, 3)", "1.5 2.5 ", 2},
+	}
+	for _, tt := range tests {
+		src := fmt.Sprintf("r = %s\nfor x in r {\n  write(x, \" \")\n}\nputs()\nprint(len(r))\n", tt.expr)
+		want := fmt.Sprintf("%s\n%d\n", tt.elements, tt.length)
+		if got := run(t, golan.NewEngine(), src); got != want {
+			t.Errorf("%s: output = %q, want %q", tt.expr, got, want)
+		}
+	}
+}
+
+func TestRangeOperations(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"3 in 1..3", "true"},
+		{"3 in 1...3", "false"},
+		{"4 in range(0, 10, 2)", "true"},
+		{"5 in range(0, 10, 2)", "false"},
+		{"2.5 in range(0, 5)", "false"},
+		{"-1 in range(3, -3, -2)", "true"},
+		{"range(3)[0]", "0"},
+		{"range(3)[-1]", "2"},
+		{"range(10, 0, -3)[2]", "4"},
+		{"len(range(0, 10, 3))", "4"},
+		{"1..3", "1..3"},
+	}
+	for _, tt := range tests {
+		if got := run(t, golan.NewEngine(), "print("+tt.expr+")\n"); got != tt.want+"\n" {
+			t.Errorf("%s = %q, want %q", tt.expr, got, tt.want+"\n")
+		}
+	}
+}
+
+func TestRangeErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		kind string
+	}{
+		{"range(1, 5, 0)", "ValueError"},
+		{"range(0, 1, 0.0)", "ValueError"},
+		{`range("a")`, "RuntimeError"},
+		{"range(3)[3]", "IndexError"},
+	}
+	for _, tt := range tests {
+		tree, err := golan.Parse("x = " + tt.expr + "\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = golan.NewEngine().Execute(tree)
+		var x *golan.Error
+		if !errors.As(err, &x) || x.Kind != tt.kind {
+			t.Errorf("%s: error = %v, want %s", tt.expr, err, tt.kind)
+		}
+	}
+}
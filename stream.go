@@ -0,0 +1,93 @@
+package golan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Option configures an Engine created by NewEngine.
+type Option func(e *Engine)
+
+// WithInput makes stdin read from r instead of os.Stdin.
+func WithInput(r io.Reader) Option {
+	return func(e *Engine) { e.SetInput(r) }
+}
+
+// WithOutput makes print, write, puts and stdout write to w instead of
+// os.Stdout.
+func WithOutput(w io.Writer) Option {
+	return func(e *Engine) { e.SetOutput(w) }
+}
+
+// WithErrorOutput makes eprint and stderr write to w instead of
+// os.Stderr.
+func WithErrorOutput(w io.Writer) Option {
+	return func(e *Engine) { e.SetErrorOutput(w) }
+}
+
+// SetInput rebinds stdin to a file reading from r.
+func (e *Engine) SetInput(r io.Reader) {
+	e.bindBuiltin("stdin", NewFile("<stdin>", r, nil, nil))
+}
+
+// SetOutput directs print, write and puts to w and rebinds stdout to a
+// file writing to w.
+func (e *Engine) SetOutput(w io.Writer) {
+	w = &syncWriter{w: w}
+	e.stdout = w
+	e.bindBuiltin("stdout", NewFile("<stdout>", nil, w, nil))
+}
+
+// SetErrorOutput directs eprint to w and rebinds stderr to a file writing
+// to w.
+func (e *Engine) SetErrorOutput(w io.Writer) {
+	w = &syncWriter{w: w}
+	e.stderr = w
+	e.bindBuiltin("stderr", NewFile("<stderr>", nil, w, nil))
+}
+
+// syncWriter serializes the writes of tasks sharing an output, so that
+// lines printed by different tasks do not interleave.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(b []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(b)
+}
+
+// joinValues formats values as print does, separated by spaces.
+func joinValues(values []Value) string {
+	s := []string{}
+	for _, v := range values {
+		s = append(s, fmt.Sprint(v))
+	}
+	return strings.Join(s, " ")
+}
+
+func writeString(w io.Writer, s string) (Value, error) {
+	if _, err := io.WriteString(w, s); err != nil {
+		return nil, ioError("%v", err)
+	}
+	return Undefined{}, nil
+}
+
+// builtinWrite writes the values without separators or a newline.
+func builtinWrite(e *Engine, args []Value) (Value, error) {
+	return writeString(e.stdout, concatValues(args))
+}
+
+// builtinPuts writes the values separated by spaces and ends the line.
+func builtinPuts(e *Engine, args []Value) (Value, error) {
+	return writeString(e.stdout, joinValues(args)+"\n")
+}
+
+// builtinEprint is puts on the error output.
+func builtinEprint(e *Engine, args []Value) (Value, error) {
+	return writeString(e.stderr, joinValues(args)+"\n")
+}
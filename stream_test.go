@@ -0,0 +1,65 @@
+package golan_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of testdata")
+
+// TestStreamGolden runs each testdata/streams/*.gl with stdin read from
+// the .in file next to it, if any, and compares what it writes to stdout
+// and stderr with the .stdout and .stderr files.
+func TestStreamGolden(t *testing.T) {
+	scripts, err := filepath.Glob(filepath.Join("testdata", "streams", "*.gl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, script := range scripts {
+		base := strings.TrimSuffix(script, ".gl")
+		t.Run(filepath.Base(base), func(t *testing.T) {
+			tree, err := golan.ParseFile(script)
+			if err != nil {
+				t.Fatal(err)
+			}
+			input, err := os.ReadFile(base + ".in")
+			if err != nil && !os.IsNotExist(err) {
+				t.Fatal(err)
+			}
+			var stdout, stderr bytes.Buffer
+			e := golan.NewEngine(
+				golan.WithInput(bytes.NewReader(input)),
+				golan.WithOutput(&stdout),
+				golan.WithErrorOutput(&stderr),
+			)
+			if _, err := e.Execute(tree); err != nil {
+				t.Fatal(err)
+			}
+			golden(t, base+".stdout", stdout.Bytes())
+			golden(t, base+".stderr", stderr.Bytes())
+		})
+	}
+}
+
+func golden(t *testing.T, path string, got []byte) {
+	t.Helper()
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s: got %q, want %q", filepath.Base(path), got, want)
+	}
+}
@@ -0,0 +1,126 @@
+package golan
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// StructType is the record type declared by a struct statement. Calling it
+// constructs an instance from the values of its fields, in order.
+type StructType struct {
+	Name   string
+	Fields []string
+}
+
+func (t *StructType) String() string { return fmt.Sprintf("#<struct %s>", t.Name) }
+
+func (t *StructType) OpCall(e *Engine, args []Value) (Value, error) {
+	if len(args) != len(t.Fields) {
+		return nil, &Error{
+			Kind:    "TypeError",
+			Message: fmt.Sprintf("wrong number of arguments (given %d, expected %d)", len(args), len(t.Fields)),
+		}
+	}
+	return &StructInstance{Type: t, values: append([]Value{}, args...)}, nil
+}
+
+func (t *StructType) field(name string) (int, bool) {
+	for i, f := range t.Fields {
+		if f == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// StructInstance is a value of a struct type. Instances are equal when
+// they are of the same type and their fields are equal.
+type StructInstance struct {
+	Type   *StructType
+	mu     sync.RWMutex
+	values []Value
+}
+
+func (s *StructInstance) String() string { return s.format(map[Value]bool{}) }
+
+// format prints s as Name(field: value, ...), or as Name{...} when s is
+// in seen, being printed already.
+func (s *StructInstance) format(seen map[Value]bool) string {
+	if seen[s] {
+		return s.Type.Name + "{...}"
+	}
+	seen[s] = true
+	defer delete(seen, s)
+	fields := []string{}
+	for i, v := range s.snapshot() {
+		fields = append(fields, s.Type.Fields[i]+": "+inspectIn(v, seen))
+	}
+	return s.Type.Name + "(" + strings.Join(fields, ", ") + ")"
+}
+
+func (s *StructInstance) OpGetAttr(name string) (Value, error) {
+	i, ok := s.Type.field(name)
+	if !ok {
+		return nil, fmt.Errorf("undefined attribute - %s", name)
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.values[i], nil
+}
+
+func (s *StructInstance) OpSetAttr(name string, v Value) error {
+	i, ok := s.Type.field(name)
+	if !ok {
+		return fmt.Errorf("undefined attribute - %s", name)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[i] = v
+	return nil
+}
+
+func (s *StructInstance) OpCmp(other Value) CompareResult {
+	return s.compare(other, map[[2]*StructInstance]bool{})
+}
+
+// compare is OpCmp within the comparisons of the pairs of instances in
+// pairs, which it takes as equal when they come up again so that
+// comparing cyclic instances ends.
+func (s *StructInstance) compare(other Value, pairs map[[2]*StructInstance]bool) CompareResult {
+	t, ok := other.(*StructInstance)
+	if !ok || t.Type != s.Type {
+		return CMP_NE
+	}
+	if t == s || pairs[[2]*StructInstance{s, t}] {
+		return CMP_EQ
+	}
+	pairs[[2]*StructInstance{s, t}] = true
+	for i, v := range s.snapshot() {
+		w := t.value(i)
+		if x, ok := v.(*StructInstance); ok {
+			if x.compare(w, pairs) != CMP_EQ {
+				return CMP_NE
+			}
+			continue
+		}
+		if !valuesEqual(nil, v, w) {
+			return CMP_NE
+		}
+	}
+	return CMP_EQ
+}
+
+// snapshot returns a copy of the values of the fields, so that comparing
+// them does not hold the lock while other instances are locked.
+func (s *StructInstance) snapshot() []Value {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Value{}, s.values...)
+}
+
+func (s *StructInstance) value(i int) Value {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.values[i]
+}
@@ -0,0 +1,21 @@
+package golan_test
+
+import "testing"
+
+func TestStructCycles(t *testing.T) {
+	expectOutput(t, `
+struct P { x, next }
+p = P(1, 0)
+p.next = p
+print(p)
+q = P(1, 0)
+q.next = q
+print(p == q)
+r = P(2, 0)
+r.next = r
+print(p == r)
+l = [p]
+p.next = l
+print(l)
+`, "P(x: 1, next: P{...})\ntrue\nfalse\n[P(x: 1, next: [...])]\n")
+}
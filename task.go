@@ -0,0 +1,108 @@
+package golan
+
+import "fmt"
+
+// Task is a function call started by spawn, running on a goroutine of its
+// own. An error ending the call is kept until the task is waited for.
+type Task struct {
+	name   string
+	done   chan struct{}
+	result Value
+	err    error
+}
+
+func (t *Task) String() string { return fmt.Sprintf("#<task %s>", t.name) }
+
+func (t *Task) OpGetAttr(name string) (Value, error) {
+	switch name {
+	case "done":
+		select {
+		case <-t.done:
+			return Boolean(true), nil
+		default:
+			return Boolean(false), nil
+		}
+	}
+	return nil, fmt.Errorf("undefined attribute - %s", name)
+}
+
+// Wait blocks until the task ends and returns the result of its call.
+func (t *Task) Wait() (Value, error) {
+	<-t.done
+	return t.result, t.err
+}
+
+func (e *Engine) execSpawn(s *Spawn) (Value, error) {
+	a := s.Call.(*Apply)
+	f, args, err := e.evalApply(a)
+	if err != nil {
+		return nil, err
+	}
+	name := calleeName(a.function)
+	t := &Task{name: name, done: make(chan struct{})}
+	te := e.task("<task "+name+">", s.position)
+	go func() {
+		defer close(t.done)
+		r, err := te.call(name, a.position, f, args)
+		if err != nil {
+			t.err = errorAt(err, "RuntimeError", a.position)
+			return
+		}
+		t.result = r
+	}()
+	return t, nil
+}
+
+func taskArgument(v Value) (*Task, error) {
+	t, ok := v.(*Task)
+	if !ok {
+		return nil, fmt.Errorf("not a Task - %v(%T)", v, v)
+	}
+	return t, nil
+}
+
+// builtinWait waits for a task and returns its result, raising the error
+// that ended it, if any.
+func builtinWait(e *Engine, args []Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("wrong number of arguments (given %d, expected 1)", len(args))
+	}
+	t, err := taskArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return t.Wait()
+}
+
+// builtinJoin waits for every task given, alone or in lists, and returns
+// the list of their results. When tasks failed, it raises the error of the
+// first of them after all have ended.
+func builtinJoin(e *Engine, args []Value) (Value, error) {
+	tasks := []*Task{}
+	for _, a := range args {
+		values := []Value{a}
+		if l, ok := a.(*List); ok {
+			values = l.Elements
+		}
+		for _, v := range values {
+			t, err := taskArgument(v)
+			if err != nil {
+				return nil, err
+			}
+			tasks = append(tasks, t)
+		}
+	}
+	results := []Value{}
+	var first error
+	for _, t := range tasks {
+		r, err := t.Wait()
+		if err != nil && first == nil {
+			first = err
+		}
+		results = append(results, r)
+	}
+	if first != nil {
+		return nil, first
+	}
+	return NewList(results...), nil
+}
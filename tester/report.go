@@ -0,0 +1,158 @@
+package tester
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Summary counts the results of a run.
+type Summary struct {
+	Passed int
+	Failed int
+}
+
+func Summarize(results []*Result) Summary {
+	s := Summary{}
+	for _, r := range results {
+		if r.Failed() {
+			s.Failed++
+		} else {
+			s.Passed++
+		}
+	}
+	return s
+}
+
+// byFile groups results by file, keeping the order of the run.
+func byFile(results []*Result) ([]string, map[string][]*Result) {
+	files := []string{}
+	m := map[string][]*Result{}
+	for _, r := range results {
+		if _, ok := m[r.File]; !ok {
+			files = append(files, r.File)
+		}
+		m[r.File] = append(m[r.File], r)
+	}
+	return files, m
+}
+
+// WriteText writes a report in the style of go test: failures with their
+// errors, a line per file and a final summary. Passing tests are listed
+// only when verbose.
+func WriteText(w io.Writer, results []*Result, verbose bool) {
+	files, m := byFile(results)
+	for _, file := range files {
+		failed := false
+		total := 0.0
+		for _, r := range m[file] {
+			total += r.Duration.Seconds()
+			if r.Failed() {
+				failed = true
+				fmt.Fprintf(w, "--- FAIL: %s (%.3fs)\n\t%v\n", r.Name, r.Duration.Seconds(), r.Err)
+			} else if verbose {
+				fmt.Fprintf(w, "--- PASS: %s (%.3fs)\n", r.Name, r.Duration.Seconds())
+			}
+		}
+		if failed {
+			fmt.Fprintf(w, "FAIL\t%s\t%.3fs\n", file, total)
+		} else {
+			fmt.Fprintf(w, "ok\t%s\t%.3fs\n", file, total)
+		}
+	}
+	s := Summarize(results)
+	if s.Failed > 0 {
+		fmt.Fprintf(w, "FAIL: %d failed, %d passed\n", s.Failed, s.Passed)
+	} else {
+		fmt.Fprintf(w, "PASS: %d passed\n", s.Passed)
+	}
+}
+
+// WriteTAP writes the results in the Test Anything Protocol, version 13.
+func WriteTAP(w io.Writer, results []*Result) {
+	fmt.Fprintln(w, "TAP version 13")
+	fmt.Fprintf(w, "1..%d\n", len(results))
+	for i, r := range results {
+		status := "ok"
+		if r.Failed() {
+			status = "not ok"
+		}
+		fmt.Fprintf(w, "%s %d - %s: %s\n", status, i+1, r.File, r.Name)
+		if r.Failed() {
+			fmt.Fprintln(w, "  ---")
+			fmt.Fprintf(w, "  kind: %s\n", r.Err.Kind)
+			fmt.Fprintf(w, "  message: %q\n", r.Err.Message)
+			if r.Err.Position != nil {
+				fmt.Fprintf(w, "  at: %q\n", r.Err.Position.String())
+			}
+			fmt.Fprintln(w, "  ...")
+		}
+	}
+}
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the results as JUnit XML with a test suite per file.
+func WriteJUnit(w io.Writer, results []*Result) error {
+	files, m := byFile(results)
+	doc := junitSuites{}
+	for _, file := range files {
+		suite := junitSuite{Name: file}
+		total := 0.0
+		for _, r := range m[file] {
+			c := junitCase{Name: r.Name, Classname: strings.TrimSuffix(file, ".gl"), Time: seconds(r.Duration.Seconds())}
+			total += r.Duration.Seconds()
+			if r.Failed() {
+				c.Failure = &junitFailure{Message: r.Err.Message, Type: r.Err.Kind, Text: r.Err.Error()}
+				suite.Failures++
+			}
+			suite.Cases = append(suite.Cases, c)
+			suite.Tests++
+		}
+		suite.Time = seconds(total)
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Suites = append(doc.Suites, suite)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(s float64) string {
+	return fmt.Sprintf("%.3f", s)
+}
@@ -0,0 +1,89 @@
+package tester
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/arikui1911/golan"
+)
+
+var results = []*Result{
+	{File: "a_test.gl", Name: "test_add", Duration: 1500 * time.Microsecond},
+	{File: "a_test.gl", Name: "test_sub", Duration: 2 * time.Millisecond, Err: &golan.Error{
+		Kind:     "AssertionError",
+		Message:  `expected 1, got "1" & <2>`,
+		Position: &golan.Position{Source: "a_test.gl", FirstLineno: 7, FirstColumn: 3, LastLineno: 7, LastColumn: 20},
+	}},
+	{File: "b_test.gl", Name: "test_raise", Duration: 250 * time.Millisecond, Err: &golan.Error{
+		Kind:    "RuntimeError",
+		Message: "boom",
+	}},
+}
+
+func TestWriteTAP(t *testing.T) {
+	var out bytes.Buffer
+	WriteTAP(&out, results)
+	want := `TAP version 13
+1..3
+ok 1 - a_test.gl: test_add
+not ok 2 - a_test.gl: test_sub
+  ---
+  kind: AssertionError
+  message: "expected 1, got \"1\" & <2>"
+  at: "a_test.gl:7:3"
+  ...
+not ok 3 - b_test.gl: test_raise
+  ---
+  kind: RuntimeError
+  message: "boom"
+  ...
+`
+	if out.String() != want {
+		t.Errorf("TAP =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteJUnit(&out, results); err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="2">
+  <testsuite name="a_test.gl" tests="2" failures="1" time="0.004">
+    <testcase name="test_add" classname="a_test" time="0.002"></testcase>
+    <testcase name="test_sub" classname="a_test" time="0.002">
+      <failure message="expected 1, got &#34;1&#34; &amp; &lt;2&gt;" type="AssertionError">a_test.gl:7:3: AssertionError: expected 1, got &#34;1&#34; &amp; &lt;2&gt;</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="b_test.gl" tests="1" failures="1" time="0.250">
+    <testcase name="test_raise" classname="b_test" time="0.250">
+      <failure message="boom" type="RuntimeError">RuntimeError: boom</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	if out.String() != want {
+		t.Errorf("JUnit =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	tests := []struct {
+		verbose bool
+		want    string
+	}{
+		{false, "--- FAIL: test_sub (0.002s)\n\ta_test.gl:7:3: AssertionError: expected 1, got \"1\" & <2>\nFAIL\ta_test.gl\t0.004s\n" +
+			"--- FAIL: test_raise (0.250s)\n\tRuntimeError: boom\nFAIL\tb_test.gl\t0.250s\nFAIL: 2 failed, 1 passed\n"},
+		{true, "--- PASS: test_add (0.002s)\n--- FAIL: test_sub (0.002s)\n\ta_test.gl:7:3: AssertionError: expected 1, got \"1\" & <2>\nFAIL\ta_test.gl\t0.004s\n" +
+			"--- FAIL: test_raise (0.250s)\n\tRuntimeError: boom\nFAIL\tb_test.gl\t0.250s\nFAIL: 2 failed, 1 passed\n"},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		WriteText(&out, results, tt.verbose)
+		if out.String() != tt.want {
+			t.Errorf("verbose %v: text =\n%s\nwant\n%s", tt.verbose, out.String(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Package tester discovers and runs golan tests.
+//
+// A test file is a script whose name ends in _test.gl. Its top-level
+// statements run first; then every function it defines whose name starts
+// with test_ is called without arguments, in source order. A test fails
+// when it raises, typically through the assert builtins. A file defining
+// no test function is a single test that fails when the script raises.
+package tester
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arikui1911/golan"
+)
+
+// Result is the outcome of one test.
+type Result struct {
+	File     string
+	Name     string
+	Err      *golan.Error
+	Duration time.Duration
+}
+
+func (r *Result) Failed() bool { return r.Err != nil }
+
+// Options control a run.
+type Options struct {
+	// Run selects the test functions to call by name; nil selects all.
+	Run *regexp.Regexp
+	// Coverage, when not nil, counts the test files and the modules they
+	// import.
+	Coverage *golan.Coverage
+}
+
+// Discover returns the files named in paths and the *_test.gl files under
+// the directories named in paths, sorted.
+func Discover(paths ...string) ([]string, error) {
+	files := []string{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && strings.HasSuffix(p, "_test.gl") {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// TestFunctions returns the names of the test functions defined at the top
+// level of tree, in source order.
+func TestFunctions(tree golan.Node) []string {
+	names := []string{}
+	b, ok := tree.(*golan.Block)
+	if !ok {
+		return names
+	}
+	for _, s := range b.Statements() {
+		if f, ok := s.(*golan.Function); ok && strings.HasPrefix(f.Name, "test_") {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+// RunFile runs the tests of one file, each in the engine of the file.
+func RunFile(path string, opts Options) []*Result {
+	start := time.Now()
+	fail := func(err error) []*Result {
+		return []*Result{{File: path, Name: filepath.Base(path), Err: asError(err), Duration: time.Since(start)}}
+	}
+	tree, err := golan.ParseFile(path)
+	if err != nil {
+		return fail(err)
+	}
+	e := golan.NewEngine()
+	if opts.Coverage != nil {
+		opts.Coverage.Add(tree)
+		e.SetCoverage(opts.Coverage)
+	}
+	if _, err := e.Execute(tree); err != nil {
+		return fail(err)
+	}
+	names := TestFunctions(tree)
+	if len(names) == 0 {
+		return []*Result{{File: path, Name: filepath.Base(path), Duration: time.Since(start)}}
+	}
+
+	results := []*Result{}
+	for _, name := range names {
+		if opts.Run != nil && !opts.Run.MatchString(name) {
+			continue
+		}
+		r := &Result{File: path, Name: name}
+		start := time.Now()
+		_, err := e.Call(e.Globals()[name], nil)
+		r.Duration = time.Since(start)
+		if err != nil {
+			r.Err = asError(err)
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// Run runs the tests of every file.
+func Run(files []string, opts Options) []*Result {
+	results := []*Result{}
+	for _, path := range files {
+		results = append(results, RunFile(path, opts)...)
+	}
+	return results
+}
+
+func asError(err error) *golan.Error {
+	var x *golan.Error
+	if errors.As(err, &x) {
+		return x
+	}
+	var syntax *golan.SyntaxError
+	if errors.As(err, &syntax) {
+		return &golan.Error{Kind: "SyntaxError", Message: syntax.Reason(), Position: syntax.Position}
+	}
+	return &golan.Error{Kind: "RuntimeError", Message: err.Error()}
+}
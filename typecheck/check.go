@@ -0,0 +1,601 @@
+// Package typecheck infers the types of golan expressions and reports
+// operations that are certain to fail at runtime.
+//
+// Annotations are optional: variables and parameters without one take
+// the type of the values assigned to them, and an unannotated variable
+// assigned values of different types becomes any. Only operations whose
+// operand types are all known are checked.
+package typecheck
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/arikui1911/golan"
+)
+
+// Check returns the type errors of tree in source order.
+func Check(tree golan.Node) []*golan.Error {
+	c := &checker{}
+	global := &env{vars: map[string]*variable{}}
+	for _, name := range golan.BuiltinNames() {
+		global.vars[name] = &variable{typ: builtin(name), declared: true, builtin: true}
+	}
+	c.env = &env{vars: map[string]*variable{}, parent: global}
+	c.statement(tree)
+	sort.SliceStable(c.errors, func(i, j int) bool {
+		a, b := c.errors[i].Position, c.errors[j].Position
+		if a.FirstLineno != b.FirstLineno {
+			return a.FirstLineno < b.FirstLineno
+		}
+		return a.FirstColumn < b.FirstColumn
+	})
+	return c.errors
+}
+
+type variable struct {
+	typ      Type
+	declared bool
+	builtin  bool
+}
+
+type env struct {
+	vars   map[string]*variable
+	parent *env
+}
+
+func (e *env) lookup(name string) *variable {
+	for ; e != nil; e = e.parent {
+		if v, ok := e.vars[name]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// function is the function whose body is being checked.
+type function struct {
+	result    Type
+	generator bool
+}
+
+type checker struct {
+	env    *env
+	fn     *function
+	errors []*golan.Error
+}
+
+func (c *checker) errorf(p *golan.Position, format string, args ...any) {
+	c.errors = append(c.errors, &golan.Error{Kind: "TypeError", Message: fmt.Sprintf(format, args...), Position: p})
+}
+
+func (c *checker) annotation(p *golan.Position, name string) Type {
+	if name == "" {
+		return nil
+	}
+	t, ok := annotations[name]
+	if !ok {
+		c.errorf(p, "unknown type - %s", name)
+		return Any
+	}
+	return t
+}
+
+// bind assigns a value of type t to name the way the engine does: to the
+// innermost binding of name, or a new one in the current scope.
+func (c *checker) bind(p *golan.Position, name string, t Type, declared Type) {
+	v := c.env.lookup(name)
+	if v == nil || v.builtin {
+		if declared != nil {
+			if !assignable(declared, t) {
+				c.errorf(p, "cannot assign %v to %s of type %v", t, name, declared)
+			}
+			t = declared
+		}
+		c.env.vars[name] = &variable{typ: t, declared: declared != nil}
+		return
+	}
+	if declared != nil && v.declared && declared.String() != v.typ.String() {
+		c.errorf(p, "%s redeclared as %v, previously %v", name, declared, v.typ)
+		return
+	}
+	if declared != nil && !v.declared {
+		v.typ, v.declared = declared, true
+	}
+	if v.declared {
+		if !assignable(v.typ, t) {
+			c.errorf(p, "cannot assign %v to %s of type %v", t, name, v.typ)
+		}
+		return
+	}
+	if v.typ.String() != t.String() {
+		v.typ = Any
+	}
+}
+
+func (c *checker) statement(n golan.Node) {
+	switch n := n.(type) {
+	case *golan.Block:
+		for _, s := range n.Statements() {
+			c.statement(s)
+		}
+	case *golan.While:
+		c.expr(n.Condition)
+		c.statement(n.Body)
+	case *golan.For:
+		t := c.iteration(n.Iterable)
+		id := n.Variable.(*golan.Identifier)
+		c.bind(id.Position(), id.Name, t, nil)
+		c.statement(n.Body)
+	case *golan.Select:
+		for _, x := range n.Cases {
+			if t := c.expr(x.Channel); concrete(t) && t != Channel {
+				c.errorf(x.Channel.Position(), "cannot select on %v", t)
+			}
+			if x.Value != nil {
+				c.expr(x.Value)
+			}
+			if id, ok := x.Variable.(*golan.Identifier); ok {
+				c.bind(id.Position(), id.Name, Any, nil)
+			}
+			c.statement(x.Body)
+		}
+		if n.Default != nil {
+			c.statement(n.Default)
+		}
+	case *golan.If:
+		c.expr(n.Test)
+		c.statement(n.Then)
+		if n.Alt != nil {
+			c.statement(n.Alt)
+		}
+	case *golan.Begin:
+		c.statement(n.Body)
+		if n.Rescue != nil {
+			if id, ok := n.Variable.(*golan.Identifier); ok {
+				c.bind(id.Position(), id.Name, Error, nil)
+			}
+			c.statement(n.Rescue)
+		}
+		if n.Ensure != nil {
+			c.statement(n.Ensure)
+		}
+	case *golan.Raise:
+		t := c.expr(n.Expression)
+		if !assignable(Union{String, Error}, t) {
+			c.errorf(n.Expression.Position(), "exception must be a string or an error, not %v", t)
+		}
+	case *golan.Import:
+		c.bind(n.Position(), n.Name, Module, nil)
+	case *golan.Struct:
+		f := &Func{Params: []Type{}, Min: len(n.Fields), Result: &Struct{n.Name, n.Fields}}
+		for range n.Fields {
+			f.Params = append(f.Params, Any)
+		}
+		c.bind(n.Position(), n.Name, f, nil)
+	case *golan.Class:
+		c.class(n)
+	case *golan.Return:
+		t := Type(Undefined)
+		if n.Expression != nil {
+			t = c.expr(n.Expression)
+		}
+		if c.fn != nil && c.fn.generator && n.Expression != nil {
+			c.errorf(n.Position(), "cannot return a value from a generator")
+		} else if c.fn != nil && !assignable(c.fn.result, t) {
+			c.errorf(n.Position(), "cannot return %v from function returning %v", t, c.fn.result)
+		}
+	case *golan.Yield:
+		c.expr(n.Expression)
+		if c.fn == nil {
+			c.errorf(n.Position(), "yield outside of a function")
+		}
+	default:
+		c.expr(n)
+	}
+}
+
+func (c *checker) expr(n golan.Node) Type {
+	switch n := n.(type) {
+	case *golan.BooleanLiteral:
+		return Bool
+	case *golan.IntLiteral:
+		return Int
+	case *golan.FloatLiteral:
+		return Float
+	case *golan.StringLiteral:
+		return String
+	case *golan.Identifier:
+		if v := c.env.lookup(n.Name); v != nil {
+			return v.typ
+		}
+		return Any
+	case *golan.Assign:
+		t := c.expr(n.Expression)
+		if a, ok := n.Destination.(*golan.Attribute); ok {
+			c.setAttribute(a)
+			return t
+		}
+		declared := c.annotation(n.Position(), n.Type)
+		c.bind(n.Position(), n.Destination.(*golan.Identifier).Name, t, declared)
+		return t
+	case *golan.Function:
+		return c.function(n)
+	case *golan.Apply:
+		return c.apply(n)
+	case *golan.Spawn:
+		c.expr(n.Call)
+		return Task
+	case *golan.Attribute:
+		return c.attribute(n)
+	case *golan.Index:
+		return c.index(n)
+	case *golan.ListLiteral:
+		for _, x := range n.Elements {
+			c.expr(x)
+		}
+		return List
+	case *golan.Equal:
+		return c.equality("==", n.Position(), n.Left, n.Right)
+	case *golan.NotEqual:
+		return c.equality("!=", n.Position(), n.Left, n.Right)
+	case *golan.GreaterThanEqual:
+		return c.ordering(">=", n.Position(), n.Left, n.Right)
+	case *golan.LessThanEqual:
+		return c.ordering("<=", n.Position(), n.Left, n.Right)
+	case *golan.GreaterThan:
+		return c.ordering(">", n.Position(), n.Left, n.Right)
+	case *golan.LessThan:
+		return c.ordering("<", n.Position(), n.Left, n.Right)
+	case *golan.In:
+		return c.membership(n)
+	case *golan.RangeLiteral:
+		for _, x := range []golan.Node{n.Start, n.Stop} {
+			if t := c.expr(x); concrete(t) && t != Int && t != Float {
+				c.errorf(x.Position(), "range bound must be int or float, not %v", t)
+			}
+		}
+		return Range
+	case *golan.Addition:
+		return c.arith("+", n.Position(), n.Left, n.Right, Int, Float, String)
+	case *golan.Subtraction:
+		return c.arith("-", n.Position(), n.Left, n.Right, Int, Float)
+	case *golan.Multiplication:
+		return c.arith("*", n.Position(), n.Left, n.Right, Int, Float)
+	case *golan.Division:
+		return c.arith("/", n.Position(), n.Left, n.Right, Int, Float)
+	case *golan.Modulo:
+		return c.arith("%", n.Position(), n.Left, n.Right, Int)
+	case *golan.Plus:
+		return c.sign("+", n.Position(), n.Expression)
+	case *golan.Minus:
+		return c.sign("-", n.Position(), n.Expression)
+	case *golan.Not:
+		c.expr(n.Expression)
+		return Bool
+	}
+	c.statement(n)
+	return Undefined
+}
+
+func oneOf(t Type, types []Basic) bool {
+	for _, x := range types {
+		if t == x {
+			return true
+		}
+	}
+	return false
+}
+
+// arith checks a binary arithmetic operator defined on operands of the
+// same type among types.
+func (c *checker) arith(op string, p *golan.Position, left golan.Node, right golan.Node, types ...Basic) Type {
+	l, r := c.expr(left), c.expr(right)
+	if k, ok := l.(*Class); ok {
+		return c.operator(k, binaryMethods[op], op, p)
+	}
+	if concrete(l) && !oneOf(l, types) {
+		c.errorf(p, "operator %s not defined on %v", op, l)
+		return Any
+	}
+	if !concrete(l) || !concrete(r) {
+		return Any
+	}
+	if l != r {
+		c.errorf(p, "mismatched types %v and %v for %s", l, r, op)
+		return Any
+	}
+	return l
+}
+
+func (c *checker) sign(op string, p *golan.Position, x golan.Node) Type {
+	t := c.expr(x)
+	if k, ok := t.(*Class); ok {
+		return c.operator(k, unaryMethods[op], "unary "+op, p)
+	}
+	if concrete(t) && t != Int && t != Float {
+		c.errorf(p, "operator unary %s not defined on %v", op, t)
+		return Any
+	}
+	return t
+}
+
+func (c *checker) equality(op string, p *golan.Position, left golan.Node, right golan.Node) Type {
+	l, r := c.expr(left), c.expr(right)
+	if k, ok := l.(*Class); ok && k.Methods["op_cmp"] != nil {
+		return Bool
+	}
+	if concrete(l) && concrete(r) && l.String() != r.String() {
+		c.errorf(p, "mismatched types %v and %v for %s", l, r, op)
+	}
+	return Bool
+}
+
+// ordering checks a comparison operator; only Integer and instances of
+// classes defining op_cmp are ordered.
+func (c *checker) ordering(op string, p *golan.Position, left golan.Node, right golan.Node) Type {
+	l, r := c.expr(left), c.expr(right)
+	if k, ok := l.(*Class); ok {
+		c.operator(k, "op_cmp", op, p)
+		return Bool
+	}
+	if concrete(l) && l != Int {
+		c.errorf(p, "operator %s not defined on %v", op, l)
+	} else if concrete(l) && concrete(r) && r != Int {
+		c.errorf(p, "mismatched types %v and %v for %s", l, r, op)
+	}
+	return Bool
+}
+
+// operator checks an operator on an instance of k, implemented by the
+// method name, and returns the type of its result.
+func (c *checker) operator(k *Class, name string, op string, p *golan.Position) Type {
+	m, ok := k.Methods[name]
+	if !ok {
+		c.errorf(p, "operator %s not defined on %v", op, k)
+		return Any
+	}
+	return m.Result
+}
+
+// class binds the constructor of a class and checks its methods. Methods
+// are not variables, so they are bound in a scope of their own.
+func (c *checker) class(n *golan.Class) {
+	t := &Class{Name: n.Name, Methods: map[string]*Func{}}
+	ctor := &Func{Params: []Type{}, Result: t}
+	for _, m := range n.Methods() {
+		if m.Name != "init" {
+			continue
+		}
+		for _, x := range m.Parameters[1:] {
+			pt, ok := annotations[x.Type]
+			if !ok {
+				pt = Any
+			}
+			ctor.Params = append(ctor.Params, pt)
+		}
+		ctor.Min = len(ctor.Params)
+	}
+	c.bind(n.Position(), n.Name, ctor, nil)
+
+	outer := c.env
+	c.env = &env{vars: map[string]*variable{}, parent: outer}
+	for _, m := range n.Methods() {
+		f := c.function(m).(*Func)
+		t.Methods[m.Name] = &Func{Params: f.Params[1:], Min: f.Min - 1, Result: f.Result}
+	}
+	c.env = outer
+}
+
+func (c *checker) function(n *golan.Function) Type {
+	f := &Func{Params: []Type{}, Min: len(n.Parameters), Result: Any}
+	for _, x := range n.Parameters {
+		t := c.annotation(x.Position(), x.Type)
+		if t == nil {
+			t = Any
+		}
+		f.Params = append(f.Params, t)
+	}
+	generator := golan.IsGenerator(n)
+	if t := c.annotation(n.Position(), n.ReturnType); t != nil {
+		f.Result = t
+		if generator && !assignable(t, Generator) {
+			c.errorf(n.Position(), "generator function cannot return %v", t)
+		}
+	}
+	if generator {
+		f.Result = Generator
+	}
+	if n.Name != "" {
+		c.bind(n.Position(), n.Name, f, nil)
+	}
+
+	outer, fn := c.env, c.fn
+	c.env = &env{vars: map[string]*variable{}, parent: outer}
+	c.fn = &function{result: f.Result, generator: generator}
+	for i, x := range n.Parameters {
+		c.env.vars[x.Name] = &variable{typ: f.Params[i], declared: x.Type != ""}
+	}
+	c.statement(n.Body)
+	if !generator && !assignable(f.Result, Undefined) && !terminates(n.Body) {
+		c.errorf(n.Position(), "missing return at end of function returning %v", f.Result)
+	}
+	c.env, c.fn = outer, fn
+	return f
+}
+
+// terminates reports whether executing n always ends in return or raise.
+func terminates(n golan.Node) bool {
+	switch n := n.(type) {
+	case *golan.Block:
+		s := n.Statements()
+		return len(s) > 0 && terminates(s[len(s)-1])
+	case *golan.Return, *golan.Raise:
+		return true
+	case *golan.If:
+		return n.Alt != nil && terminates(n.Then) && terminates(n.Alt)
+	case *golan.Begin:
+		if n.Ensure != nil && terminates(n.Ensure) {
+			return true
+		}
+		return terminates(n.Body) && (n.Rescue == nil || terminates(n.Rescue))
+	}
+	return false
+}
+
+func (c *checker) apply(n *golan.Apply) Type {
+	callee := c.expr(n.Function())
+	args := []Type{}
+	for _, x := range n.Arguments() {
+		args = append(args, c.expr(x))
+	}
+	f, ok := callee.(*Func)
+	if !ok {
+		if concrete(callee) && callee != AnyFunc {
+			c.errorf(n.Function().Position(), "cannot call %v", callee)
+		}
+		return Any
+	}
+	if len(args) < f.Min || (f.Variadic == nil && len(args) > len(f.Params)) {
+		c.errorf(n.Position(), "wrong number of arguments (given %d, expected %s)", len(args), arity(f))
+		return f.Result
+	}
+	for i, t := range args {
+		want := f.Variadic
+		if i < len(f.Params) {
+			want = f.Params[i]
+		}
+		if !assignable(want, t) {
+			c.errorf(n.Arguments()[i].Position(), "cannot use %v as %v in argument %d", t, want, i+1)
+		}
+	}
+	return f.Result
+}
+
+func arity(f *Func) string {
+	switch {
+	case f.Variadic != nil:
+		return fmt.Sprintf("%d..", f.Min)
+	case f.Min != len(f.Params):
+		return fmt.Sprintf("%d..%d", f.Min, len(f.Params))
+	}
+	return fmt.Sprint(f.Min)
+}
+
+// iteration checks the iterable of a for loop and returns the type of
+// its elements.
+func (c *checker) iteration(n golan.Node) Type {
+	switch t := c.expr(n); t {
+	case String, File:
+		return String
+	case List, Map, Range, Generator, Channel:
+		return Any
+	default:
+		if concrete(t) {
+			c.errorf(n.Position(), "cannot iterate over %v", t)
+		}
+	}
+	return Any
+}
+
+func (c *checker) membership(n *golan.In) Type {
+	x, t := c.expr(n.Left), c.expr(n.Right)
+	switch t {
+	case String:
+		if concrete(x) && x != String {
+			c.errorf(n.Position(), "mismatched types %v and %v for in", x, t)
+		}
+	case List, Map, Range:
+	default:
+		if concrete(t) {
+			c.errorf(n.Right.Position(), "operator in not defined on %v", t)
+		}
+	}
+	return Bool
+}
+
+func (c *checker) index(n *golan.Index) Type {
+	t := c.expr(n.Receiver)
+	k := c.expr(n.Key)
+	switch t {
+	case String, List, Range:
+		if concrete(k) && k != Int {
+			c.errorf(n.Key.Position(), "cannot index %v with %v", t, k)
+		}
+		if t == String {
+			return String
+		}
+	case Map:
+	default:
+		if concrete(t) {
+			c.errorf(n.Position(), "cannot index %v", t)
+		}
+	}
+	return Any
+}
+
+func (c *checker) attribute(n *golan.Attribute) Type {
+	t := c.expr(n.Receiver)
+	switch t {
+	case Error:
+		switch n.Name {
+		case "kind", "message":
+			return String
+		case "position":
+			return Union{String, Undefined}
+		}
+		c.errorf(n.Position(), "error has no attribute %s", n.Name)
+		return Any
+	case File:
+		switch n.Name {
+		case "name":
+			return String
+		case "closed":
+			return Bool
+		}
+		c.errorf(n.Position(), "file has no attribute %s", n.Name)
+		return Any
+	case Task:
+		if n.Name == "done" {
+			return Bool
+		}
+		c.errorf(n.Position(), "task has no attribute %s", n.Name)
+		return Any
+	case Module:
+		return Any
+	}
+	if s, ok := t.(*Struct); ok {
+		if !s.has(n.Name) {
+			c.errorf(n.Position(), "%v has no attribute %s", s, n.Name)
+		}
+		return Any
+	}
+	if k, ok := t.(*Class); ok {
+		if m, ok := k.Methods[n.Name]; ok {
+			return m
+		}
+		return Any
+	}
+	if concrete(t) {
+		c.errorf(n.Position(), "%v has no attributes", t)
+	}
+	return Any
+}
+
+// setAttribute checks the destination of an attribute assignment; only
+// the fields of structs can be assigned.
+func (c *checker) setAttribute(n *golan.Attribute) {
+	t := c.expr(n.Receiver)
+	if s, ok := t.(*Struct); ok {
+		if !s.has(n.Name) {
+			c.errorf(n.Position(), "%v has no attribute %s", s, n.Name)
+		}
+		return
+	}
+	if _, ok := t.(*Class); ok {
+		return
+	}
+	if concrete(t) {
+		c.errorf(n.Position(), "cannot assign to attribute %s of %v", n.Name, t)
+	}
+}
@@ -0,0 +1,68 @@
+package typecheck
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"well typed", "x: int = 1\ny = x + 2\nprint(y)\n", []string{}},
+		{"unknown type", "x: integer = 1\n", []string{"1:1: unknown type - integer"}},
+		{"annotated assign", "x: int = \"a\"\n", []string{"1:1: cannot assign string to x of type int"}},
+		{"reassign", "x: int = 1\nx = \"a\"\n", []string{"2:1: cannot assign string to x of type int"}},
+		{"redeclared", "x: int = 1\nx: string = \"a\"\n", []string{"2:1: x redeclared as string, previously int"}},
+		{"unannotated becomes any", "x = 1\nx = \"a\"\ny = x + 1\n", []string{}},
+		{"select on int", "select {\ncase v = recv(1) {\n  print(v)\n}\n}\n", []string{"2:15: cannot select on int"}},
+		{"raise int", "raise 1\n", []string{"1:7: exception must be a string or an error, not int"}},
+		{"return from generator", "func g() {\n  yield 1\n  return 2\n}\n", []string{"3:3: cannot return a value from a generator"}},
+		{"return type", "func f(): int {\n  return \"a\"\n}\n", []string{"2:3: cannot return string from function returning int"}},
+		{"yield outside function", "yield 1\n", []string{"1:1: yield outside of a function"}},
+		{"range bound", "r = \"a\"..3\n", []string{"1:6: range bound must be int or float, not string"}},
+		{"binary operator", "x = [1] - [2]\n", []string{"1:5: operator - not defined on list"}},
+		{"mismatched", "x = 1 + \"a\"\n", []string{"1:5: mismatched types int and string for +"}},
+		{"unary operator", "x = -\"a\"\n", []string{"1:5: operator unary - not defined on string"}},
+		{"comparison", "x = 1 < \"a\"\n", []string{"1:5: mismatched types int and string for <"}},
+		{"generator result", "func g(): int {\n  yield 1\n}\n", []string{"1:1: generator function cannot return int"}},
+		{"missing return", "func f(): int {\n  print(1)\n}\n", []string{"1:1: missing return at end of function returning int"}},
+		{"call non-function", "x = 1\nx()\n", []string{"2:1: cannot call int"}},
+		{"arity", "func f(a) {\n  return a\n}\nf(1, 2)\n", []string{"4:1: wrong number of arguments (given 2, expected 1)"}},
+		{"argument type", "func f(a: int) {\n  return a\n}\nf(\"a\")\n", []string{"4:4: cannot use string as int in argument 1"}},
+		{"iterate", "for x in 1 {\n  print(x)\n}\n", []string{"1:10: cannot iterate over int"}},
+		{"in", "x = 1 in 2\n", []string{"1:10: operator in not defined on int"}},
+		{"index key", "x = [1][\"a\"]\n", []string{"1:10: cannot index list with string"}},
+		{"index", "x = 1[0]\n", []string{"1:5: cannot index int"}},
+		{"error attribute", "e = error(\"a\")\nprint(e.foo)\n", []string{"2:7: error has no attribute foo"}},
+		{"no attributes", "x = 1\nprint(x.foo)\n", []string{"2:7: int has no attributes"}},
+		{"struct attribute", "struct P { x }\np = P(1)\nprint(p.y)\n", []string{"3:7: P has no attribute y"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := golan.Parse(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, e := range Check(tree) {
+				if e.Kind != "TypeError" {
+					t.Errorf("error %v has kind %s", e, e.Kind)
+				}
+				got = append(got, fmt.Sprintf("%d:%d: %s", e.Position.FirstLineno, e.Position.FirstColumn, e.Message))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("errors = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("errors[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
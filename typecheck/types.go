@@ -0,0 +1,219 @@
+package typecheck
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arikui1911/golan"
+)
+
+// Type is the static type of an expression.
+type Type interface {
+	String() string
+}
+
+// Basic is a type named by an annotation.
+type Basic string
+
+func (t Basic) String() string { return string(t) }
+
+// The types of the values in value.go, as written in annotations.
+const (
+	Any       Basic = "any"
+	Int       Basic = "int"
+	Float     Basic = "float"
+	String    Basic = "string"
+	Bool      Basic = "bool"
+	Undefined Basic = "undefined"
+	Error     Basic = "error"
+	Module    Basic = "module"
+	File      Basic = "file"
+	List      Basic = "list"
+	Map       Basic = "map"
+	Range     Basic = "range"
+	Generator Basic = "generator"
+	Task      Basic = "task"
+	Channel   Basic = "channel"
+	AnyFunc   Basic = "func"
+)
+
+var annotations = map[string]Type{}
+
+func init() {
+	for _, t := range []Basic{Any, Int, Float, String, Bool, Undefined, Error, Module, File, List, Map, Range, Generator, Task, Channel, AnyFunc} {
+		annotations[string(t)] = t
+	}
+}
+
+// Func is the type of a function with known parameters. The last
+// len(Params)-Min parameters are optional; Variadic, if not nil, is the
+// type of any further arguments.
+type Func struct {
+	Params   []Type
+	Min      int
+	Variadic Type
+	Result   Type
+}
+
+func (f *Func) String() string {
+	params := []string{}
+	for i, p := range f.Params {
+		s := p.String()
+		if i >= f.Min {
+			s += "?"
+		}
+		params = append(params, s)
+	}
+	if f.Variadic != nil {
+		params = append(params, f.Variadic.String()+"...")
+	}
+	return "func(" + strings.Join(params, ", ") + "): " + f.Result.String()
+}
+
+// Struct is the type of the instances of a struct declaration.
+type Struct struct {
+	Name   string
+	Fields []string
+}
+
+func (s *Struct) String() string { return s.Name }
+
+func (s *Struct) has(field string) bool {
+	for _, f := range s.Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Class is the type of the instances of a class. Methods are typed
+// without their instance parameter.
+type Class struct {
+	Name    string
+	Methods map[string]*Func
+}
+
+func (c *Class) String() string { return c.Name }
+
+// The methods implementing the operators on instances.
+var (
+	binaryMethods = map[string]string{"+": "op_add", "-": "op_sub", "*": "op_mul", "/": "op_div", "%": "op_mod"}
+	unaryMethods  = map[string]string{"+": "op_plus", "-": "op_minus"}
+)
+
+// Union is a value of any one of its types.
+type Union []Type
+
+func (u Union) String() string {
+	s := []string{}
+	for _, t := range u {
+		s = append(s, t.String())
+	}
+	return strings.Join(s, " or ")
+}
+
+// builtin returns the type of a binding of golan.NewEngine.
+func builtin(name string) Type {
+	s, ok := golan.BuiltinType(name)
+	if !ok {
+		return Any
+	}
+	t, err := parseType(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// parseType reads a type written as String writes it.
+func parseType(s string) (Type, error) {
+	if !strings.HasPrefix(s, "func(") {
+		return parseUnion(s)
+	}
+	i := strings.LastIndex(s, "): ")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid type - %q", s)
+	}
+	f := &Func{}
+	r, err := parseUnion(s[i+3:])
+	if err != nil {
+		return nil, err
+	}
+	f.Result = r
+	if params := s[len("func("):i]; params != "" {
+		for _, p := range strings.Split(params, ", ") {
+			switch {
+			case strings.HasSuffix(p, "..."):
+				f.Variadic, err = parseUnion(strings.TrimSuffix(p, "..."))
+			case strings.HasSuffix(p, "?"):
+				var t Type
+				t, err = parseUnion(strings.TrimSuffix(p, "?"))
+				f.Params = append(f.Params, t)
+			default:
+				var t Type
+				t, err = parseUnion(p)
+				f.Params = append(f.Params, t)
+				f.Min = len(f.Params)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return f, nil
+}
+
+func parseUnion(s string) (Type, error) {
+	u := Union{}
+	for _, name := range strings.Split(s, " or ") {
+		t, ok := annotations[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown type - %q", name)
+		}
+		u = append(u, t)
+	}
+	if len(u) == 1 {
+		return u[0], nil
+	}
+	return u, nil
+}
+
+// assignable reports whether a value of type src may be used where dst is
+// expected.
+func assignable(dst Type, src Type) bool {
+	if dst == Any || src == Any || dst.String() == src.String() {
+		return true
+	}
+	if u, ok := src.(Union); ok {
+		for _, t := range u {
+			if !assignable(dst, t) {
+				return false
+			}
+		}
+		return true
+	}
+	switch d := dst.(type) {
+	case Union:
+		for _, t := range d {
+			if assignable(t, src) {
+				return true
+			}
+		}
+	case Basic:
+		if _, ok := src.(*Func); ok && d == AnyFunc {
+			return true
+		}
+	}
+	return false
+}
+
+// concrete reports whether t names a single known type.
+func concrete(t Type) bool {
+	switch t {
+	case Any:
+		return false
+	}
+	_, ok := t.(Union)
+	return !ok
+}
@@ -3,10 +3,14 @@ package golan
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"unicode/utf8"
 )
 
 type Value interface{}
 
+var ErrDivideByZero = errors.New("divide by zero")
+
 func ValueTest(val Value) bool {
 	switch v := val.(type) {
 	case Boolean:
@@ -24,6 +28,8 @@ const (
 	CMP_LESS
 	CMP_GREATER
 	CMP_INVALID
+	// CMP_NE tells values that differ but have no order between them.
+	CMP_NE
 )
 
 type ComparableValue interface {
@@ -31,7 +37,17 @@ type ComparableValue interface {
 	OpCmp(Value) CompareResult
 }
 
+// FallibleComparableValue is a value compared by code that can fail, such
+// as a script method.
+type FallibleComparableValue interface {
+	Value
+	OpCmpErr(Value) (CompareResult, error)
+}
+
 func CompareValues(x Value, y Value) (CompareResult, error) {
+	if a, ok := x.(FallibleComparableValue); ok {
+		return a.OpCmpErr(y)
+	}
 	if a, ok := x.(ComparableValue); ok {
 		r := a.OpCmp(y)
 		if r != CMP_INVALID {
@@ -102,6 +118,108 @@ type SignableValue interface {
 	OpMinus() (Value, error)
 }
 
+type AttributeValue interface {
+	Value
+	OpGetAttr(name string) (Value, error)
+}
+
+func GetAttribute(x Value, name string) (Value, error) {
+	if a, ok := x.(AttributeValue); ok {
+		return a.OpGetAttr(name)
+	}
+	return nil, fmt.Errorf("not an attribute-accessible value - %v(%T)", x, x)
+}
+
+type AttributeSettableValue interface {
+	Value
+	OpSetAttr(name string, v Value) error
+}
+
+func SetAttribute(x Value, name string, v Value) error {
+	if a, ok := x.(AttributeSettableValue); ok {
+		return a.OpSetAttr(name, v)
+	}
+	return fmt.Errorf("not an attribute-settable value - %v(%T)", x, x)
+}
+
+// CallableValue is a value other than a function that can be applied to
+// arguments, such as the constructor of a struct.
+type CallableValue interface {
+	Value
+	OpCall(e *Engine, args []Value) (Value, error)
+}
+
+type IndexableValue interface {
+	Value
+	OpIndex(key Value) (Value, error)
+}
+
+func GetIndex(x Value, key Value) (Value, error) {
+	if a, ok := x.(IndexableValue); ok {
+		return a.OpIndex(key)
+	}
+	return nil, fmt.Errorf("not an indexable value - %v(%T)", x, x)
+}
+
+type ContainerValue interface {
+	Value
+	OpContains(x Value) (bool, error)
+}
+
+// Contains reports whether x is an element of the container y, as tested
+// by `x in y`.
+func Contains(y Value, x Value) (bool, error) {
+	if c, ok := y.(ContainerValue); ok {
+		return c.OpContains(x)
+	}
+	return false, fmt.Errorf("not a container value - %v(%T)", y, y)
+}
+
+type SizedValue interface {
+	Value
+	OpLen() int
+}
+
+// Iterator produces the elements of an iteration one at a time. Next
+// returns false when there are no more elements.
+type Iterator interface {
+	Next() (Value, bool, error)
+}
+
+// IteratorCloser is an Iterator holding resources, such as the goroutine
+// of a generator, to release when its consumer stops before the end. For
+// loops call Close when they are left early or an element fails.
+type IteratorCloser interface {
+	Iterator
+	Close() error
+}
+
+func closeIterator(it Iterator) error {
+	if c, ok := it.(IteratorCloser); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// IteratorFunc adapts a function to the Iterator interface.
+type IteratorFunc func() (Value, bool, error)
+
+func (f IteratorFunc) Next() (Value, bool, error) { return f() }
+
+// IterableValue is a value for loops can iterate over. Native values
+// implement it to become iterable in scripts.
+type IterableValue interface {
+	Value
+	OpIter() (Iterator, error)
+}
+
+func Iterate(x Value) (Iterator, error) {
+	if a, ok := x.(IterableValue); ok {
+		return a.OpIter()
+	}
+	return nil, fmt.Errorf("not an iterable value - %v(%T)", x, x)
+}
+
 type Undefined struct{}
 
 func (Undefined) String() string { return "#<undefined>" }
@@ -159,7 +277,7 @@ func (x Integer) OpDiv(other Value) (Value, error) {
 		return nil, fmt.Errorf("not a Integer - %v(%T)", other, other)
 	}
 	if y == 0 {
-		return nil, errors.New("divide by zero")
+		return nil, ErrDivideByZero
 	}
 	return x / y, nil
 }
@@ -170,7 +288,7 @@ func (x Integer) OpMod(other Value) (Value, error) {
 		return nil, fmt.Errorf("not a Integer - %v(%T)", other, other)
 	}
 	if y == 0 {
-		return nil, errors.New("divide by zero")
+		return nil, ErrDivideByZero
 	}
 	return x % y, nil
 }
@@ -211,7 +329,7 @@ func (x Float) OpDiv(other Value) (Value, error) {
 		return nil, fmt.Errorf("not a Float - %v(%T)", other, other)
 	}
 	if y == 0 {
-		return nil, errors.New("divide by zero")
+		return nil, ErrDivideByZero
 	}
 	return x / y, nil
 }
@@ -230,9 +348,100 @@ func (x String) OpAdd(other Value) (Value, error) {
 	return x + y, nil
 }
 
+// OpLen counts the runes of x, as OpIndex and OpIter do.
+func (x String) OpLen() int { return utf8.RuneCountInString(string(x)) }
+
+// OpContains reports whether the String x is a substring.
+func (s String) OpContains(x Value) (bool, error) {
+	t, ok := x.(String)
+	if !ok {
+		return false, fmt.Errorf("not a String - %v(%T)", x, x)
+	}
+	return strings.Contains(string(s), string(t)), nil
+}
+
+// OpIter iterates over the runes of x.
+func (x String) OpIter() (Iterator, error) {
+	rs := []rune(string(x))
+	i := 0
+	return IteratorFunc(func() (Value, bool, error) {
+		if i >= len(rs) {
+			return nil, false, nil
+		}
+		i++
+		return String(rs[i-1]), true, nil
+	}), nil
+}
+
+// OpIndex returns the rune at an index counted in runes; negative indices
+// count from the end.
+func (x String) OpIndex(key Value) (Value, error) {
+	rs := []rune(string(x))
+	i, err := sequenceIndex(key, len(rs))
+	if err != nil {
+		return nil, err
+	}
+	return String(rs[i]), nil
+}
+
 type NativeFunction func(*Engine, []Value) (Value, error)
 
 type NativeValueHandle struct {
 	Info  string
 	Value any
 }
+
+// Error is an exception value. Errors raised by scripts, by the engine and
+// by native functions are all surfaced as *Error so that they can be
+// rescued.
+type Error struct {
+	Kind     string
+	Message  string
+	Position *Position
+}
+
+func (e *Error) Error() string {
+	if e.Position == nil {
+		return fmt.Sprintf("%s: %s", e.Kind, e.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.Position, e.Kind, e.Message)
+}
+
+func (e *Error) String() string { return fmt.Sprintf("#<%s: %s>", e.Kind, e.Message) }
+
+func (e *Error) OpGetAttr(name string) (Value, error) {
+	switch name {
+	case "kind":
+		return String(e.Kind), nil
+	case "message":
+		return String(e.Message), nil
+	case "position":
+		if e.Position == nil {
+			return Undefined{}, nil
+		}
+		return String(e.Position.String()), nil
+	}
+	return nil, fmt.Errorf("undefined attribute - %s", name)
+}
+
+func newError(p *Position, kind string, format string, args ...any) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...), Position: p}
+}
+
+// errorAt converts err to *Error. An *Error without position is located
+// at p; any other error becomes a RuntimeError at p.
+func errorAt(err error, kind string, p *Position) *Error {
+	var x *Error
+	if errors.As(err, &x) {
+		if x.Position != nil {
+			return x
+		}
+		c := *x
+		c.Position = p
+		return &c
+	}
+	if errors.Is(err, ErrDivideByZero) {
+		kind = "ZeroDivisionError"
+	}
+	return &Error{Kind: kind, Message: err.Error(), Position: p}
+}
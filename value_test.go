@@ -0,0 +1,17 @@
+package golan_test
+
+import "testing"
+
+func TestStringRunes(t *testing.T) {
+	expectOutput(t, `
+s = "añb日"
+print(len(s))
+print(s[len(s) - 1])
+print(s[1])
+n = 0
+for c in s {
+  n += 1
+}
+print(n == len(s))
+`, "4\n日\nñ\ntrue\n")
+}
@@ -0,0 +1,290 @@
+package golan
+
+// A Visitor's Visit method is invoked for each node encountered by Walk.
+// If the result visitor w is not nil, Walk visits each of the children of
+// node with w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+// Walk traverses an AST in depth-first order.
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+	for _, c := range Children(node) {
+		Walk(v, c)
+	}
+	v.Visit(nil)
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses an AST in depth-first order, calling f(node) for each
+// node and f(nil) after its children. Children are skipped when f returns
+// false.
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
+
+func (b *Block) Statements() []Node { return b.statements }
+
+func (a *Apply) Function() Node { return a.function }
+
+func (a *Apply) Arguments() []Node { return a.arguments }
+
+// Children returns the direct child nodes of node in source order.
+func Children(node Node) []Node {
+	switch n := node.(type) {
+	case *Block:
+		return append([]Node{}, n.statements...)
+	case *While:
+		return []Node{n.Condition, n.Body}
+	case *For:
+		return []Node{n.Variable, n.Iterable, n.Body}
+	case *Select:
+		r := []Node{}
+		for _, c := range n.Cases {
+			r = append(r, c)
+		}
+		return append(r, compactNodes(n.Default)...)
+	case *SelectCase:
+		return compactNodes(n.Variable, n.Channel, n.Value, n.Body)
+	case *If:
+		return compactNodes(n.Test, n.Then, n.Alt)
+	case *Begin:
+		return compactNodes(n.Body, n.Variable, n.Rescue, n.Ensure)
+	case *Raise:
+		return []Node{n.Expression}
+	case *Class:
+		return []Node{n.Body}
+	case *Function:
+		r := []Node{}
+		for _, x := range n.Parameters {
+			r = append(r, x)
+		}
+		return append(r, n.Body)
+	case *Return:
+		return compactNodes(n.Expression)
+	case *Spawn:
+		return []Node{n.Call}
+	case *Yield:
+		return []Node{n.Expression}
+	case *Assign:
+		return []Node{n.Destination, n.Expression}
+	case *Equal:
+		return []Node{n.Left, n.Right}
+	case *NotEqual:
+		return []Node{n.Left, n.Right}
+	case *GreaterThanEqual:
+		return []Node{n.Left, n.Right}
+	case *LessThanEqual:
+		return []Node{n.Left, n.Right}
+	case *GreaterThan:
+		return []Node{n.Left, n.Right}
+	case *LessThan:
+		return []Node{n.Left, n.Right}
+	case *In:
+		return []Node{n.Left, n.Right}
+	case *RangeLiteral:
+		return []Node{n.Start, n.Stop}
+	case *Addition:
+		return []Node{n.Left, n.Right}
+	case *Subtraction:
+		return []Node{n.Left, n.Right}
+	case *Multiplication:
+		return []Node{n.Left, n.Right}
+	case *Division:
+		return []Node{n.Left, n.Right}
+	case *Modulo:
+		return []Node{n.Left, n.Right}
+	case *Plus:
+		return []Node{n.Expression}
+	case *Minus:
+		return []Node{n.Expression}
+	case *Not:
+		return []Node{n.Expression}
+	case *Apply:
+		return append([]Node{n.function}, n.arguments...)
+	case *Attribute:
+		return []Node{n.Receiver}
+	case *ListLiteral:
+		return append([]Node{}, n.Elements...)
+	case *Index:
+		return []Node{n.Receiver, n.Key}
+	}
+	return nil
+}
+
+func compactNodes(nodes ...Node) []Node {
+	r := []Node{}
+	for _, n := range nodes {
+		if n != nil {
+			r = append(r, n)
+		}
+	}
+	return r
+}
+
+// rekey moves the comments of the statement old to new, which replaces
+// it, or drops them when new is nil.
+func (b *Block) rekey(old Node, new Node) {
+	if c, ok := b.leading[old]; ok {
+		delete(b.leading, old)
+		if new != nil {
+			b.leading[new] = c
+		}
+	}
+	if c, ok := b.trailing[old]; ok {
+		delete(b.trailing, old)
+		if new != nil {
+			b.trailing[new] = c
+		}
+	}
+}
+
+// rewriteTarget rewrites a node assigned to, keeping it unless f returns
+// an Identifier, or an Attribute when attributes is true.
+func rewriteTarget(node Node, f func(Node) Node, attributes bool) Node {
+	if node == nil {
+		return nil
+	}
+	switch r := Rewrite(node, f).(type) {
+	case *Identifier:
+		return r
+	case *Attribute:
+		if attributes {
+			return r
+		}
+	}
+	return node
+}
+
+// Rewrite replaces every node of the tree, bottom up, with the result of
+// f. Children are updated in place; the result of f for the root is
+// returned. Parameters and select cases can only be replaced by nodes of
+// the same type, and variables assigned to by identifiers, or attributes
+// for the destination of an assignment; any other result for them is
+// ignored. A statement replaced by nil is removed. Comments of a replaced
+// statement move to its replacement.
+func Rewrite(node Node, f func(Node) Node) Node {
+	if node == nil {
+		return nil
+	}
+	switch n := node.(type) {
+	case *Block:
+		statements := n.statements[:0]
+		for _, s := range n.statements {
+			r := Rewrite(s, f)
+			if r != s {
+				n.rekey(s, r)
+			}
+			if r != nil {
+				statements = append(statements, r)
+			}
+		}
+		n.statements = statements
+	case *While:
+		n.Condition = Rewrite(n.Condition, f)
+		n.Body = Rewrite(n.Body, f)
+	case *For:
+		n.Variable = rewriteTarget(n.Variable, f, false)
+		n.Iterable = Rewrite(n.Iterable, f)
+		n.Body = Rewrite(n.Body, f)
+	case *If:
+		n.Test = Rewrite(n.Test, f)
+		n.Then = Rewrite(n.Then, f)
+		n.Alt = Rewrite(n.Alt, f)
+	case *Begin:
+		n.Body = Rewrite(n.Body, f)
+		n.Variable = rewriteTarget(n.Variable, f, false)
+		n.Rescue = Rewrite(n.Rescue, f)
+		n.Ensure = Rewrite(n.Ensure, f)
+	case *Raise:
+		n.Expression = Rewrite(n.Expression, f)
+	case *Class:
+		n.Body = Rewrite(n.Body, f)
+	case *Function:
+		for i, x := range n.Parameters {
+			if p, ok := Rewrite(x, f).(*Parameter); ok {
+				n.Parameters[i] = p
+			}
+		}
+		n.Body = Rewrite(n.Body, f)
+	case *Return:
+		n.Expression = Rewrite(n.Expression, f)
+	case *Spawn:
+		n.Call = Rewrite(n.Call, f)
+	case *Select:
+		for i, c := range n.Cases {
+			if x, ok := Rewrite(c, f).(*SelectCase); ok {
+				n.Cases[i] = x
+			}
+		}
+		n.Default = Rewrite(n.Default, f)
+	case *SelectCase:
+		n.Variable = rewriteTarget(n.Variable, f, false)
+		n.Channel = Rewrite(n.Channel, f)
+		n.Value = Rewrite(n.Value, f)
+		n.Body = Rewrite(n.Body, f)
+	case *Yield:
+		n.Expression = Rewrite(n.Expression, f)
+	case *Assign:
+		n.Destination = rewriteTarget(n.Destination, f, true)
+		n.Expression = Rewrite(n.Expression, f)
+	case *Equal:
+		n.Left, n.Right = Rewrite(n.Left, f), Rewrite(n.Right, f)
+	case *NotEqual:
+		n.Left, n.Right = Rewrite(n.Left, f), Rewrite(n.Right, f)
+	case *GreaterThanEqual:
+		n.Left, n.Right = Rewrite(n.Left, f), Rewrite(n.Right, f)
+	case *LessThanEqual:
+		n.Left, n.Right = Rewrite(n.Left, f), Rewrite(n.Right, f)
+	case *GreaterThan:
+		n.Left, n.Right = Rewrite(n.Left, f), Rewrite(n.Right, f)
+	case *LessThan:
+		n.Left, n.Right = Rewrite(n.Left, f), Rewrite(n.Right, f)
+	case *In:
+		n.Left, n.Right = Rewrite(n.Left, f), Rewrite(n.Right, f)
+	case *RangeLiteral:
+		n.Start, n.Stop = Rewrite(n.Start, f), Rewrite(n.Stop, f)
+	case *Addition:
+		n.Left, n.Right = Rewrite(n.Left, f), Rewrite(n.Right, f)
+	case *Subtraction:
+		n.Left, n.Right = Rewrite(n.Left, f), Rewrite(n.Right, f)
+	case *Multiplication:
+		n.Left, n.Right = Rewrite(n.Left, f), Rewrite(n.Right, f)
+	case *Division:
+		n.Left, n.Right = Rewrite(n.Left, f), Rewrite(n.Right, f)
+	case *Modulo:
+		n.Left, n.Right = Rewrite(n.Left, f), Rewrite(n.Right, f)
+	case *Plus:
+		n.Expression = Rewrite(n.Expression, f)
+	case *Minus:
+		n.Expression = Rewrite(n.Expression, f)
+	case *Not:
+		n.Expression = Rewrite(n.Expression, f)
+	case *Apply:
+		n.function = Rewrite(n.function, f)
+		for i, x := range n.arguments {
+			n.arguments[i] = Rewrite(x, f)
+		}
+	case *Attribute:
+		n.Receiver = Rewrite(n.Receiver, f)
+	case *ListLiteral:
+		for i, x := range n.Elements {
+			n.Elements[i] = Rewrite(x, f)
+		}
+	case *Index:
+		n.Receiver = Rewrite(n.Receiver, f)
+		n.Key = Rewrite(n.Key, f)
+	}
+	return f(node)
+}
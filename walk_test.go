@@ -0,0 +1,80 @@
+package golan_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arikui1911/golan"
+)
+
+func TestRewriteKeepsComments(t *testing.T) {
+	tree, err := golan.Parse("# leading\nx = 1 # trailing\nprint(x)\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The replacement is on line 2 as the statement it replaces is, so
+	// that the formatter puts no blank line after it.
+	replacement, err := golan.Parse("\nx = 2\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := tree.(*golan.Block).Statements()[0]
+	tree = golan.Rewrite(tree, func(n golan.Node) golan.Node {
+		if n == old {
+			return replacement.(*golan.Block).Statements()[0]
+		}
+		return n
+	})
+	var out bytes.Buffer
+	if err := golan.Fprint(&out, tree); err != nil {
+		t.Fatal(err)
+	}
+	if want := "# leading\nx = 2 # trailing\nprint(x)\n"; out.String() != want {
+		t.Errorf("formatted = %q, want %q", out.String(), want)
+	}
+}
+
+func TestRewriteMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(golan.Node) golan.Node
+		want string
+	}{
+		{"nil statement", func(n golan.Node) golan.Node {
+			if a, ok := n.(*golan.Apply); ok && a.Position().FirstLineno == 2 {
+				return nil
+			}
+			return n
+		}, "1\n"},
+		{"literal destination", func(n golan.Node) golan.Node {
+			if id, ok := n.(*golan.Identifier); ok && id.Name == "x" && id.Position().FirstLineno == 1 {
+				return &golan.IntLiteral{Value: 3}
+			}
+			return n
+		}, "1\n1\n"},
+		{"nil expression", func(n golan.Node) golan.Node {
+			if _, ok := n.(*golan.IntLiteral); ok {
+				return nil
+			}
+			return n
+		}, ""},
+	}
+	for _, tt := range tests {
+		tree, err := golan.Parse("x = 1\nprint(x)\nprint(x)\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		tree = golan.Rewrite(tree, tt.f)
+		var out bytes.Buffer
+		_, err = golan.NewEngine(golan.WithOutput(&out)).Execute(tree)
+		if tt.want == "" {
+			if x, ok := err.(*golan.Error); !ok || x.Kind != "RuntimeError" {
+				t.Errorf("%s: error = %v, want a RuntimeError", tt.name, err)
+			}
+			continue
+		}
+		if err != nil || out.String() != tt.want {
+			t.Errorf("%s: output = %q, %v; want %q", tt.name, out.String(), err, tt.want)
+		}
+	}
+}